@@ -15,8 +15,10 @@ var (
 )
 
 func main() {
-	if err := cli.Run(strings.TrimSuffix(version, "\n"), os.Args...); err != nil {
+	code, err := cli.Run(strings.TrimSuffix(version, "\n"), os.Args...)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to execute command: %v", err)
-		os.Exit(1)
 	}
+	// The exit code may be nonzero even on success, when it is configured so.
+	os.Exit(code)
 }
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+// actionsOutputEnv is the environment variable set by GitHub Actions to the file which the step outputs are
+// appended to. It is not set outside of GitHub Actions, where the outputs are not written.
+const actionsOutputEnv = "GITHUB_OUTPUT"
+
+// formatActionsOutput formats the result of the gate as the step outputs, so that the later steps can branch on it
+// with steps.<id>.outputs. The validators without job results are counted as a single job named after them.
+func formatActionsOutput(vs []validators.Validator, results *reportResults) ([]byte, error) {
+	var oe *overriddenError
+	succeeded := results.result == nil || errors.As(results.result, &oe)
+
+	var failed []string
+	var pending int
+	for i, v := range vs {
+		st, err := results.statuses[i], results.errs[i]
+		jr := jobResults(st, err)
+		if jr == nil {
+			switch {
+			case err != nil:
+				failed = append(failed, v.Name())
+			case st == nil || !st.IsSuccess():
+				pending++
+			}
+			continue
+		}
+		for _, js := range jr.JobStatuses() {
+			switch js.State {
+			case commitStatusSuccess:
+			case commitStatusFailure, commitStatusError:
+				failed = append(failed, js.Job)
+			default:
+				pending++
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "succeeded=%t\n", succeeded)
+	fmt.Fprintf(&b, "pending_count=%d\n", pending)
+	fmt.Fprintf(&b, "failed_count=%d\n", len(failed))
+	// The line breaks would end the output, and thus they are replaced.
+	fmt.Fprintf(&b, "failed_jobs=%s\n", strings.NewReplacer("\r", " ", "\n", " ").Replace(strings.Join(failed, ",")))
+	if !results.deadline.IsZero() {
+		fmt.Fprintf(&b, "remaining_wait_seconds=%d\n", int64(remainingWait(results.deadline, results.now)/time.Second))
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+func Test_formatActionsOutput(t *testing.T) {
+	statusValidator := &mock.Validator{NameFunc: func() string { return "status" }}
+	labelValidator := &mock.Validator{NameFunc: func() string { return "labels" }}
+
+	tests := map[string]struct {
+		vs       []validators.Validator
+		statuses []validators.Status
+		errs     []error
+		result   error
+		want     string
+	}{
+		"writes the failed and pending jobs": {
+			vs: []validators.Validator{statusValidator, labelValidator},
+			errs: []error{
+				&jobResultError{jobResultStatus{
+					jobStatuses: []status.JobStatus{
+						{Job: "build", State: "success"},
+						{Job: "e2e", State: "failure"},
+						{Job: "lint", State: "error"},
+						{Job: "docs", State: "pending"},
+					},
+				}},
+				nil,
+			},
+			statuses: []validators.Status{nil, &mock.Status{IsSuccessFunc: func() bool { return false }}},
+			result:   errors.New("err"),
+			want:     "succeeded=false\npending_count=2\nfailed_count=2\nfailed_jobs=e2e,lint\n",
+		},
+		"writes the success": {
+			vs: []validators.Validator{statusValidator, labelValidator},
+			statuses: []validators.Status{
+				&jobResultStatus{
+					Status:      mock.Status{IsSuccessFunc: func() bool { return true }},
+					jobStatuses: []status.JobStatus{{Job: "build", State: "success"}},
+				},
+				&mock.Status{IsSuccessFunc: func() bool { return true }},
+			},
+			errs: []error{nil, nil},
+			want: "succeeded=true\npending_count=0\nfailed_count=0\nfailed_jobs=\n",
+		},
+		"writes the validator without job results as a failed job": {
+			vs:     []validators.Validator{labelValidator},
+			errs:   []error{errors.New("err")},
+			result: errors.New("err"),
+			want:   "succeeded=false\npending_count=0\nfailed_count=1\nfailed_jobs=labels\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			results := &reportResults{
+				statuses: make([]validators.Status, len(tt.vs)),
+				errs:     make([]error, len(tt.vs)),
+				result:   tt.result,
+			}
+			copy(results.statuses, tt.statuses)
+			copy(results.errs, tt.errs)
+			got, err := formatActionsOutput(tt.vs, results)
+			if err != nil {
+				t.Fatalf("formatActionsOutput() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("formatActionsOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fileReporter_write_actionsOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output")
+	if err := os.WriteFile(path, []byte("previous=step\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	vs := []validators.Validator{
+		&mock.Validator{NameFunc: func() string { return "status" }},
+	}
+
+	r := newFileReporter(len(vs), time.Time{}, reportFile{path: path, format: formatActionsOutput, append: true})
+	r.record(0, &jobResultStatus{
+		Status:      mock.Status{IsSuccessFunc: func() bool { return false }},
+		jobStatuses: []status.JobStatus{{Job: "build", State: "pending"}},
+	}, nil)
+	if err := r.write(vs, errors.New("timed out"), time.Now()); err != nil {
+		t.Fatalf("fileReporter.write() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "previous=step\nsucceeded=false\npending_count=1\nfailed_count=0\nfailed_jobs=\n"
+	if string(got) != want {
+		t.Errorf("output file = %q, want %q", got, want)
+	}
+}
@@ -2,27 +2,118 @@ package cli
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
 )
 
 // These variables will be set by command line flags.
 var (
-	ghToken string
+	ghToken     string
+	ghTokenFile string
+	ghUserAgent string
+	ghHeaders   []string
+	ghMaxRPM    int
+	ghAPIURL    string
+
+	ghRetryableStatusCodes string
+)
+
+// ErrInterrupted is returned when the validation is interrupted by a signal, such as CI cancellation.
+var ErrInterrupted = errors.New("validation was interrupted")
+
+// Exit codes returned by Run by default.
+const (
+	ExitCodeSuccess     = 0
+	ExitCodeFailure     = 1
+	ExitCodeInterrupted = 130
 )
 
-func Run(version string, args ...string) error {
+// maxExitCode is the maximum exit code, as only the lowest 8 bits are available to the parent process.
+const maxExitCode = 255
+
+// exitCodeMap maps the terminal outcomes to the exit codes.
+type exitCodeMap struct {
+	success int
+	timeout int
+	failure int
+}
+
+func (m exitCodeMap) validate() error {
+	codes := []struct {
+		outcome string
+		code    int
+	}{
+		{outcome: "success", code: m.success},
+		{outcome: "timeout", code: m.timeout},
+		{outcome: "failure", code: m.failure},
+	}
+	for _, c := range codes {
+		if c.code < 0 || c.code > maxExitCode {
+			return fmt.Errorf("invalid %s exit code %d, must be between 0 and %d", c.outcome, c.code, maxExitCode)
+		}
+		if c.code == ExitCodeInterrupted {
+			return fmt.Errorf("invalid %s exit code %d, it is reserved for interruptions", c.outcome, c.code)
+		}
+	}
+	return nil
+}
+
+// defaultExitCodes are the exit codes returned by Run unless the command sets its own codes.
+var defaultExitCodes = exitCodeMap{
+	success: ExitCodeSuccess,
+	timeout: ExitCodeFailure,
+	failure: ExitCodeFailure,
+}
+
+// exitCode returns the exit code for the error of the command, so that interruptions are distinguished from
+// failures and timeouts. It returns the exit code for the success when the error is nil, which may be nonzero.
+func (m exitCodeMap) exitCode(err error) int {
+	switch {
+	case err == nil:
+		return m.success
+	case errors.Is(err, ErrInterrupted):
+		return ExitCodeInterrupted
+	case errors.Is(err, context.DeadlineExceeded):
+		return m.timeout
+	default:
+		return m.failure
+	}
+}
+
+// Run runs the command, and returns the exit code for its result along with the error. The exit code may be nonzero
+// even when the error is nil, when the command is configured so.
+func Run(version string, args ...string) (int, error) {
+	codes := defaultExitCodes
+
 	cmd := &cobra.Command{
 		Use:     "merge-gatekeeper",
 		Short:   "Get more refined merge control",
 		Version: version,
 	}
 	cmd.PersistentFlags().StringVarP(&ghToken, "token", "t", "", "set github token")
-	cmd.MarkPersistentFlagRequired("token")
+	cmd.PersistentFlags().StringVar(&ghTokenFile, "token-file", "", "set path of the file to read github token from, instead of passing it with --token")
+	cmd.PersistentFlags().StringVar(&ghUserAgent, "user-agent", "", "set custom user agent for github requests")
+	cmd.PersistentFlags().StringArrayVar(&ghHeaders, "header", nil, "set extra header for github requests, e.g. \"X-Correlation-Id: abc\" (can be repeated)")
 
-	cmd.AddCommand(validateCmd())
+	cmd.PersistentFlags().StringVar(&ghAPIURL, "api-url", "", "set url of github api, such as https://github.example.com/api/v3 for github enterprise server")
+	cmd.PersistentFlags().IntVar(&ghMaxRPM, "max-requests-per-minute", 0, "set maximum number of github requests per minute, 0 means unlimited")
+	cmd.PersistentFlags().StringVar(&ghRetryableStatusCodes, "retryable-status-codes", "500,502,503,504", "set status codes of github responses to retry (comma-separated list), empty disables the retries")
+
+	cmd.AddCommand(validateCmd(&codes))
+	cmd.AddCommand(healthCmd())
+	cmd.AddCommand(jobsCmd())
+	cmd.AddCommand(requiredDiffCmd())
+	cmd.AddCommand(sweepCmd())
+	cmd.AddCommand(trainCmd())
 
 	ctx, cancel := signal.NotifyContext(context.Background(),
 		syscall.SIGINT,
@@ -30,8 +121,88 @@ func Run(version string, args ...string) error {
 	)
 	defer cancel()
 
-	if err := cmd.ExecuteContext(ctx); err != nil {
-		return err
+	err := cmd.ExecuteContext(ctx)
+	return codes.exitCode(err), err
+}
+
+func newGitHubClient(ctx context.Context, opts ...github.Option) (github.Client, error) {
+	headers, err := parseHeaders(ghHeaders)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	retryableStatusCodes, err := parseStatusCodes(ghRetryableStatusCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := resolveToken(ghToken, ghTokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]github.Option{
+		github.WithUserAgent(ghUserAgent),
+		github.WithHeaders(headers),
+		github.WithMaxRequestsPerMinute(ghMaxRPM),
+		github.WithBaseURL(ghAPIURL),
+		github.WithRetryableStatusCodes(retryableStatusCodes),
+	}, opts...)
+	c, err := github.NewClient(ctx, token, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github client: %w", err)
+	}
+	return c, nil
+}
+
+// resolveToken returns the token read from the file when the path is given, so that the token does not
+// appear in process listings. Otherwise it returns the token given with the flag.
+func resolveToken(token, path string) (string, error) {
+	if len(path) == 0 {
+		if len(token) == 0 {
+			return "", errors.New("github token is empty, set either --token or --token-file")
+		}
+		return token, nil
+	}
+	if len(token) != 0 {
+		return "", errors.New("only one of --token and --token-file can be set")
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token = strings.TrimSpace(string(b))
+	if len(token) == 0 {
+		return "", fmt.Errorf("token file is empty: %s", path)
+	}
+	return token, nil
+}
+
+func parseHeaders(strs []string) (map[string]string, error) {
+	headers := make(map[string]string, len(strs))
+	for _, str := range strs {
+		i := strings.Index(str, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid header format, want \"Name: value\": %s", str)
+		}
+		headers[strings.TrimSpace(str[:i])] = strings.TrimSpace(str[i+1:])
+	}
+	return headers, nil
+}
+
+// parseStatusCodes parses the comma-separated list of the status codes such as "500,502".
+func parseStatusCodes(str string) ([]int, error) {
+	codes := []int{}
+	for _, entry := range strings.Split(str, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		code, err := strconv.Atoi(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid status code %q", entry)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
 }
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_resolveToken(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := map[string]struct {
+		token   string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		"returns the token of the flag when the file is not set": {
+			token: "token-flag",
+			want:  "token-flag",
+		},
+		"returns the trimmed token read from the file": {
+			path: writeFile("token", "token-file\n"),
+			want: "token-file",
+		},
+		"returns error when neither is set": {
+			wantErr: true,
+		},
+		"returns error when both are set": {
+			token:   "token-flag",
+			path:    writeFile("both", "token-file"),
+			wantErr: true,
+		},
+		"returns error when the file is unreadable": {
+			path:    filepath.Join(dir, "missing"),
+			wantErr: true,
+		},
+		"returns error when the file is empty": {
+			path:    writeFile("empty", " \n"),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveToken(tt.token, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("resolveToken() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_exitCodeMap_exitCode(t *testing.T) {
+	tests := map[string]struct {
+		codes exitCodeMap
+		err   error
+		want  int
+	}{
+		"returns interrupted exit code when interrupted": {
+			err:  fmt.Errorf("wrapped: %w", ErrInterrupted),
+			want: ExitCodeInterrupted,
+		},
+		"returns failure exit code when timed out": {
+			err:  context.DeadlineExceeded,
+			want: ExitCodeFailure,
+		},
+		"returns failure exit code when failed": {
+			err:  errors.New("err"),
+			want: ExitCodeFailure,
+		},
+		"returns success exit code when succeeded": {
+			err:  nil,
+			want: ExitCodeSuccess,
+		},
+		"returns configured success exit code when succeeded": {
+			codes: exitCodeMap{success: 78, timeout: 1, failure: 1},
+			err:   nil,
+			want:  78,
+		},
+		"returns configured timeout exit code when timed out": {
+			codes: exitCodeMap{success: 0, timeout: 124, failure: 2},
+			err:   fmt.Errorf("wrapped: %w", context.DeadlineExceeded),
+			want:  124,
+		},
+		"returns configured failure exit code when failed": {
+			codes: exitCodeMap{success: 0, timeout: 124, failure: 2},
+			err:   errors.New("err"),
+			want:  2,
+		},
+		"returns interrupted exit code regardless of configured exit codes": {
+			codes: exitCodeMap{success: 0, timeout: 124, failure: 2},
+			err:   ErrInterrupted,
+			want:  ExitCodeInterrupted,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			codes := defaultExitCodes
+			if tt.codes != (exitCodeMap{}) {
+				codes = tt.codes
+			}
+			if got := codes.exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_exitCodeMap_validate(t *testing.T) {
+	tests := map[string]struct {
+		codes   exitCodeMap
+		wantErr bool
+	}{
+		"returns nil when the codes are the defaults": {
+			codes: defaultExitCodes,
+		},
+		"returns nil when the codes are in the range": {
+			codes: exitCodeMap{success: 78, timeout: 124, failure: 255},
+		},
+		"returns error when a code is negative": {
+			codes:   exitCodeMap{success: -1, timeout: 1, failure: 1},
+			wantErr: true,
+		},
+		"returns error when a code is out of the range": {
+			codes:   exitCodeMap{success: 0, timeout: 256, failure: 1},
+			wantErr: true,
+		},
+		"returns error when a code is reserved for interruptions": {
+			codes:   exitCodeMap{success: 0, timeout: 1, failure: ExitCodeInterrupted},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tt.codes.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parseStatusCodes(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		want    []int
+		wantErr bool
+	}{
+		"parses the codes": {
+			str:  "500, 502,429",
+			want: []int{500, 502, 429},
+		},
+		"returns no codes when the list is empty": {
+			str:  "",
+			want: []int{},
+		},
+		"returns error when a code is not a number": {
+			str:     "500,bad",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseStatusCodes(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStatusCodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStatusCodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
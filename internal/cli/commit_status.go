@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+// Commit status states, which are described in https://docs.github.com/en/rest/commits/statuses.
+const (
+	commitStatusPending = "pending"
+	commitStatusSuccess = "success"
+	commitStatusFailure = "failure"
+	commitStatusError   = "error"
+
+	maxCommitStatusDescriptionLen = 140
+
+	// finalStatusTimeout is the timeout to post the final status, which is posted even after the wait is cancelled.
+	finalStatusTimeout = 10 * time.Second
+)
+
+// statusPoster posts the aggregate result of the validations as a commit status onto the ref, so that the branch
+// protection can require it directly. A nil statusPoster posts nothing. When following the head of the pull request,
+// the status is posted onto its latest head, as is validated.
+type statusPoster struct {
+	client     github.Client
+	owner      string
+	repo       string
+	ref        string
+	prNumber   int
+	context    string
+	followHead bool
+
+	sha             string
+	lastState       string
+	lastDescription string
+	pendingSummary  string
+}
+
+func newStatusPoster(c github.Client, owner, repo, ref string, prNumber int, context string, followHead bool) *statusPoster {
+	return &statusPoster{
+		client:     c,
+		owner:      owner,
+		repo:       repo,
+		ref:        ref,
+		prNumber:   prNumber,
+		context:    context,
+		followHead: followHead,
+	}
+}
+
+// pending posts the pending status with the progress of the validations.
+func (p *statusPoster) pending(ctx context.Context, logger logger, vs []validators.Validator, statuses []validators.Status) {
+	if p == nil {
+		return
+	}
+	p.pendingSummary = summarizeProgress(vs, statuses)
+	if err := p.post(ctx, commitStatusPending, p.pendingSummary); err != nil {
+		logger.PrintErrf("  WARNING: Failed to post the pending commit status: %v\n", err)
+	}
+}
+
+// finish posts the final status for the result of the wait, and returns the result.
+func (p *statusPoster) finish(logger logger, result error) error {
+	if p == nil {
+		return result
+	}
+
+	state, description := commitStatusSuccess, "All validations were successful"
+	var pg progresser
+	var oe *overriddenError
+	switch {
+	case result == nil:
+	case errors.As(result, &oe):
+		description = oe.Error()
+	case errors.Is(result, ErrInterrupted):
+		state, description = commitStatusError, "Interrupted: "+p.pendingSummary
+	case errors.Is(result, context.DeadlineExceeded):
+		state, description = commitStatusFailure, "Timed out: "+p.pendingSummary
+	case errors.As(result, &pg):
+		state, description = commitStatusFailure, "Failed: "+pg.Progress()
+	default:
+		state, description = commitStatusFailure, "Validation failed"
+	}
+
+	// The wait may have been cancelled, so the final status is posted with its own context.
+	ctx, cancel := context.WithTimeout(context.Background(), finalStatusTimeout)
+	defer cancel()
+	if err := p.post(ctx, state, description); err != nil {
+		if state == commitStatusSuccess {
+			return fmt.Errorf("failed to post the %s commit status: %w", state, err)
+		}
+		logger.PrintErrf("  WARNING: Failed to post the %s commit status: %v\n", state, err)
+	}
+	return result
+}
+
+func (p *statusPoster) post(ctx context.Context, state, description string) error {
+	description = truncateDescription(description)
+	if err := p.resolveSHA(ctx); err != nil {
+		return err
+	}
+	if state == p.lastState && description == p.lastDescription {
+		return nil
+	}
+
+	if _, _, err := p.client.CreateStatus(ctx, p.owner, p.repo, p.sha, &github.RepoStatus{
+		State:       &state,
+		Description: &description,
+		Context:     &p.context,
+	}); err != nil {
+		return err
+	}
+	p.lastState, p.lastDescription = state, description
+	return nil
+}
+
+// resolveSHA resolves the commit to post the status onto, as commit statuses can only be posted onto a SHA. The head
+// of the pull request is re-resolved on every post when following it, and the status is posted anew onto a new head.
+func (p *statusPoster) resolveSHA(ctx context.Context) error {
+	if len(p.sha) != 0 && (!p.followHead || len(p.ref) != 0) {
+		return nil
+	}
+	if len(p.ref) != 0 {
+		sha, _, err := p.client.GetCommitSHA1(ctx, p.owner, p.repo, p.ref, "")
+		if err != nil {
+			return fmt.Errorf("failed to resolve the commit of %s: %w", p.ref, err)
+		}
+		p.sha = sha
+		return nil
+	}
+	pr, _, err := p.client.GetPullRequest(ctx, p.owner, p.repo, p.prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request #%d: %w", p.prNumber, err)
+	}
+	if head := pr.GetHead().GetSHA(); head != p.sha {
+		p.sha = head
+		p.lastState, p.lastDescription = "", ""
+	}
+	return nil
+}
+
+// summarizeProgress returns the one-line progress of the validations.
+func summarizeProgress(vs []validators.Validator, statuses []validators.Status) string {
+	summaries := make([]string, 0, len(vs))
+	for i, v := range vs {
+		summary := "yet to be validated"
+		if p, ok := statuses[i].(progresser); ok {
+			summary = p.Progress()
+		} else if statuses[i] != nil && statuses[i].IsSuccess() {
+			summary = "successful"
+		} else if statuses[i] != nil {
+			summary = "yet to be completed"
+		}
+		if len(vs) > 1 {
+			summary = v.Name() + ": " + summary
+		}
+		summaries = append(summaries, summary)
+	}
+	return strings.Join(summaries, "; ")
+}
+
+func truncateDescription(description string) string {
+	return truncateText(description, maxCommitStatusDescriptionLen)
+}
+
+// truncateText truncates the text to the maximum number of characters with an ellipsis.
+func truncateText(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-3]) + "..."
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	ghmock "github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+)
+
+type progressStatus struct {
+	mock.Status
+	progress string
+}
+
+func (s *progressStatus) Progress() string {
+	return s.progress
+}
+
+type progressError struct {
+	progress string
+}
+
+func (e *progressError) Error() string {
+	return "detail"
+}
+
+func (e *progressError) Progress() string {
+	return e.progress
+}
+
+type postedStatus struct {
+	ref, state, description, context string
+}
+
+func Test_doValidateCmd_postStatus(t *testing.T) {
+	pendingStatus := &progressStatus{
+		Status: mock.Status{
+			DetailFunc:    func() string { return "pending-detail" },
+			IsSuccessFunc: func() bool { return false },
+		},
+		progress: "1/2 checks complete, 1 pending: job-2",
+	}
+	successStatus := &mock.Status{
+		DetailFunc:    func() string { return "success-detail" },
+		IsSuccessFunc: func() bool { return true },
+	}
+
+	tests := map[string]struct {
+		results   []func() (validators.Status, error)
+		createErr error
+		wantErr   bool
+		want      []postedStatus
+	}{
+		"posts pending and then success": {
+			results: []func() (validators.Status, error){
+				func() (validators.Status, error) { return pendingStatus, nil },
+				func() (validators.Status, error) { return pendingStatus, nil },
+				func() (validators.Status, error) { return successStatus, nil },
+			},
+			want: []postedStatus{
+				{ref: "sha", state: commitStatusPending, description: "1/2 checks complete, 1 pending: job-2", context: "merge-gatekeeper"},
+				{ref: "sha", state: commitStatusSuccess, description: "All validations were successful", context: "merge-gatekeeper"},
+			},
+		},
+		"posts failure with the progress of the failed validation": {
+			results: []func() (validators.Status, error){
+				func() (validators.Status, error) { return pendingStatus, nil },
+				func() (validators.Status, error) {
+					return nil, &progressError{progress: "1/2 checks complete, 1 failed: job-2"}
+				},
+			},
+			wantErr: true,
+			want: []postedStatus{
+				{ref: "sha", state: commitStatusPending, description: "1/2 checks complete, 1 pending: job-2", context: "merge-gatekeeper"},
+				{ref: "sha", state: commitStatusFailure, description: "Failed: 1/2 checks complete, 1 failed: job-2", context: "merge-gatekeeper"},
+			},
+		},
+		"posts failure with the last progress when timed out": {
+			results: []func() (validators.Status, error){
+				func() (validators.Status, error) { return pendingStatus, nil },
+			},
+			wantErr: true,
+			want: []postedStatus{
+				{ref: "sha", state: commitStatusPending, description: "1/2 checks complete, 1 pending: job-2", context: "merge-gatekeeper"},
+				{ref: "sha", state: commitStatusFailure, description: "Timed out: 1/2 checks complete, 1 pending: job-2", context: "merge-gatekeeper"},
+			},
+		},
+		"returns error when the success status cannot be posted": {
+			results: []func() (validators.Status, error){
+				func() (validators.Status, error) { return successStatus, nil },
+			},
+			createErr: errors.New("err"),
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var posted []postedStatus
+			client := &ghmock.Client{
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					return "sha", nil, nil
+				},
+				CreateStatusFunc: func(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+					if tt.createErr != nil {
+						return nil, nil, tt.createErr
+					}
+					posted = append(posted, postedStatus{
+						ref:         ref,
+						state:       status.GetState(),
+						description: status.GetDescription(),
+						context:     status.GetContext(),
+					})
+					return status, nil, nil
+				},
+			}
+
+			var polls int
+			v := &mock.Validator{
+				NameFunc: func() string { return "validator-1" },
+				ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+					i := polls
+					if i >= len(tt.results) {
+						i = len(tt.results) - 1
+					}
+					polls++
+					return tt.results[i]()
+				},
+			}
+
+			cmd := &cobra.Command{}
+			cmd.SetOut(&strings.Builder{})
+			cmd.SetErr(&strings.Builder{})
+			poster := newStatusPoster(client, "test-owner", "test-repo", "main", 0, "merge-gatekeeper", false)
+			if err := doValidateCmd(context.Background(), cmd, nil, nil, poster, nil, false, v); (err != nil) != tt.wantErr {
+				t.Fatalf("doValidateCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(posted, tt.want) {
+				t.Errorf("posted statuses = %v, want %v", posted, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusPoster_followHead(t *testing.T) {
+	heads := []string{"sha-1", "sha-1", "sha-2"}
+	var posted []postedStatus
+	client := &ghmock.Client{
+		GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+			head := heads[0]
+			if len(heads) > 1 {
+				heads = heads[1:]
+			}
+			return &github.PullRequest{Head: &github.PullRequestBranch{SHA: &head}}, nil, nil
+		},
+		CreateStatusFunc: func(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+			posted = append(posted, postedStatus{ref: ref, state: status.GetState(), description: status.GetDescription()})
+			return status, nil, nil
+		},
+	}
+
+	poster := newStatusPoster(client, "test-owner", "test-repo", "", 1, "merge-gatekeeper", true)
+	for i := 0; i < 3; i++ {
+		if err := poster.post(context.Background(), commitStatusPending, "pending"); err != nil {
+			t.Fatalf("post() error = %v", err)
+		}
+	}
+
+	// The same status is posted again onto the new head, while it is not posted twice onto the same head.
+	want := []postedStatus{
+		{ref: "sha-1", state: commitStatusPending, description: "pending"},
+		{ref: "sha-2", state: commitStatusPending, description: "pending"},
+	}
+	if !reflect.DeepEqual(posted, want) {
+		t.Errorf("posted statuses = %v, want %v", posted, want)
+	}
+}
+
+func Test_truncateDescription(t *testing.T) {
+	long := strings.Repeat("a", maxCommitStatusDescriptionLen+1)
+	got := truncateDescription(long)
+	if len([]rune(got)) != maxCommitStatusDescriptionLen || !strings.HasSuffix(got, "...") {
+		t.Errorf("truncateDescription() = %s, want %d characters ending with ...", got, maxCommitStatusDescriptionLen)
+	}
+	if got := truncateDescription("short"); got != "short" {
+		t.Errorf("truncateDescription() = %s, want short", got)
+	}
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+// effectiveConfig is the configuration of the validation as resolved from the flags, the environment variables and
+// the files, which is printed for debugging instead of validating.
+type effectiveConfig struct {
+	Repository  string `json:"repository"`
+	Ref         string `json:"ref,omitempty"`
+	PullRequest int    `json:"pull_request,omitempty"`
+	SelfJob     string `json:"self_job"`
+	Timeout     string `json:"timeout"`
+	Interval    string `json:"interval"`
+
+	MinCommitAge string `json:"min_commit_age"`
+
+	IgnoredJobs    []configJob `json:"ignored_jobs"`
+	RequiredJobs   []configJob `json:"required_jobs"`
+	OverriddenJobs []configJob `json:"overridden_jobs"`
+
+	OnlyJobs           []string          `json:"only_jobs"`
+	ConditionalJobs    []string          `json:"conditional_jobs"`
+	NonBlockingPending []string          `json:"non_blocking_pending_jobs"`
+	HardBlockingJobs   []string          `json:"hard_blocking_jobs"`
+	RequiredEnvs       []string          `json:"required_environments"`
+	AllowedWorkflows   []string          `json:"allowed_workflows"`
+	JobTimeouts        map[string]string `json:"job_timeouts"`
+
+	// CheckRunStates are the states of the jobs which the check run states are mapped to, the defaults included, so
+	// that the conclusions regarded as success are visible at once.
+	CheckRunStates       map[string]string `json:"check_run_states"`
+	ToleratedConclusions []string          `json:"tolerated_conclusions"`
+	AppConclusions       []string          `json:"app_conclusions"`
+}
+
+// configJob is a job of the resolved lists along with the sources which have listed it.
+type configJob struct {
+	Job     string   `json:"job"`
+	Sources []string `json:"sources"`
+}
+
+func validateConfigCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "config",
+		Short: "Print the configuration resolved from the flags of validate, the environment variables and the files as JSON without validating",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			// The token is only needed to read the required status checks of the branch protection.
+			var ghClient github.Client
+			if len(protectionBranch) != 0 {
+				c, err := newGitHubClient(ctx)
+				if err != nil {
+					return err
+				}
+				ghClient = c
+			}
+
+			cmd.SilenceUsage = true
+			config, err := resolveEffectiveConfig(ctx, ghClient, owner, repo, os.Getenv)
+			if err != nil {
+				return err
+			}
+			b, err := json.MarshalIndent(config, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode configuration: %w", err)
+			}
+			cmd.Println(string(b))
+			return nil
+		},
+	}
+}
+
+// resolveEffectiveConfig resolves the configuration in the same way as the validation does, so that the printed
+// configuration is the one which would take effect.
+func resolveEffectiveConfig(ctx context.Context, c github.Client, owner, repo string, getenv func(string) string) (*effectiveConfig, error) {
+	ignored, required, err := jobListSources(ctx, c, owner, repo, getenv)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := status.ResolveJobLists(ignored, required)
+	if err != nil {
+		return nil, err
+	}
+
+	timeouts, err := parseJobTimeouts(jobTimeouts)
+	if err != nil {
+		return nil, err
+	}
+	runStates, err := parseCheckRunStates(checkRunStates)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &effectiveConfig{
+		Repository:  owner + "/" + repo,
+		Ref:         ghRef,
+		PullRequest: prNumber,
+		SelfJob:     selfJobName,
+		Timeout:     (time.Duration(timeoutSecond) * time.Second).String(),
+		Interval:    (time.Duration(validateInvalSecond) * time.Second).String(),
+
+		MinCommitAge: (time.Duration(minCommitAgeSecond) * time.Second).String(),
+
+		IgnoredJobs:    configJobs(lists.Ignored, lists.IgnoredBy),
+		RequiredJobs:   configJobs(lists.Required, lists.RequiredBy),
+		OverriddenJobs: configJobs(lists.Overridden, lists.IgnoredBy),
+
+		OnlyJobs:           splitConfigList(onlyJobs),
+		ConditionalJobs:    splitConfigList(conditionalJobs),
+		NonBlockingPending: splitConfigList(nonBlockingPending),
+		HardBlockingJobs:   splitConfigList(hardBlockingJobs),
+		RequiredEnvs:       splitConfigList(requiredEnvs),
+		AllowedWorkflows:   splitConfigList(allowedWorkflows),
+		JobTimeouts:        make(map[string]string, len(timeouts)),
+
+		CheckRunStates:       make(map[string]string),
+		ToleratedConclusions: splitConfigList(toleratedConclusion),
+		AppConclusions:       splitConfigList(appConclusions),
+	}
+	for job, timeout := range timeouts {
+		config.JobTimeouts[job] = timeout.String()
+	}
+	for state, mapped := range status.DefaultCheckRunStates() {
+		config.CheckRunStates[state.String()] = mapped
+	}
+	for state, mapped := range runStates {
+		config.CheckRunStates[state.String()] = mapped
+	}
+	return config, nil
+}
+
+func configJobs(jobs []string, sources func(string) []string) []configJob {
+	configured := make([]configJob, 0, len(jobs))
+	for _, job := range jobs {
+		configured = append(configured, configJob{Job: job, Sources: sources(job)})
+	}
+	return configured
+}
+
+// splitConfigList splits the comma-separated list, dropping the empty entries, and sorts it so that the printed
+// configuration is stable.
+func splitConfigList(str string) []string {
+	list := []string{}
+	for _, entry := range strings.Split(str, ",") {
+		if entry = strings.TrimSpace(entry); len(entry) != 0 {
+			list = append(list, entry)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
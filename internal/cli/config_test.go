@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	ghmock "github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+func Test_resolveEffectiveConfig(t *testing.T) {
+	defer func(ignored, required, branch, only, timeouts, states string, timeout, interval uint) {
+		ignoredJobs, requiredJobs, protectionBranch, onlyJobs, jobTimeouts, checkRunStates = ignored, required, branch, only, timeouts, states
+		timeoutSecond, validateInvalSecond = timeout, interval
+	}(ignoredJobs, requiredJobs, protectionBranch, onlyJobs, jobTimeouts, checkRunStates, timeoutSecond, validateInvalSecond)
+	ignoredJobs, requiredJobs, protectionBranch = "docs,lint", "build", "main"
+	onlyJobs, jobTimeouts, checkRunStates = "test, build", "lint=5m", "completed:neutral=failure"
+	timeoutSecond, validateInvalSecond = 900, 15
+
+	c := &ghmock.Client{
+		GetRequiredStatusChecksFunc: func(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+			return &github.RequiredStatusChecks{Contexts: []string{"build", "lint"}}, nil, nil
+		},
+	}
+	env := map[string]string{ignoredJobsEnv: "docs"}
+
+	got, err := resolveEffectiveConfig(context.Background(), c, "test-owner", "test-repo", func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("resolveEffectiveConfig() error = %v", err)
+	}
+
+	if got.Repository != "test-owner/test-repo" || got.Timeout != "15m0s" || got.Interval != "15s" {
+		t.Errorf("resolveEffectiveConfig() repository = %s, timeout = %s, interval = %s, want test-owner/test-repo, 15m0s and 15s",
+			got.Repository, got.Timeout, got.Interval)
+	}
+	if want := []configJob{{Job: "docs", Sources: []string{"flag", "env " + ignoredJobsEnv}}}; !reflect.DeepEqual(got.IgnoredJobs, want) {
+		t.Errorf("resolveEffectiveConfig() ignored jobs = %v, want %v", got.IgnoredJobs, want)
+	}
+	wantRequired := []configJob{
+		{Job: "build", Sources: []string{"flag", "protection of main"}},
+		{Job: "lint", Sources: []string{"protection of main"}},
+	}
+	if !reflect.DeepEqual(got.RequiredJobs, wantRequired) {
+		t.Errorf("resolveEffectiveConfig() required jobs = %v, want %v", got.RequiredJobs, wantRequired)
+	}
+	if want := []configJob{{Job: "lint", Sources: []string{"flag"}}}; !reflect.DeepEqual(got.OverriddenJobs, want) {
+		t.Errorf("resolveEffectiveConfig() overridden jobs = %v, want %v", got.OverriddenJobs, want)
+	}
+	if want := []string{"build", "test"}; !reflect.DeepEqual(got.OnlyJobs, want) {
+		t.Errorf("resolveEffectiveConfig() only jobs = %v, want %v", got.OnlyJobs, want)
+	}
+	if want := map[string]string{"lint": "5m0s"}; !reflect.DeepEqual(got.JobTimeouts, want) {
+		t.Errorf("resolveEffectiveConfig() job timeouts = %v, want %v", got.JobTimeouts, want)
+	}
+	if state := got.CheckRunStates["completed:neutral"]; state != status.CheckRunMappedFailure {
+		t.Errorf("resolveEffectiveConfig() completed:neutral state = %s, want overridden %s", state, status.CheckRunMappedFailure)
+	}
+	if state := got.CheckRunStates["completed:success"]; state != status.CheckRunMappedSuccess {
+		t.Errorf("resolveEffectiveConfig() completed:success state = %s, want default %s", state, status.CheckRunMappedSuccess)
+	}
+
+	// The lists are printed even when they are empty, so that an unset list is told apart from a missing field.
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if hard, ok := fields["hard_blocking_jobs"].([]interface{}); !ok || len(hard) != 0 {
+		t.Errorf("hard_blocking_jobs = %v, want empty list", fields["hard_blocking_jobs"])
+	}
+}
+
+func Test_resolveEffectiveConfig_invalid(t *testing.T) {
+	defer func(timeouts string) { jobTimeouts = timeouts }(jobTimeouts)
+	jobTimeouts = "lint"
+
+	if _, err := resolveEffectiveConfig(context.Background(), nil, "test-owner", "test-repo", func(string) string { return "" }); err == nil {
+		t.Error("resolveEffectiveConfig() error = nil, want the error of the invalid job timeouts")
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// errTotalWaitExceeded is returned when the deadline persisted by an earlier invocation has already passed, so that
+// an outer loop relaunching the gatekeeper does not wait indefinitely.
+var errTotalWaitExceeded = errors.New("total wait deadline has passed")
+
+// waitDeadline is the deadline of the total wait across the invocations, or zero when the wait is bounded only by
+// the timeout of each invocation.
+var waitDeadline time.Time
+
+// deadlineState is the state file persisting the deadline of the total wait across the invocations.
+type deadlineState struct {
+	Deadline time.Time `json:"deadline"`
+}
+
+// loadDeadline returns the deadline persisted in the state file. The first invocation finds no state file, and thus
+// persists the deadline after the maximum total wait from now, which the later invocations then honor.
+func loadDeadline(path string, maxWait time.Duration, now time.Time) (time.Time, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		deadline := now.Add(maxWait)
+		if err := writeDeadline(path, deadline); err != nil {
+			return time.Time{}, err
+		}
+		return deadline, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read deadline file: %w", err)
+	}
+
+	var state deadlineState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return time.Time{}, fmt.Errorf("invalid deadline file %s: %w", path, err)
+	}
+	if state.Deadline.IsZero() {
+		return time.Time{}, fmt.Errorf("invalid deadline file %s: deadline is missing", path)
+	}
+	return state.Deadline, nil
+}
+
+func writeDeadline(path string, deadline time.Time) error {
+	b, err := json.Marshal(deadlineState{Deadline: deadline.UTC()})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write deadline file: %w", err)
+	}
+	return nil
+}
+
+// remainingWait returns the budget of the total wait left at now, which is never negative.
+func remainingWait(deadline, now time.Time) time.Duration {
+	if remaining := deadline.Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+)
+
+func Test_loadDeadline(t *testing.T) {
+	now := time.Date(2022, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		content  string
+		noFile   bool
+		want     time.Time
+		wantErr  bool
+		wantFile bool
+	}{
+		"persists the deadline after the maximum total wait when there is no state file": {
+			noFile:   true,
+			want:     now.Add(time.Hour),
+			wantFile: true,
+		},
+		"honors the deadline persisted by an earlier invocation": {
+			content: `{"deadline":"2022-04-01T08:30:00Z"}`,
+			want:    now.Add(-30 * time.Minute),
+		},
+		"returns error when the state file is invalid": {
+			content: "deadline",
+			wantErr: true,
+		},
+		"returns error when the deadline is missing": {
+			content: "{}",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "deadline.json")
+			if !tt.noFile {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := loadDeadline(path, time.Hour, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadDeadline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("loadDeadline() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantFile {
+				return
+			}
+
+			// The later invocations read the same deadline however late they are.
+			again, err := loadDeadline(path, 2*time.Hour, now.Add(10*time.Minute))
+			if err != nil {
+				t.Fatalf("loadDeadline() of the persisted deadline error = %v", err)
+			}
+			if !again.Equal(tt.want) {
+				t.Errorf("loadDeadline() of the persisted deadline = %v, want %v", again, tt.want)
+			}
+		})
+	}
+}
+
+func Test_remainingWait(t *testing.T) {
+	now := time.Date(2022, 4, 1, 9, 0, 0, 0, time.UTC)
+	if got := remainingWait(now.Add(time.Minute), now); got != time.Minute {
+		t.Errorf("remainingWait() = %v, want %v", got, time.Minute)
+	}
+	if got := remainingWait(now.Add(-time.Minute), now); got != 0 {
+		t.Errorf("remainingWait() of the passed deadline = %v, want 0", got)
+	}
+}
+
+func Test_doValidateCmd_waitDeadline(t *testing.T) {
+	waitDeadline = time.Now().Add(500 * time.Millisecond)
+	defer func() { waitDeadline = time.Time{} }()
+
+	pending := &mock.Validator{
+		NameFunc: func() string { return "validator" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			return &mock.Status{
+				DetailFunc:    func() string { return "pending" },
+				IsSuccessFunc: func() bool { return false },
+			}, nil
+		},
+	}
+
+	start := time.Now()
+	err := doValidateCmd(context.Background(), &cobra.Command{}, nil, nil, nil, nil, false, pending)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("doValidateCmd() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// The persisted deadline comes before the timeout of the invocation.
+	if elapsed := time.Since(start); elapsed >= time.Duration(timeoutSecond)*time.Second {
+		t.Errorf("doValidateCmd() waited %v, want to stop at the persisted deadline", elapsed)
+	}
+}
+
+func Test_formatActionsOutput_remainingWait(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	got, err := formatActionsOutput(nil, &reportResults{deadline: now.Add(time.Hour), now: now})
+	if err != nil {
+		t.Fatalf("formatActionsOutput() error = %v", err)
+	}
+	if !strings.Contains(string(got), "remaining_wait_seconds=3600\n") {
+		t.Errorf("formatActionsOutput() = %q, want the remaining wait", got)
+	}
+}
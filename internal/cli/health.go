@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+func healthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "Check github token and connectivity without validating jobs",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			ghClient, err := newGitHubClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			if err := status.CheckAccess(ctx, ghClient,
+				status.WithGitHubOwnerAndRepo(owner, repo),
+				status.WithGitHubRef(ghRef),
+			); err != nil {
+				return fmt.Errorf("health check failed: %w", err)
+			}
+
+			cmd.Printf("Successfully accessed statuses of %s/%s@%s\n", owner, repo, ghRef)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+
+	cmd.PersistentFlags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name")
+	cmd.MarkPersistentFlagRequired("ref")
+
+	return cmd
+}
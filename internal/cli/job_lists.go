@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+// The environment variables setting the ignored and required jobs, which are merged with the flags for the runs
+// outside of the action, e.g. on another CI.
+const (
+	ignoredJobsEnv  = "MERGE_GATEKEEPER_IGNORED"
+	requiredJobsEnv = "MERGE_GATEKEEPER_REQUIRED"
+)
+
+// jobListSources returns the sources of the ignored and required jobs in the order of precedence, which is the flags,
+// the environment variables, the files and then the branch protection. The merged lists keep the jobs in this order.
+// Both lists are the unions of their sources, while the intersection of them, i.e. a job which is required by any
+// source while another source ignores it, is validated.
+func jobListSources(ctx context.Context, c github.Client, owner, repo string, getenv func(string) string) (ignored, required []status.JobListSource, err error) {
+	ignored = []status.JobListSource{
+		{Name: "flag", Jobs: strings.Split(ignoredJobs, ",")},
+		{Name: "env " + ignoredJobsEnv, Jobs: strings.Split(getenv(ignoredJobsEnv), ",")},
+	}
+	if len(ignoredJobsFile) != 0 {
+		jobs, err := loadIgnoredJobsFile(ignoredJobsFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		ignored = append(ignored, status.JobListSource{Name: "file " + ignoredJobsFile, Jobs: jobs})
+	}
+
+	required = []status.JobListSource{
+		{Name: "flag", Jobs: strings.Split(requiredJobs, ",")},
+		{Name: "env " + requiredJobsEnv, Jobs: strings.Split(getenv(requiredJobsEnv), ",")},
+	}
+
+	if len(requiredChecksFile) != 0 {
+		patterns, err := loadRequiredChecksFile(requiredChecksFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		required = append(required, status.JobListSource{Name: "file " + requiredChecksFile, Jobs: patterns})
+	}
+
+	if len(protectionBranch) != 0 {
+		checks, _, err := c.GetRequiredStatusChecks(ctx, owner, repo, protectionBranch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get required status checks of branch %s: %w", protectionBranch, err)
+		}
+		required = append(required, status.JobListSource{Name: "protection of " + protectionBranch, Jobs: checks.Contexts})
+	}
+	return ignored, required, nil
+}
+
+// resolveJobLists merges the ignored and required jobs of all the sources, and logs the effective lists.
+func resolveJobLists(ctx context.Context, logger logger, c github.Client, owner, repo string) (*status.ResolvedJobLists, error) {
+	ignored, required, err := jobListSources(ctx, c, owner, repo, os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := status.ResolveJobLists(ignored, required)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lists.Summary() {
+		logger.Println(line)
+	}
+	return lists, nil
+}
+
+func loadIgnoredJobsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ignored jobs file: %w", err)
+	}
+	defer f.Close()
+
+	jobs, err := status.LoadIgnoredJobs(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ignored jobs file %s: %w", path, err)
+	}
+	return jobs, nil
+}
+
+func loadRequiredChecksFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open required checks file: %w", err)
+	}
+	defer f.Close()
+
+	patterns, err := status.LoadRequiredChecks(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid required checks file %s: %w", path, err)
+	}
+	return patterns, nil
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	ghmock "github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+func Test_jobListSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "required-checks")
+	if err := os.WriteFile(path, []byte("# required\ne2e-*\nbuild\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ignoredPath := filepath.Join(t.TempDir(), "ignored-jobs")
+	if err := os.WriteFile(ignoredPath, []byte("# ignored\nbot\ne2e-macos\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(ignored, required, file, ignoredFile, branch string) {
+		ignoredJobs, requiredJobs, requiredChecksFile, ignoredJobsFile, protectionBranch = ignored, required, file, ignoredFile, branch
+	}(ignoredJobs, requiredJobs, requiredChecksFile, ignoredJobsFile, protectionBranch)
+	ignoredJobs, requiredJobs, requiredChecksFile, ignoredJobsFile, protectionBranch = "docs,e2e-linux", "build", path, ignoredPath, "main"
+
+	c := &ghmock.Client{
+		GetRequiredStatusChecksFunc: func(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+			if branch != "main" {
+				t.Errorf("GetRequiredStatusChecks() branch = %s, want main", branch)
+			}
+			return &github.RequiredStatusChecks{Contexts: []string{"build", "test"}}, nil, nil
+		},
+	}
+	env := map[string]string{
+		ignoredJobsEnv:  "lint,test",
+		requiredJobsEnv: "lint",
+	}
+
+	ignored, required, err := jobListSources(context.Background(), c, "test-owner", "test-repo", func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("jobListSources() error = %v", err)
+	}
+	lists, err := status.ResolveJobLists(ignored, required)
+	if err != nil {
+		t.Fatalf("ResolveJobLists() error = %v", err)
+	}
+
+	if want := []string{"docs", "bot"}; !reflect.DeepEqual(lists.Ignored, want) {
+		t.Errorf("ignored jobs = %v, want %v", lists.Ignored, want)
+	}
+	if want := []string{"build", "lint", "e2e-*", "test"}; !reflect.DeepEqual(lists.Required, want) {
+		t.Errorf("required jobs = %v, want %v", lists.Required, want)
+	}
+	if want := []string{"e2e-linux", "lint", "test", "e2e-macos"}; !reflect.DeepEqual(lists.Overridden, want) {
+		t.Errorf("overridden jobs = %v, want %v", lists.Overridden, want)
+	}
+	want := []string{
+		"Ignored jobs: docs (flag), bot (file " + ignoredPath + ")",
+		"Required jobs: build (flag, file " + path + ", protection of main), lint (env MERGE_GATEKEEPER_REQUIRED), e2e-* (file " + path + "), test (protection of main)",
+		"Ignored but required jobs, which are validated: e2e-linux (flag), lint (env MERGE_GATEKEEPER_IGNORED), test (env MERGE_GATEKEEPER_IGNORED), e2e-macos (file " + ignoredPath + ")",
+	}
+	if got := lists.Summary(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+var jobsCheckRunStatus string
+
+func jobsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "List all distinct job names reported on the ref with their states and sources",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			ghClient, err := newGitHubClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			jobs, err := status.ListJobs(ctx, ghClient, jobsCheckRunStatus,
+				status.WithGitHubOwnerAndRepo(owner, repo),
+				status.WithGitHubRef(ghRef),
+				status.WithServerVersionDetection(len(ghAPIURL) != 0),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to list jobs: %w", err)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "JOB\tSTATE\tSOURCE")
+			for _, job := range jobs {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", job.Job, job.State, job.Source)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+
+	cmd.PersistentFlags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name")
+	cmd.MarkPersistentFlagRequired("ref")
+	cmd.PersistentFlags().StringVar(&jobsCheckRunStatus, "check-run-status", "", "list only check runs of the status, either \"queued\", \"in_progress\" or \"completed\", while commit statuses are listed regardless")
+
+	return cmd
+}
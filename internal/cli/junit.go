@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const junitSuitesName = "merge-gatekeeper"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// formatJUnitReport formats the results of the validations in the JUnit XML format, so that they are visible
+// in test dashboards. Each job is a test case, and each validator is a test suite.
+func formatJUnitReport(vs []validators.Validator, results *reportResults) ([]byte, error) {
+	out, err := xml.MarshalIndent(junitSuites(vs, results), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+func junitSuites(vs []validators.Validator, results *reportResults) junitTestSuites {
+	suites := junitTestSuites{
+		Name:   junitSuitesName,
+		Suites: make([]junitTestSuite, 0, len(vs)),
+	}
+	for i, v := range vs {
+		suite := junitTestSuite{Name: v.Name()}
+		suite.Cases = junitTestCases(v, results.statuses[i], results.errs[i])
+		for _, c := range suite.Cases {
+			switch {
+			case c.Failure != nil:
+				suite.Failures++
+			case c.Skipped != nil:
+				suite.Skipped++
+			}
+		}
+		suite.Tests = len(suite.Cases)
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+	return suites
+}
+
+// junitTestCases returns a test case for each job when the result is structured, and a single test case for
+// the validator otherwise.
+func junitTestCases(v validators.Validator, st validators.Status, err error) []junitTestCase {
+	jr := jobResults(st, err)
+	if jr == nil {
+		return []junitTestCase{validatorTestCase(v, st, err)}
+	}
+
+	cases := make([]junitTestCase, 0, len(jr.JobStatuses())+len(jr.IgnoredJobs()))
+	for _, js := range jr.JobStatuses() {
+		c := junitTestCase{Name: js.Job, ClassName: v.Name()}
+		// The job states are the same as the commit status states.
+		switch js.State {
+		case commitStatusSuccess:
+		case commitStatusFailure, commitStatusError:
+			// The details URL is the message, so that the job can be looked into from the dashboards.
+			c.Failure = &junitFailure{
+				Message: js.DetailsURL,
+				Type:    js.State,
+				Text:    fmt.Sprintf("%s is in %s state", js.Job, js.State),
+			}
+		default:
+			c.Skipped = &junitSkipped{Message: "yet to be completed"}
+		}
+		cases = append(cases, c)
+	}
+	for _, job := range jr.IgnoredJobs() {
+		cases = append(cases, junitTestCase{
+			Name:      job,
+			ClassName: v.Name(),
+			Skipped:   &junitSkipped{Message: "ignored"},
+		})
+	}
+	return cases
+}
+
+func validatorTestCase(v validators.Validator, st validators.Status, err error) junitTestCase {
+	c := junitTestCase{Name: v.Name(), ClassName: v.Name()}
+	switch {
+	case err != nil:
+		c.Failure = &junitFailure{
+			Message: "validation failed",
+			Type:    commitStatusFailure,
+			Text:    err.Error(),
+		}
+	case st == nil:
+		c.Skipped = &junitSkipped{Message: "not validated yet"}
+	case !st.IsSuccess():
+		c.Skipped = &junitSkipped{Message: "yet to be completed"}
+	}
+	return c
+}
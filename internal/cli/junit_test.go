@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+// junitSchema is the subset of the widely used JUnit XML schema (https://github.com/jenkinsci/xunit-plugin,
+// junit-10.xsd), mapping the elements to their allowed attributes, required attributes and child elements.
+var junitSchema = map[string]struct {
+	attrs    []string
+	required []string
+	children []string
+}{
+	"testsuites": {
+		attrs:    []string{"name", "time", "tests", "failures", "disabled", "errors"},
+		children: []string{"testsuite"},
+	},
+	"testsuite": {
+		attrs:    []string{"name", "tests", "failures", "errors", "group", "time", "disabled", "skipped", "timestamp", "hostname", "id", "package", "file", "log", "url", "version"},
+		required: []string{"name", "tests"},
+		children: []string{"properties", "testcase", "system-out", "system-err"},
+	},
+	"testcase": {
+		attrs:    []string{"name", "assertions", "time", "classname", "status"},
+		required: []string{"name"},
+		children: []string{"skipped", "error", "failure", "system-out", "system-err"},
+	},
+	"failure": {
+		attrs: []string{"message", "type"},
+	},
+	"skipped": {
+		attrs: []string{"message"},
+	},
+}
+
+// validateJUnitSchema validates the document against junitSchema.
+func validateJUnitSchema(t *testing.T, doc []byte) {
+	t.Helper()
+
+	dec := xml.NewDecoder(bytes.NewReader(doc))
+	var parents []string
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %v", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			name := tok.Name.Local
+			elem, ok := junitSchema[name]
+			if !ok {
+				t.Fatalf("element %s is not allowed", name)
+			}
+			if len(parents) == 0 && name != "testsuites" {
+				t.Fatalf("root element is %s, want testsuites", name)
+			}
+			if len(parents) != 0 && !containsString(junitSchema[parents[len(parents)-1]].children, name) {
+				t.Fatalf("element %s is not allowed in %s", name, parents[len(parents)-1])
+			}
+			for _, attr := range tok.Attr {
+				if !containsString(elem.attrs, attr.Name.Local) {
+					t.Fatalf("attribute %s is not allowed in %s", attr.Name.Local, name)
+				}
+			}
+			for _, required := range elem.required {
+				found := false
+				for _, attr := range tok.Attr {
+					found = found || attr.Name.Local == required
+				}
+				if !found {
+					t.Fatalf("attribute %s is required in %s", required, name)
+				}
+			}
+			parents = append(parents, name)
+		case xml.EndElement:
+			parents = parents[:len(parents)-1]
+		}
+	}
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+type jobResultStatus struct {
+	mock.Status
+	jobStatuses []status.JobStatus
+	ignoredJobs []string
+}
+
+func (s *jobResultStatus) JobStatuses() []status.JobStatus {
+	return s.jobStatuses
+}
+
+func (s *jobResultStatus) IgnoredJobs() []string {
+	return s.ignoredJobs
+}
+
+type jobResultError struct {
+	jobResultStatus
+}
+
+func (e *jobResultError) Error() string {
+	return "detail"
+}
+
+func Test_formatJUnitReport(t *testing.T) {
+	statusValidator := &mock.Validator{NameFunc: func() string { return "status" }}
+	labelValidator := &mock.Validator{NameFunc: func() string { return "labels" }}
+	approvalValidator := &mock.Validator{NameFunc: func() string { return "approvals" }}
+
+	tests := map[string]struct {
+		vs       []validators.Validator
+		statuses []validators.Status
+		errs     []error
+		want     junitTestSuites
+	}{
+		"reports each job as a test case with the details URL of the failure": {
+			vs: []validators.Validator{statusValidator},
+			errs: []error{
+				&jobResultError{jobResultStatus{
+					jobStatuses: []status.JobStatus{
+						{Job: "build", State: "success"},
+						{Job: "e2e", State: "failure", DetailsURL: "https://ci.example.com/e2e"},
+						{Job: "lint", State: "pending"},
+					},
+					ignoredJobs: []string{"docs"},
+				}},
+			},
+			want: junitTestSuites{
+				Name:     "merge-gatekeeper",
+				Tests:    4,
+				Failures: 1,
+				Suites: []junitTestSuite{
+					{
+						Name:     "status",
+						Tests:    4,
+						Failures: 1,
+						Skipped:  2,
+						Cases: []junitTestCase{
+							{Name: "build", ClassName: "status"},
+							{Name: "e2e", ClassName: "status", Failure: &junitFailure{Message: "https://ci.example.com/e2e", Type: "failure", Text: "e2e is in failure state"}},
+							{Name: "lint", ClassName: "status", Skipped: &junitSkipped{Message: "yet to be completed"}},
+							{Name: "docs", ClassName: "status", Skipped: &junitSkipped{Message: "ignored"}},
+						},
+					},
+				},
+			},
+		},
+		"reports each validator without job results as a test case": {
+			vs: []validators.Validator{statusValidator, labelValidator, approvalValidator},
+			statuses: []validators.Status{
+				&jobResultStatus{
+					Status:      mock.Status{IsSuccessFunc: func() bool { return true }},
+					jobStatuses: []status.JobStatus{{Job: "build", State: "success"}},
+					ignoredJobs: []string{},
+				},
+				&mock.Status{IsSuccessFunc: func() bool { return false }},
+				nil,
+			},
+			errs: []error{nil, nil, errors.New("err")},
+			want: junitTestSuites{
+				Name:     "merge-gatekeeper",
+				Tests:    3,
+				Failures: 1,
+				Suites: []junitTestSuite{
+					{
+						Name:  "status",
+						Tests: 1,
+						Cases: []junitTestCase{
+							{Name: "build", ClassName: "status"},
+						},
+					},
+					{
+						Name:    "labels",
+						Tests:   1,
+						Skipped: 1,
+						Cases: []junitTestCase{
+							{Name: "labels", ClassName: "labels", Skipped: &junitSkipped{Message: "yet to be completed"}},
+						},
+					},
+					{
+						Name:     "approvals",
+						Tests:    1,
+						Failures: 1,
+						Cases: []junitTestCase{
+							{Name: "approvals", ClassName: "approvals", Failure: &junitFailure{Message: "validation failed", Type: "failure", Text: "err"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			results := &reportResults{
+				statuses: make([]validators.Status, len(tt.vs)),
+				errs:     make([]error, len(tt.vs)),
+			}
+			copy(results.statuses, tt.statuses)
+			copy(results.errs, tt.errs)
+			doc, err := formatJUnitReport(tt.vs, results)
+			if err != nil {
+				t.Fatalf("formatJUnitReport() error = %v", err)
+			}
+			validateJUnitSchema(t, doc)
+
+			var got junitTestSuites
+			if err := xml.Unmarshal(doc, &got); err != nil {
+				t.Fatalf("failed to decode the report: %v", err)
+			}
+			got.XMLName = xml.Name{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("formatJUnitReport() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_doValidateCmd_junitReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	v := &mock.Validator{
+		NameFunc: func() string { return "validator" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			return &mock.Status{
+				DetailFunc:    func() string { return "detail" },
+				IsSuccessFunc: func() bool { return true },
+			}, nil
+		},
+	}
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	reports := []reportFile{{path: path, format: formatJUnitReport}}
+	if err := doValidateCmd(context.Background(), cmd, nil, nil, nil, reports, false, v); err != nil {
+		t.Fatalf("doValidateCmd() error = %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("doValidateCmd() has not written the report: %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(b, &suites); err != nil {
+		t.Fatalf("report is not JUnit XML: %v", err)
+	}
+	if len(suites.Suites) != 1 || suites.Suites[0].Name != "validator" {
+		t.Errorf("report suites = %+v, want the suite of the validator", suites.Suites)
+	}
+}
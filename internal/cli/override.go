@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+const maxLabelsPerPage = 100
+
+// gateOverride passes the gate without validating when the pull request has the override label, which is
+// an escape hatch for emergencies. A nil gateOverride never overrides the gate.
+type gateOverride struct {
+	client   github.Client
+	owner    string
+	repo     string
+	prNumber int
+	label    string
+}
+
+func newGateOverride(c github.Client, owner, repo string, prNumber int, label string) *gateOverride {
+	return &gateOverride{
+		client:   c,
+		owner:    owner,
+		repo:     repo,
+		prNumber: prNumber,
+		label:    label,
+	}
+}
+
+// check returns *overriddenError when the pull request has the override label. It is checked on every poll,
+// so that the label added while waiting is honored.
+func (o *gateOverride) check(ctx context.Context, logger logger) error {
+	if o == nil {
+		return nil
+	}
+
+	for page := 1; page != 0; {
+		labels, resp, err := o.client.ListLabelsByIssue(ctx, o.owner, o.repo, o.prNumber, &github.ListOptions{
+			Page:    page,
+			PerPage: maxLabelsPerPage,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list labels of pull request #%d: %w", o.prNumber, err)
+		}
+		for _, l := range labels {
+			// Labels are compared case-insensitively as GitHub does.
+			if strings.EqualFold(l.GetName(), o.label) {
+				err := &overriddenError{label: l.GetName(), prNumber: o.prNumber}
+				// The override bypasses all the validations, so it is logged prominently for audit.
+				logger.PrintErrln("")
+				logger.PrintErrf("  WARNING: %s. All validations are skipped.\n", err.Error())
+				logger.PrintErrf("::warning::%s\n", err.Error())
+				return err
+			}
+		}
+
+		page = 0
+		if resp != nil {
+			page = resp.NextPage
+		}
+	}
+	return nil
+}
+
+// overriddenError is returned from the wait when the gate is overridden, which is regarded as success.
+type overriddenError struct {
+	label    string
+	prNumber int
+}
+
+func (e *overriddenError) Error() string {
+	return fmt.Sprintf("Gate overridden by the label %q on pull request #%d", e.label, e.prNumber)
+}
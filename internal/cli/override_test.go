@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	ghmock "github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+)
+
+func Test_doValidateCmd_override(t *testing.T) {
+	tests := map[string]struct {
+		labels       []string
+		listErr      error
+		wantErr      bool
+		wantValidate bool
+		wantOutput   string
+	}{
+		"succeeds without validating when the pull request has the override label": {
+			labels:       []string{"bug", "Override-Gatekeeper"},
+			wantErr:      false,
+			wantValidate: false,
+			wantOutput:   `Gate overridden by the label "Override-Gatekeeper" on pull request #1`,
+		},
+		"validates as usual when the pull request does not have the override label": {
+			labels:       []string{"bug"},
+			wantErr:      true,
+			wantValidate: true,
+		},
+		"returns error when the labels cannot be listed": {
+			listErr:      errors.New("err"),
+			wantErr:      true,
+			wantValidate: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &ghmock.Client{
+				ListLabelsByIssueFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+					if tt.listErr != nil {
+						return nil, nil, tt.listErr
+					}
+					labels := make([]*github.Label, 0, len(tt.labels))
+					for i := range tt.labels {
+						labels = append(labels, &github.Label{Name: &tt.labels[i]})
+					}
+					return labels, &github.Response{}, nil
+				},
+			}
+
+			var validated bool
+			v := &mock.Validator{
+				NameFunc: func() string { return "validator-1" },
+				ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+					validated = true
+					return &mock.Status{
+						DetailFunc:    func() string { return "pending-detail" },
+						IsSuccessFunc: func() bool { return false },
+					}, nil
+				},
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			override := newGateOverride(client, "owner", "repo", 1, "override-gatekeeper")
+			if err := doValidateCmd(context.Background(), cmd, nil, override, nil, nil, false, v); (err != nil) != tt.wantErr {
+				t.Fatalf("doValidateCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if validated != tt.wantValidate {
+				t.Errorf("validated = %v, want %v", validated, tt.wantValidate)
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("output does not contain %q:\n%s", tt.wantOutput, out.String())
+			}
+		})
+	}
+}
+
+func Test_statusPoster_finish_overridden(t *testing.T) {
+	var posted postedStatus
+	client := &ghmock.Client{
+		CreateStatusFunc: func(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+			posted = postedStatus{ref: ref, state: status.GetState(), description: status.GetDescription(), context: status.GetContext()}
+			return status, nil, nil
+		},
+	}
+	poster := newStatusPoster(client, "owner", "repo", "", 1, "merge-gatekeeper", false)
+	poster.sha = "sha"
+
+	result := &overriddenError{label: "override-gatekeeper", prNumber: 1}
+	if err := poster.finish(&cobra.Command{}, result); err != result {
+		t.Fatalf("statusPoster.finish() error = %v, want %v", err, result)
+	}
+	want := postedStatus{
+		ref:         "sha",
+		state:       commitStatusSuccess,
+		description: `Gate overridden by the label "override-gatekeeper" on pull request #1`,
+		context:     "merge-gatekeeper",
+	}
+	if posted != want {
+		t.Errorf("posted status = %+v, want %+v", posted, want)
+	}
+}
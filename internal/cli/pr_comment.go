@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const (
+	// maxCommentJobs is the maximum number of the jobs listed in a comment, so that the comment stays readable.
+	maxCommentJobs = 50
+
+	maxCommentsPerPage = 100
+)
+
+// commentPoster posts the summary of the gate as a comment on the pull request. The comment posted by the previous
+// run is found by its marker and edited, so that a single sticky comment is kept updated instead of piling up.
+// A nil commentPoster posts nothing.
+type commentPoster struct {
+	client   github.Client
+	owner    string
+	repo     string
+	prNumber int
+
+	// marker is the hidden line identifying the comment, which is named after the self job so that multiple gates
+	// on the same pull request keep their own comments.
+	marker string
+}
+
+func newCommentPoster(c github.Client, owner, repo string, prNumber int, selfJob string) *commentPoster {
+	return &commentPoster{
+		client:   c,
+		owner:    owner,
+		repo:     repo,
+		prNumber: prNumber,
+		marker:   fmt.Sprintf("<!-- merge-gatekeeper: %s -->", selfJob),
+	}
+}
+
+// reportFile returns the report which is written to the comment instead of a file.
+func (p *commentPoster) reportFile() reportFile {
+	if p == nil {
+		return reportFile{}
+	}
+	return reportFile{
+		path:   fmt.Sprintf("the comment on pull request #%d", p.prNumber),
+		format: p.format,
+		write:  p.post,
+	}
+}
+
+func (p *commentPoster) format(vs []validators.Validator, results *reportResults) ([]byte, error) {
+	return []byte(p.marker + "\n" + formatCommentReport(vs, results)), nil
+}
+
+// post edits the comment posted by the previous run, or creates one when there is none yet. The wait may have been
+// cancelled, so the comment is posted with its own context.
+func (p *commentPoster) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), finalStatusTimeout)
+	defer cancel()
+
+	text := string(body)
+	comment := &github.IssueComment{Body: &text}
+	id, err := p.findComment(ctx)
+	if err != nil {
+		return err
+	}
+	if id != 0 {
+		_, _, err = p.client.EditIssueComment(ctx, p.owner, p.repo, id, comment)
+		return err
+	}
+	_, _, err = p.client.CreateIssueComment(ctx, p.owner, p.repo, p.prNumber, comment)
+	return err
+}
+
+// findComment returns the ID of the comment which starts with the marker, or 0 when there is none.
+func (p *commentPoster) findComment(ctx context.Context) (int64, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: maxCommentsPerPage}}
+	for page := 1; ; page++ {
+		opts.Page = page
+		comments, _, err := p.client.ListIssueComments(ctx, p.owner, p.repo, p.prNumber, opts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list comments of pull request #%d: %w", p.prNumber, err)
+		}
+		for _, c := range comments {
+			if strings.HasPrefix(c.GetBody(), p.marker) {
+				return c.GetID(), nil
+			}
+		}
+		if len(comments) < maxCommentsPerPage {
+			return 0, nil
+		}
+	}
+}
+
+// formatCommentReport formats the result of the gate as markdown with the failed and pending jobs.
+func formatCommentReport(vs []validators.Validator, results *reportResults) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n", commentTitle(results.result))
+	for i, v := range vs {
+		fmt.Fprintf(&b, "\n**%s**: %s\n", v.Name(), slackSummary(results.statuses[i], results.errs[i]))
+
+		jr := jobResults(results.statuses[i], results.errs[i])
+		if jr == nil {
+			continue
+		}
+		var failed, pending []string
+		for _, js := range jr.JobStatuses() {
+			switch js.State {
+			case commitStatusSuccess:
+			case commitStatusFailure, commitStatusError:
+				failed = append(failed, commentJobLink(js.Job, js.DetailsURL))
+			default:
+				pending = append(pending, commentJobLink(js.Job, js.DetailsURL))
+			}
+		}
+		writeCommentJobList(&b, "Failed jobs", failed)
+		writeCommentJobList(&b, "Pending jobs", pending)
+	}
+	return b.String()
+}
+
+func commentTitle(result error) string {
+	var oe *overriddenError
+	switch {
+	case result == nil:
+		return ":white_check_mark: Merge Gatekeeper passed"
+	case errors.As(result, &oe):
+		return ":warning: Merge Gatekeeper passed by the override label " + oe.label
+	case errors.Is(result, ErrInterrupted):
+		return ":warning: Merge Gatekeeper was interrupted"
+	case errors.Is(result, context.DeadlineExceeded):
+		return ":hourglass: Merge Gatekeeper timed out"
+	default:
+		return ":x: Merge Gatekeeper failed"
+	}
+}
+
+func writeCommentJobList(b *strings.Builder, heading string, jobs []string) {
+	if len(jobs) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n%s:\n", heading)
+	for i, job := range jobs {
+		if i == maxCommentJobs {
+			fmt.Fprintf(b, "- … and %d more\n", len(jobs)-maxCommentJobs)
+			break
+		}
+		fmt.Fprintf(b, "- %s\n", job)
+	}
+}
+
+func commentJobLink(job, url string) string {
+	job = escapeMarkdown(job)
+	if len(url) == 0 {
+		return job
+	}
+	return fmt.Sprintf("[%s](%s)", job, url)
+}
+
+// escapeMarkdown escapes the characters of the job names which would otherwise be rendered as markdown.
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "`", "\\`", "<", "&lt;", ">", "&gt;").Replace(text)
+}
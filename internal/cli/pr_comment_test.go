@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	ghmock "github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+func Test_commentPoster_post(t *testing.T) {
+	const marker = "<!-- merge-gatekeeper: merge-gatekeeper -->"
+	comment := func(id int64, body string) *github.IssueComment {
+		return &github.IssueComment{ID: &id, Body: &body}
+	}
+	fullPage := make([]*github.IssueComment, maxCommentsPerPage)
+	for i := range fullPage {
+		fullPage[i] = comment(int64(i+1), "lgtm")
+	}
+
+	tests := map[string]struct {
+		pages      [][]*github.IssueComment
+		listErr    error
+		wantErr    bool
+		wantCreate bool
+		wantEdit   int64
+	}{
+		"creates a comment when there is none by the gate": {
+			pages:      [][]*github.IssueComment{{comment(1, "lgtm"), comment(2, "<!-- merge-gatekeeper: other -->\n### passed")}},
+			wantCreate: true,
+		},
+		"edits the comment posted by the previous run": {
+			pages:    [][]*github.IssueComment{{comment(1, "lgtm"), comment(2, marker+"\n### failed")}},
+			wantEdit: 2,
+		},
+		"finds the comment on the later pages": {
+			pages:    [][]*github.IssueComment{fullPage, {comment(101, marker+"\n### failed")}},
+			wantEdit: 101,
+		},
+		"returns error when the comments cannot be listed": {
+			listErr: errors.New("err"),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var created, edited bool
+			var editedID int64
+			var posted string
+			c := &ghmock.Client{
+				ListIssueCommentsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+					if tt.listErr != nil {
+						return nil, nil, tt.listErr
+					}
+					if opts.Page > len(tt.pages) {
+						return nil, nil, nil
+					}
+					return tt.pages[opts.Page-1], nil, nil
+				},
+				CreateIssueCommentFunc: func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+					if number != 1 {
+						t.Errorf("CreateIssueComment() number = %d, want 1", number)
+					}
+					created, posted = true, comment.GetBody()
+					return comment, nil, nil
+				},
+				EditIssueCommentFunc: func(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+					edited, editedID, posted = true, commentID, comment.GetBody()
+					return comment, nil, nil
+				},
+			}
+
+			p := newCommentPoster(c, "upsidr", "merge-gatekeeper", 1, "merge-gatekeeper")
+			rf := p.reportFile()
+			body, err := rf.format([]validators.Validator{}, &reportResults{})
+			if err != nil {
+				t.Fatalf("format() error = %v", err)
+			}
+			err = rf.write(body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("post() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if created != tt.wantCreate {
+				t.Errorf("created = %v, want %v", created, tt.wantCreate)
+			}
+			if wantEdit := tt.wantEdit != 0; edited != wantEdit || editedID != tt.wantEdit {
+				t.Errorf("edited comment = %d, want %d", editedID, tt.wantEdit)
+			}
+			if (created || edited) && !strings.HasPrefix(posted, marker+"\n") {
+				t.Errorf("posted body = %q, want to start with the marker", posted)
+			}
+		})
+	}
+}
+
+func Test_commentPoster_reportFile(t *testing.T) {
+	var p *commentPoster
+	if rf := p.reportFile(); rf.format != nil || rf.write != nil {
+		t.Errorf("reportFile() of nil poster = %+v, want empty", rf)
+	}
+}
+
+func Test_formatCommentReport(t *testing.T) {
+	statusValidator := &mock.Validator{NameFunc: func() string { return "status" }}
+	results := &reportResults{
+		statuses: []validators.Status{nil},
+		errs: []error{
+			&jobResultError{jobResultStatus{
+				jobStatuses: []status.JobStatus{
+					{Job: "build", State: "success"},
+					{Job: "e2e [linux]", State: "failure", DetailsURL: "https://ci.example.com/e2e"},
+					{Job: "lint_go", State: "pending"},
+				},
+			}},
+		},
+		result: errors.New("failed"),
+	}
+	want := "### :x: Merge Gatekeeper failed\n" +
+		"\n**status**: detail\n" +
+		"\nFailed jobs:\n- [e2e \\[linux\\]](https://ci.example.com/e2e)\n" +
+		"\nPending jobs:\n- lint\\_go\n"
+	if got := formatCommentReport([]validators.Validator{statusValidator}, results); got != want {
+		t.Errorf("formatCommentReport() = %q, want %q", got, want)
+	}
+}
+
+func Test_writeCommentJobList(t *testing.T) {
+	jobs := make([]string, maxCommentJobs+2)
+	for i := range jobs {
+		jobs[i] = "job"
+	}
+	var b strings.Builder
+	writeCommentJobList(&b, "Pending jobs", jobs)
+	if want := "- … and 2 more\n"; !strings.HasSuffix(b.String(), want) {
+		t.Errorf("writeCommentJobList() = %q, want to end with %q", b.String(), want)
+	}
+}
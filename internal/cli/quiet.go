@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+// quietLog holds off logging the polls until something significant happens to a validation, which is a job failing,
+// all the jobs turning green, or the validation succeeding. Each event is logged only once, so that the CI logs only
+// have the summaries which matter.
+type quietLog struct {
+	reported []string
+}
+
+func newQuietLog(n int) *quietLog {
+	return &quietLog{reported: make([]string, n)}
+}
+
+// significant reports whether the status of the i-th validation is worth logging, as it has an event which has not
+// been logged yet.
+func (q *quietLog) significant(i int, st validators.Status) bool {
+	event := quietEvent(st)
+	if len(event) == 0 || event == q.reported[i] {
+		return false
+	}
+	q.reported[i] = event
+	return true
+}
+
+// quietEvent returns the significant event of the status, or empty when it is merely progressing. The failed jobs are
+// part of the event, so that another job failing later is logged as well.
+func quietEvent(st validators.Status) string {
+	if st.IsSuccess() {
+		return "success"
+	}
+	jr := jobResults(st, nil)
+	if jr == nil {
+		return ""
+	}
+	jobs := jr.JobStatuses()
+	var failed []string
+	var pending bool
+	for _, js := range jobs {
+		switch js.State {
+		case commitStatusSuccess:
+		case commitStatusFailure, commitStatusError:
+			failed = append(failed, js.Job)
+		default:
+			pending = true
+		}
+	}
+	switch {
+	case len(failed) != 0:
+		return "failure: " + strings.Join(failed, ",")
+	case !pending && len(jobs) != 0:
+		return "green"
+	default:
+		return ""
+	}
+}
+
+// silentLogger discards everything, which the validations log to while quiet.
+type silentLogger struct{}
+
+func (silentLogger) Print(i ...interface{})                    {}
+func (silentLogger) Println(i ...interface{})                  {}
+func (silentLogger) Printf(format string, i ...interface{})    {}
+func (silentLogger) PrintErr(i ...interface{})                 {}
+func (silentLogger) PrintErrln(i ...interface{})               {}
+func (silentLogger) PrintErrf(format string, i ...interface{}) {}
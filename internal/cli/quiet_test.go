@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+func Test_doValidateCmd_quiet(t *testing.T) {
+	quietMode = true
+	defer func(timeout uint) {
+		quietMode = false
+		timeoutSecond = timeout
+	}(timeoutSecond)
+
+	jobs := func(detail string, success bool, states ...string) validators.Status {
+		st := &jobResultStatus{
+			Status: mock.Status{
+				DetailFunc:    func() string { return detail },
+				IsSuccessFunc: func() bool { return success },
+			},
+		}
+		for _, state := range states {
+			st.jobStatuses = append(st.jobStatuses, status.JobStatus{Job: "job-" + state, State: state})
+		}
+		return st
+	}
+
+	tests := map[string]struct {
+		results    []validators.Status
+		timeout    uint
+		wantErr    bool
+		wantOutput []string
+		wantCounts map[string]int
+	}{
+		"prints nothing until the validation succeeds": {
+			results: []validators.Status{
+				jobs("pending-detail", false, "success", "pending"),
+				jobs("pending-detail", false, "success", "pending"),
+				jobs("success-detail", true, "success", "success"),
+			},
+			timeout:    5,
+			wantOutput: []string{"success-detail", "All validations were successful!"},
+			wantCounts: map[string]int{"pending-detail": 0, "WARNING": 0},
+		},
+		"prints a failing job only once": {
+			results: []validators.Status{
+				jobs("pending-detail", false, "pending"),
+				jobs("failing-detail", false, "failure", "pending"),
+				jobs("failing-detail", false, "failure", "pending"),
+				jobs("success-detail", true, "success"),
+			},
+			timeout:    5,
+			wantOutput: []string{"success-detail"},
+			wantCounts: map[string]int{"pending-detail": 0, "failing-detail": 1, "WARNING": 0},
+		},
+		"prints the summary once when timed out": {
+			results: []validators.Status{
+				jobs("pending-detail", false, "pending"),
+			},
+			timeout:    2,
+			wantErr:    true,
+			wantOutput: []string{"Last known status of validator-1:\npending-detail"},
+			wantCounts: map[string]int{"pending-detail": 1},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeoutSecond = tt.timeout
+
+			var polls int
+			v := &mock.Validator{
+				NameFunc: func() string { return "validator-1" },
+				ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+					st := tt.results[polls]
+					if polls < len(tt.results)-1 {
+						polls++
+					}
+					return st, nil
+				},
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			err := doValidateCmd(context.Background(), cmd, nil, nil, nil, nil, false, v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("doValidateCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, out.String())
+				}
+			}
+			for text, want := range tt.wantCounts {
+				if got := strings.Count(out.String(), text); got != want {
+					t.Errorf("output contains %q %d times, want %d:\n%s", text, got, want, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+// reportResults are the latest results of the validators, along with the result of the wait. The deadline is the
+// deadline of the total wait, or zero when there is none, and now is when the results are written.
+type reportResults struct {
+	statuses []validators.Status
+	errs     []error
+	result   error
+	deadline time.Time
+	now      time.Time
+}
+
+// reportFormatter formats the results of the validators into a report.
+type reportFormatter func(vs []validators.Validator, results *reportResults) ([]byte, error)
+
+// reportFile is the file to write the report to in the format. The file is appended to when it is shared with the
+// others, such as the step outputs of GitHub Actions, instead of being overwritten. The report is written by write
+// instead when it is set, such as to the comment on the pull request, which is then named by path.
+type reportFile struct {
+	path   string
+	format reportFormatter
+	append bool
+	write  func(out []byte) error
+}
+
+// jobResults returns the structured results of the jobs from either the failure or the status, or nil when
+// the validator does not report them.
+func jobResults(st validators.Status, err error) status.JobResultReporter {
+	var jr status.JobResultReporter
+	if errors.As(err, &jr) {
+		return jr
+	}
+	jr, _ = st.(status.JobResultReporter)
+	return jr
+}
+
+// fileReporter records the latest results of the validations, and writes them to the files once the wait is over,
+// so that they are available to the other tools. A nil fileReporter writes nothing.
+type fileReporter struct {
+	results reportResults
+	files   []reportFile
+}
+
+// newFileReporter returns the reporter for n validators writing the files whose paths are set, reporting the
+// deadline of the total wait.
+func newFileReporter(n int, deadline time.Time, files ...reportFile) *fileReporter {
+	r := &fileReporter{
+		results: reportResults{
+			statuses: make([]validators.Status, n),
+			errs:     make([]error, n),
+			deadline: deadline,
+		},
+	}
+	for _, f := range files {
+		if len(f.path) != 0 {
+			r.files = append(r.files, f)
+		}
+	}
+	if len(r.files) == 0 {
+		return nil
+	}
+	return r
+}
+
+// record records the latest result of the i-th validator.
+func (r *fileReporter) record(i int, st validators.Status, err error) {
+	if r == nil {
+		return
+	}
+	r.results.statuses[i], r.results.errs[i] = st, err
+}
+
+// write writes the latest results of the validators and the result of the wait at now to the files.
+func (r *fileReporter) write(vs []validators.Validator, result error, now time.Time) error {
+	if r == nil {
+		return nil
+	}
+
+	r.results.result, r.results.now = result, now
+	for _, f := range r.files {
+		out, err := f.format(vs, &r.results)
+		if err != nil {
+			return fmt.Errorf("failed to format the report for %s: %w", f.path, err)
+		}
+		if err := writeReportFile(f, out); err != nil {
+			return fmt.Errorf("failed to write the report to %s: %w", f.path, err)
+		}
+	}
+	return nil
+}
+
+func writeReportFile(f reportFile, out []byte) error {
+	if f.write != nil {
+		return f.write(out)
+	}
+	if !f.append {
+		return os.WriteFile(f.path, out, 0o644)
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(out); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+)
+
+func Test_fileReporter_write(t *testing.T) {
+	dir := t.TempDir()
+	vs := []validators.Validator{
+		&mock.Validator{NameFunc: func() string { return "validator-1" }},
+	}
+	st := &mock.Status{IsSuccessFunc: func() bool { return true }}
+	wantResult := errors.New("err")
+
+	var formatted []*reportResults
+	format := func(name string) reportFormatter {
+		return func(vs []validators.Validator, results *reportResults) ([]byte, error) {
+			formatted = append(formatted, results)
+			return []byte(name), nil
+		}
+	}
+	r := newFileReporter(len(vs), time.Time{},
+		reportFile{path: filepath.Join(dir, "a"), format: format("a")},
+		reportFile{path: "", format: format("unused")},
+		reportFile{path: filepath.Join(dir, "b"), format: format("b")},
+	)
+	r.record(0, st, nil)
+	if err := r.write(vs, wantResult, time.Now()); err != nil {
+		t.Fatalf("fileReporter.write() error = %v", err)
+	}
+
+	if len(formatted) != 2 {
+		t.Fatalf("formatted %d reports, want 2", len(formatted))
+	}
+	for _, results := range formatted {
+		if results.statuses[0] != st || results.errs[0] != nil || results.result != wantResult {
+			t.Errorf("formatted results = %+v, want the recorded results", results)
+		}
+	}
+	for _, name := range []string{"a", "b"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != name {
+			t.Errorf("file %s = %q, want %q", name, got, name)
+		}
+	}
+}
+
+func Test_fileReporter_nil(t *testing.T) {
+	r := newFileReporter(1, time.Time{}, reportFile{path: "", format: formatJUnitReport})
+	if r != nil {
+		t.Fatalf("newFileReporter() = %v, want nil", r)
+	}
+	r.record(0, nil, nil)
+	if err := r.write(nil, nil, time.Now()); err != nil {
+		t.Errorf("fileReporter.write() error = %v, want nil", err)
+	}
+}
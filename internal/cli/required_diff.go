@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+var protectedBranch string
+
+func requiredDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "required-diff",
+		Short: "Print the difference between the checks required by the branch protection and the jobs reported on the ref",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			ghClient, err := newGitHubClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			diff, err := status.DiffRequiredChecks(ctx, ghClient, protectedBranch,
+				status.WithGitHubOwnerAndRepo(owner, repo),
+				status.WithGitHubRef(ghRef),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to diff required checks: %w", err)
+			}
+
+			printRequiredDiff(cmd.OutOrStdout(), protectedBranch, ghRef, diff)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+
+	cmd.PersistentFlags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name")
+	cmd.MarkPersistentFlagRequired("ref")
+	cmd.PersistentFlags().StringVar(&protectedBranch, "branch", "", "set protected branch whose required status checks are compared")
+	cmd.MarkPersistentFlagRequired("branch")
+
+	return cmd
+}
+
+func printRequiredDiff(w io.Writer, branch, ref string, diff *status.RequiredChecksDiff) {
+	fmt.Fprintf(w, "%d check(s) required by the protection of %s, %d job(s) reported on %s\n", len(diff.Required), branch, len(diff.Reported), ref)
+	fmt.Fprintln(w, "\nMissing (required but not reported):")
+	printJobList(w, diff.Missing)
+	fmt.Fprintln(w, "\nExtra (reported but not required):")
+	printJobList(w, diff.Extra)
+}
+
+func printJobList(w io.Writer, jobs []string) {
+	if len(jobs) == 0 {
+		fmt.Fprintln(w, "[]")
+		return
+	}
+	for _, job := range jobs {
+		fmt.Fprintf(w, "- %s\n", job)
+	}
+}
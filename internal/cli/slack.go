@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const (
+	// maxSlackHeaderLen is the maximum length of the text of a header block.
+	maxSlackHeaderLen = 150
+	// maxSlackTextLen is the maximum length of the text of a section block.
+	maxSlackTextLen = 3000
+	// maxSlackJobs is the maximum number of the jobs listed in a section, so that the message stays readable.
+	maxSlackJobs = 20
+)
+
+// slackPayload is the message payload of the Slack Block Kit, which is described in https://api.slack.com/block-kit.
+type slackPayload struct {
+	// Text is the fallback text for the notifications.
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// formatSlackReport formats the result of the gate as a Slack Block Kit payload with the failed and pending jobs,
+// which can be posted to an incoming webhook as is.
+func formatSlackReport(vs []validators.Validator, results *reportResults) ([]byte, error) {
+	title := slackTitle(results.result)
+	payload := slackPayload{
+		Text: title,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: truncateText(title, maxSlackHeaderLen)}},
+		},
+	}
+
+	for i, v := range vs {
+		payload.Blocks = append(payload.Blocks, slackSection(fmt.Sprintf("*%s*: %s", escapeSlack(v.Name()), escapeSlack(slackSummary(results.statuses[i], results.errs[i])))))
+
+		jr := jobResults(results.statuses[i], results.errs[i])
+		if jr == nil {
+			continue
+		}
+		var failed, pending []string
+		for _, js := range jr.JobStatuses() {
+			// The job states are the same as the commit status states.
+			switch js.State {
+			case commitStatusSuccess:
+			case commitStatusFailure, commitStatusError:
+				failed = append(failed, slackJobLink(js.Job, js.DetailsURL))
+			default:
+				pending = append(pending, slackJobLink(js.Job, js.DetailsURL))
+			}
+		}
+		if len(failed) != 0 {
+			payload.Blocks = append(payload.Blocks, slackSection(slackJobList("Failed jobs", failed)))
+		}
+		if len(pending) != 0 {
+			payload.Blocks = append(payload.Blocks, slackSection(slackJobList("Pending jobs", pending)))
+		}
+	}
+
+	return json.MarshalIndent(payload, "", "  ")
+}
+
+func slackTitle(result error) string {
+	var oe *overriddenError
+	switch {
+	case result == nil:
+		return ":white_check_mark: Merge Gatekeeper passed"
+	case errors.As(result, &oe):
+		return ":warning: Merge Gatekeeper passed by the override label " + oe.label
+	case errors.Is(result, ErrInterrupted):
+		return ":warning: Merge Gatekeeper was interrupted"
+	case errors.Is(result, context.DeadlineExceeded):
+		return ":hourglass: Merge Gatekeeper timed out"
+	default:
+		return ":x: Merge Gatekeeper failed"
+	}
+}
+
+// slackSummary returns the one-line summary of the validator.
+func slackSummary(st validators.Status, err error) string {
+	var pg progresser
+	switch {
+	case errors.As(err, &pg):
+		return pg.Progress()
+	case err != nil:
+		return strings.SplitN(err.Error(), "\n", 2)[0]
+	}
+	if p, ok := st.(progresser); ok {
+		return p.Progress()
+	}
+	switch {
+	case st == nil:
+		return "yet to be validated"
+	case st.IsSuccess():
+		return "successful"
+	default:
+		return "yet to be completed"
+	}
+}
+
+func slackSection(text string) slackBlock {
+	return slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: truncateText(text, maxSlackTextLen)}}
+}
+
+func slackJobList(heading string, jobs []string) string {
+	text := fmt.Sprintf("*%s*", heading)
+	for i, job := range jobs {
+		if i == maxSlackJobs {
+			text += fmt.Sprintf("\n… and %d more", len(jobs)-maxSlackJobs)
+			break
+		}
+		text += "\n• " + job
+	}
+	return text
+}
+
+func slackJobLink(job, url string) string {
+	if len(url) == 0 {
+		return escapeSlack(job)
+	}
+	return fmt.Sprintf("<%s|%s>", url, escapeSlack(job))
+}
+
+// escapeSlack escapes the control characters of the Slack mrkdwn, which is described in
+// https://api.slack.com/reference/surfaces/formatting#escaping.
+func escapeSlack(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
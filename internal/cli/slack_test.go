@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+// validateSlackBlocks validates the structure of the payload against the Block Kit reference
+// (https://api.slack.com/reference/block-kit/blocks) for the blocks which are used.
+func validateSlackBlocks(t *testing.T, doc []byte) {
+	t.Helper()
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(doc, &payload); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if text, ok := payload["text"].(string); !ok || len(text) == 0 {
+		t.Errorf("fallback text is missing: %v", payload["text"])
+	}
+	blocks, ok := payload["blocks"].([]interface{})
+	if !ok || len(blocks) == 0 || len(blocks) > 50 {
+		t.Fatalf("blocks must be an array of 1 to 50 blocks: %v", payload["blocks"])
+	}
+	for i, b := range blocks {
+		block, ok := b.(map[string]interface{})
+		if !ok {
+			t.Fatalf("block %d is not an object: %v", i, b)
+		}
+		text, ok := block["text"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("block %d has no text object: %v", i, block)
+		}
+		body, _ := text["text"].(string)
+		switch block["type"] {
+		case "header":
+			if text["type"] != "plain_text" || utf8.RuneCountInString(body) > 150 {
+				t.Errorf("header block %d must have plain text up to 150 characters: %v", i, text)
+			}
+		case "section":
+			if (text["type"] != "mrkdwn" && text["type"] != "plain_text") || utf8.RuneCountInString(body) > 3000 {
+				t.Errorf("section block %d must have text up to 3000 characters: %v", i, text)
+			}
+		default:
+			t.Errorf("block %d has unexpected type: %v", i, block["type"])
+		}
+		if len(body) == 0 {
+			t.Errorf("block %d has empty text", i)
+		}
+	}
+}
+
+func Test_formatSlackReport(t *testing.T) {
+	statusValidator := &mock.Validator{NameFunc: func() string { return "status" }}
+	labelValidator := &mock.Validator{NameFunc: func() string { return "labels" }}
+
+	tests := map[string]struct {
+		vs       []validators.Validator
+		statuses []validators.Status
+		errs     []error
+		result   error
+		want     []slackBlock
+	}{
+		"lists the failed and pending jobs with links": {
+			vs: []validators.Validator{statusValidator},
+			errs: []error{
+				&jobResultError{jobResultStatus{
+					jobStatuses: []status.JobStatus{
+						{Job: "build", State: "success"},
+						{Job: "e2e <linux>", State: "failure", DetailsURL: "https://ci.example.com/e2e"},
+						{Job: "lint", State: "pending"},
+					},
+				}},
+			},
+			result: errors.New("failed"),
+			want: []slackBlock{
+				{Type: "header", Text: &slackText{Type: "plain_text", Text: ":x: Merge Gatekeeper failed"}},
+				{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*status*: detail"}},
+				{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*Failed jobs*\n• <https://ci.example.com/e2e|e2e &lt;linux&gt;>"}},
+				{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*Pending jobs*\n• lint"}},
+			},
+		},
+		"summarizes each validator when the gate passed": {
+			vs: []validators.Validator{statusValidator, labelValidator},
+			statuses: []validators.Status{
+				&progressStatus{progress: "2/2 checks complete"},
+				&mock.Status{IsSuccessFunc: func() bool { return true }},
+			},
+			result: nil,
+			want: []slackBlock{
+				{Type: "header", Text: &slackText{Type: "plain_text", Text: ":white_check_mark: Merge Gatekeeper passed"}},
+				{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*status*: 2/2 checks complete"}},
+				{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*labels*: successful"}},
+			},
+		},
+		"reports the timeout with the validators yet to be completed": {
+			vs: []validators.Validator{labelValidator},
+			statuses: []validators.Status{
+				&mock.Status{IsSuccessFunc: func() bool { return false }},
+			},
+			result: context.DeadlineExceeded,
+			want: []slackBlock{
+				{Type: "header", Text: &slackText{Type: "plain_text", Text: ":hourglass: Merge Gatekeeper timed out"}},
+				{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*labels*: yet to be completed"}},
+			},
+		},
+		"reports the override label": {
+			vs:     []validators.Validator{labelValidator},
+			result: &overriddenError{label: "override-gatekeeper", prNumber: 1},
+			want: []slackBlock{
+				{Type: "header", Text: &slackText{Type: "plain_text", Text: ":warning: Merge Gatekeeper passed by the override label override-gatekeeper"}},
+				{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*labels*: yet to be validated"}},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			results := &reportResults{
+				statuses: make([]validators.Status, len(tt.vs)),
+				errs:     make([]error, len(tt.vs)),
+				result:   tt.result,
+			}
+			copy(results.statuses, tt.statuses)
+			copy(results.errs, tt.errs)
+			doc, err := formatSlackReport(tt.vs, results)
+			if err != nil {
+				t.Fatalf("formatSlackReport() error = %v", err)
+			}
+			validateSlackBlocks(t, doc)
+
+			var got slackPayload
+			if err := json.Unmarshal(doc, &got); err != nil {
+				t.Fatalf("failed to decode the payload: %v", err)
+			}
+			if got.Text != tt.want[0].Text.Text {
+				t.Errorf("formatSlackReport() text = %q, want %q", got.Text, tt.want[0].Text.Text)
+			}
+			if !reflect.DeepEqual(got.Blocks, tt.want) {
+				t.Errorf("formatSlackReport() blocks = %+v, want %+v", got.Blocks, tt.want)
+			}
+		})
+	}
+}
+
+func Test_slackJobList(t *testing.T) {
+	jobs := make([]string, maxSlackJobs+2)
+	for i := range jobs {
+		jobs[i] = "job"
+	}
+	got := slackJobList("Pending jobs", jobs)
+	if want := "\n… and 2 more"; got[len(got)-len(want):] != want {
+		t.Errorf("slackJobList() = %q, want to end with %q", got, want)
+	}
+}
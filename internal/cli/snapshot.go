@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// errReplayPending is returned when the replayed validation is still pending, as the responses in the snapshot never
+// change however long it is waited.
+var errReplayPending = errors.New("validation is pending in the replayed snapshot")
+
+// newReplayClient returns the client serving the responses recorded in the snapshot file instead of the API.
+func newReplayClient(path string) (github.Client, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	s, err := github.ReadSnapshot(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+	return github.NewReplayClient(s), nil
+}
+
+// writeSnapshot writes the responses recorded by the client to the snapshot file.
+func writeSnapshot(path string, c *github.RecordingClient) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	if err := c.WriteSnapshot(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write snapshot %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	ghmock "github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+func Test_doValidateCmd_replay(t *testing.T) {
+	tests := map[string]struct {
+		runStatus  string
+		conclusion string
+		wantErr    error
+	}{
+		"succeeds when the recorded jobs have succeeded": {
+			runStatus:  "completed",
+			conclusion: "success",
+		},
+		"returns error at once when the recorded jobs are pending": {
+			runStatus: "in_progress",
+			wantErr:   errReplayPending,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "snapshot.json")
+
+			job := "build"
+			recorder := github.NewRecordingClient(&ghmock.Client{
+				// The ref is resolved to its commit, which is replayed as well.
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					return "0123456789abcdef0123456789abcdef01234567", nil, nil
+				},
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{{Name: &job, Status: &tt.runStatus, Conclusion: &tt.conclusion}},
+					}, nil, nil
+				},
+			})
+			v, err := status.CreateValidator(recorder, status.WithGitHubOwnerAndRepo("owner", "repo"), status.WithGitHubRef("main"), status.WithRefResolution(true), status.WithSelfJob("self-job"))
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+			if _, err := v.Validate(ctx); err != nil {
+				t.Fatalf("Validate() unexpected error: %v", err)
+			}
+			if err := writeSnapshot(path, recorder); err != nil {
+				t.Fatalf("writeSnapshot() unexpected error: %v", err)
+			}
+
+			c, err := newReplayClient(path)
+			if err != nil {
+				t.Fatalf("newReplayClient() unexpected error: %v", err)
+			}
+			v, err = status.CreateValidator(c, status.WithGitHubOwnerAndRepo("owner", "repo"), status.WithGitHubRef("main"), status.WithRefResolution(true), status.WithSelfJob("self-job"))
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			if err := doValidateCmd(ctx, cmd, nil, nil, nil, nil, true, v); !errors.Is(err, tt.wantErr) {
+				t.Errorf("doValidateCmd() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+var sweepBase string
+
+func sweepCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Check the gate once for every open pull request targeting a branch, and report which of them are mergeable",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			ghClient, err := newGitHubClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			results, err := status.SweepPullRequests(ctx, ghClient, sweepBase,
+				status.WithGitHubOwnerAndRepo(owner, repo),
+				status.WithSelfJob(selfJobName),
+				status.WithIgnoredJobs(ignoredJobs),
+				status.WithServerVersionDetection(len(ghAPIURL) != 0),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to sweep pull requests: %w", err)
+			}
+
+			return printSweepResults(cmd.OutOrStdout(), sweepBase, results)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+
+	cmd.PersistentFlags().StringVar(&sweepBase, "base", "", "set base branch of the open pull requests to check")
+	cmd.MarkPersistentFlagRequired("base")
+	cmd.PersistentFlags().StringVarP(&selfJobName, "self", "s", defaultSelfJobName, "set self job name, which is disregarded on every pull request")
+	cmd.PersistentFlags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs (comma-separated list)")
+
+	return cmd
+}
+
+func printSweepResults(w io.Writer, base string, results []status.PullRequestResult) error {
+	var mergeable int
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PR\tRESULT\tHEAD\tTITLE")
+	for _, r := range results {
+		if r.Result == status.PullRequestMergeable {
+			mergeable++
+		}
+		title := r.Title
+		if r.Result == status.PullRequestError {
+			// Only the first line is shown, as the errors of the API may span multiple lines.
+			title += ": " + strings.SplitN(r.Err.Error(), "\n", 2)[0]
+		}
+		fmt.Fprintf(tw, "#%d\t%s\t%s\t%s\n", r.Number, r.Result, r.HeadSHA, title)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d of %d open pull request(s) targeting %s are mergeable\n", mergeable, len(results), base)
+	return err
+}
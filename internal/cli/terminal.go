@@ -0,0 +1,12 @@
+package cli
+
+import "os"
+
+// isTerminal reports whether the given file is attached to a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
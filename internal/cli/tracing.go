@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName      = "github.com/upsidr/merge-gatekeeper/internal/cli"
+	otlpServiceName = "merge-gatekeeper"
+
+	// otlpExportTimeout bounds the export, which is also done once the wait has been interrupted.
+	otlpExportTimeout = 10 * time.Second
+)
+
+var otlpEndpoint string
+
+// newTracerProvider creates the provider tracing the wait along with the polls and the API calls made during it,
+// whose spans are exported to the OTLP/HTTP endpoint. Nothing is traced when the endpoint is not set. The returned
+// function exports the remaining spans, where a failure to export is only logged so that the result of the gate is
+// never changed by it.
+func newTracerProvider(ctx context.Context, endpoint string) (trace.TracerProvider, func(logger), error) {
+	if len(endpoint) == 0 {
+		return trace.NewNoopTracerProvider(), func(logger) {}, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || len(u.Host) == 0 {
+		return nil, nil, fmt.Errorf("invalid otlp endpoint %q, which must be a url such as http://localhost:4318", endpoint)
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(path.Join("/", u.Path, "v1/traces")),
+		otlptracehttp.WithTimeout(otlpExportTimeout),
+		// The spans are exported once the wait is over, when retrying would only delay the result of the gate.
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	otlpExporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+	}
+
+	exporter := &failedExport{SpanExporter: otlpExporter}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(otlpServiceName))),
+	)
+	shutdown := func(logger logger) {
+		ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+		defer cancel()
+
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.PrintErrf("  WARNING: failed to shut down tracer provider: %v\n", err)
+		}
+		if err := exporter.failure(); err != nil {
+			logger.PrintErrf("  WARNING: failed to export spans: %v\n", err)
+		}
+	}
+	return tp, shutdown, nil
+}
+
+// failedExport keeps the last failure to export, as the spans are exported in the background where the failure is
+// not returned to the caller.
+type failedExport struct {
+	sdktrace.SpanExporter
+
+	mu  sync.Mutex
+	err error
+}
+
+func (e *failedExport) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	if err != nil {
+		e.mu.Lock()
+		e.err = err
+		e.mu.Unlock()
+	}
+	return err
+}
+
+func (e *failedExport) failure() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+// startWaitSpan starts the span of the wait for the validations, which is the parent of the spans of the polls.
+func startWaitSpan(ctx context.Context, tp trace.TracerProvider, vs int) (context.Context, trace.Span) {
+	if tp == nil {
+		tp = trace.NewNoopTracerProvider()
+	}
+	return tp.Tracer(tracerName).Start(ctx, "wait", trace.WithAttributes(
+		attribute.Int("validators", vs),
+		attribute.Int("timeout_seconds", int(timeoutSecond)),
+		attribute.Int("interval_seconds", int(validateInvalSecond)),
+	))
+}
+
+// endWaitSpan ends the span of the wait, which records the error the wait has ended with.
+func endWaitSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
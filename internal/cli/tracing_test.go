@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+)
+
+func Test_doValidateCmd_tracerProvider(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	polls := 0
+	v := &mock.Validator{
+		NameFunc: func() string { return "validator" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			// The polls of a traced validator are the children of the wait.
+			_, span := tp.Tracer("test").Start(ctx, "poll")
+			span.End()
+			polls++
+			return &mock.Status{
+				DetailFunc:    func() string { return "detail" },
+				IsSuccessFunc: func() bool { return polls == 2 },
+			}, nil
+		},
+	}
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := doValidateCmd(context.Background(), cmd, tp, nil, nil, nil, false, v); err != nil {
+		t.Fatalf("doValidateCmd() error = %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Ended() = %d spans, want the two polls and the wait", len(spans))
+	}
+	wait := spans[2]
+	if wait.Name() != "wait" || wait.Parent().IsValid() || len(wait.Events()) != 0 {
+		t.Fatalf("Ended() last = %s, want the successful wait as the root", wait.Name())
+	}
+	var validatorCount int64
+	for _, attr := range wait.Attributes() {
+		if attr.Key == "validators" {
+			validatorCount = attr.Value.AsInt64()
+		}
+	}
+	if validatorCount != 1 {
+		t.Errorf("wait validators = %d, want 1", validatorCount)
+	}
+	for _, poll := range spans[:2] {
+		if poll.Parent().SpanID() != wait.SpanContext().SpanID() || poll.SpanContext().TraceID() != wait.SpanContext().TraceID() {
+			t.Errorf("poll of trace %s and parent %s, want the child of the wait", poll.SpanContext().TraceID(), poll.Parent().SpanID())
+		}
+	}
+}
+
+func Test_newTracerProvider(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			requests++
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("traces nothing without the endpoint", func(t *testing.T) {
+		tp, shutdown, err := newTracerProvider(context.Background(), "")
+		if err != nil {
+			t.Fatalf("newTracerProvider() error = %v", err)
+		}
+		_, span := tp.Tracer(tracerName).Start(context.Background(), "wait")
+		span.End()
+		shutdown(&cobra.Command{})
+		if span.IsRecording() || requests != 0 {
+			t.Errorf("newTracerProvider() recorded the span, want nothing traced")
+		}
+	})
+
+	t.Run("rejects the endpoint which is not a url", func(t *testing.T) {
+		if _, _, err := newTracerProvider(context.Background(), "localhost"); err == nil {
+			t.Error("newTracerProvider() error = nil, want the invalid endpoint")
+		}
+	})
+
+	t.Run("exports the spans once shut down", func(t *testing.T) {
+		tp, shutdown, err := newTracerProvider(context.Background(), srv.URL)
+		if err != nil {
+			t.Fatalf("newTracerProvider() error = %v", err)
+		}
+		_, span := tp.Tracer(tracerName).Start(context.Background(), "wait")
+		span.End()
+
+		cmd := &cobra.Command{}
+		errOut := &bytes.Buffer{}
+		cmd.SetErr(errOut)
+		shutdown(cmd)
+		if requests != 1 || errOut.Len() != 0 {
+			t.Errorf("exported %d times with %q, want the wait span exported once", requests, errOut.String())
+		}
+	})
+
+	t.Run("only logs a failure to export", func(t *testing.T) {
+		tp, shutdown, err := newTracerProvider(context.Background(), srv.URL)
+		if err != nil {
+			t.Fatalf("newTracerProvider() error = %v", err)
+		}
+		_, span := tp.Tracer(tracerName).Start(context.Background(), "wait")
+		span.End()
+
+		srv.Close()
+		cmd := &cobra.Command{}
+		errOut := &bytes.Buffer{}
+		cmd.SetErr(errOut)
+		shutdown(cmd)
+		if !strings.Contains(errOut.String(), "WARNING: failed to export spans") {
+			t.Errorf("shutdown logged %q, want the warning", errOut.String())
+		}
+	})
+}
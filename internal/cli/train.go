@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+// errTrainBlocked is returned when a commit of the merge train is not green, so that the train system can tell
+// from the exit code that the train is to be truncated.
+var errTrainBlocked = errors.New("merge train is blocked")
+
+var trainCommits string
+
+func trainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "train",
+		Short: "Check the gate once for each commit of a merge train in its order, and report the first commit which is not green",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			str := os.Getenv("GITHUB_REPOSITORY")
+			if len(str) != 0 {
+				ghRepo = str
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			owner, repo := ownerAndRepository(ghRepo)
+			if len(owner) == 0 || len(repo) == 0 {
+				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
+			}
+
+			ghClient, err := newGitHubClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			result, err := status.ValidateTrain(ctx, ghClient, splitTrainCommits(trainCommits),
+				status.WithGitHubOwnerAndRepo(owner, repo),
+				status.WithSelfJob(selfJobName),
+				status.WithIgnoredJobs(ignoredJobs),
+				status.WithServerVersionDetection(len(ghAPIURL) != 0),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to validate merge train: %w", err)
+			}
+
+			printTrainResult(cmd.OutOrStdout(), result)
+			if blocking := result.BlockingCommit(); blocking != nil {
+				return fmt.Errorf("%w at commit %s", errTrainBlocked, blocking.SHA)
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
+
+	cmd.PersistentFlags().StringVar(&trainCommits, "commits", "", "set commits of the merge train in its order, from the first to merge (comma-separated list)")
+	cmd.MarkPersistentFlagRequired("commits")
+	cmd.PersistentFlags().StringVarP(&selfJobName, "self", "s", defaultSelfJobName, "set self job name, which is disregarded on every commit")
+	cmd.PersistentFlags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs (comma-separated list)")
+
+	return cmd
+}
+
+func splitTrainCommits(str string) []string {
+	var shas []string
+	for _, sha := range strings.Split(str, ",") {
+		if sha = strings.TrimSpace(sha); len(sha) != 0 {
+			shas = append(shas, sha)
+		}
+	}
+	return shas
+}
+
+func printTrainResult(w io.Writer, result *status.TrainResult) {
+	for i, commit := range result.Commits {
+		fmt.Fprintf(w, "%d. %s: %s\n", i+1, commit.SHA, commit.Result)
+	}
+
+	blocking := result.BlockingCommit()
+	if blocking == nil {
+		fmt.Fprintf(w, "\nAll %d commit(s) of the merge train are green\n", len(result.Commits))
+		return
+	}
+	fmt.Fprintf(w, "\nThe merge train is to be truncated before commit %d (%s), which is %s\n", result.Blocking+1, blocking.SHA, blocking.Result)
+	switch {
+	case len(blocking.FailedJobs) != 0:
+		fmt.Fprintln(w, "\nFailed jobs:")
+		printJobList(w, blocking.FailedJobs)
+	case len(blocking.PendingJobs) != 0:
+		fmt.Fprintln(w, "\nPending jobs:")
+		printJobList(w, blocking.PendingJobs)
+	case blocking.Err != nil:
+		fmt.Fprintf(w, "\nError: %v\n", blocking.Err)
+	}
+}
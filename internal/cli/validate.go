@@ -2,16 +2,22 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/upsidr/merge-gatekeeper/internal/github"
 	"github.com/upsidr/merge-gatekeeper/internal/ticker"
 	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/approval"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/files"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/label"
 	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
 )
 
@@ -22,12 +28,97 @@ var (
 	ghRepo              string // e.g) upsidr/merge-gatekeeper
 	ghRef               string
 	timeoutSecond       uint
+	maxTotalWaitSecond  uint
+	deadlineFile        string
 	validateInvalSecond uint
 	selfJobName         string
 	ignoredJobs         string
+	onlyJobs            string
+	requiredEnvs        string
+	showProgress        bool
+	quietMode           bool
+	watchMode           bool
+	ignoreSelfSuite     bool
+	strictStates        bool
+	resolveRef          bool
+	failingOnly         bool
+	timingReport        bool
+	reverifySecond      uint
+	minCommitAgeSecond  uint
+	hungRunSecond       uint
+	refCacheSecond      uint
+	nonBlockingPending  string
+	passUnlessFailing   bool
+	hardBlockingJobs    string
+	annotationJobs      string
+	stages              string
+	conditionalJobs     string
+	prNumber            int
+	followHead          bool
+	draftSkipped        string
+	pageConcurrency     int
+	requireSelfJob      bool
+	matrixSelfJob       bool
+	reportSelfFailure   bool
+	requireCompleted    bool
+	intermediateSHAs    string
+	checkBaseBranch     bool
+	maxCommitsBehind    int
+	failOnIntermediate  bool
+	checkCombinedState  bool
+	toleratedConclusion string
+	appConclusions      string
+	stablePolls         int
+	onJobSetShrink      string
+	failOnRegression    bool
+	onHeadSHAMismatch   string
+	expectedSHA         string
+	onErrorState        string
+	errorStateRetries   int
+	summaryTemplate     string
+	skipUnchanged       bool
+	requiredJobs        string
+	requiredChecksFile  string
+	ignoredJobsFile     string
+	protectionBranch    string
+	requiredAsPending   bool
+	allowPartial        bool
+	jobTimeouts         string
+	requiredLabels      string
+	forbiddenLabels     string
+	requiredPaths       string
+	forbiddenPaths      string
+	minApprovals        int
+	requiredReviewers   string
+	requiredTeams       string
+	postStatus          bool
+	prComment           bool
+	skipPermCheck       bool
+	mergeRef            bool
+	allowedTargetHosts  string
+	allowedWorkflows    string
+	latestCheckSuites   bool
+	failOnUntrusted     bool
+	requiredDescs       string
+	maxMatchingJobs     string
+	toleranceWindow     string
+	overrideLabel       string
+	junitReport         string
+	slackReport         string
+	minDistinctApps     int
+	recordSnapshot      string
+	replaySnapshot      string
+	workflowRunID       int64
+	jobAliases          string
+	checkRunStates      string
+	successExitCode     int
+	timeoutExitCode     int
+	failureExitCode     int
 )
 
-func validateCmd() *cobra.Command {
+// validateCmd returns the validate command, which sets the exit codes configured by its flags to codes once they are
+// validated.
+func validateCmd(codes *exitCodeMap) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate other github actions job",
@@ -40,37 +131,363 @@ func validateCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
+			configured := exitCodeMap{success: successExitCode, timeout: timeoutExitCode, failure: failureExitCode}
+			if err := configured.validate(); err != nil {
+				return err
+			}
+			*codes = configured
+
 			owner, repo := ownerAndRepository(ghRepo)
 			if len(owner) == 0 || len(repo) == 0 {
 				return fmt.Errorf("github owner or repository is empty. owner: %s, repository: %s", owner, repo)
 			}
 
-			statusValidator, err := status.CreateValidator(github.NewClient(ctx, ghToken),
+			timeouts, err := parseJobTimeouts(jobTimeouts)
+			if err != nil {
+				return err
+			}
+
+			aliases, err := parseJobAliases(jobAliases)
+			if err != nil {
+				return err
+			}
+
+			runStates, err := parseCheckRunStates(checkRunStates)
+			if err != nil {
+				return err
+			}
+
+			descriptions, err := parseRequiredDescriptions(requiredDescs)
+			if err != nil {
+				return err
+			}
+
+			jobCounts, err := parseMaxMatchingJobs(maxMatchingJobs)
+			if err != nil {
+				return err
+			}
+
+			if len(recordSnapshot) != 0 && len(replaySnapshot) != 0 {
+				return errors.New("snapshot cannot be recorded while replaying another one")
+			}
+
+			// The deadline is persisted across the invocations, so that the total wait is bounded even when an outer
+			// loop keeps relaunching the gatekeeper.
+			waitDeadline = time.Time{}
+			if len(deadlineFile) != 0 {
+				if maxTotalWaitSecond == 0 {
+					return errors.New("deadline file requires the maximum total wait")
+				}
+				now := time.Now()
+				waitDeadline, err = loadDeadline(deadlineFile, time.Duration(maxTotalWaitSecond)*time.Second, now)
+				if err != nil {
+					return err
+				}
+				remaining := remainingWait(waitDeadline, now)
+				if remaining == 0 {
+					return fmt.Errorf("%w at %s", errTotalWaitExceeded, waitDeadline.Format(time.RFC3339))
+				}
+				cmd.Printf("Remaining total wait: %s, until %s\n", remaining.Round(time.Second), waitDeadline.Format(time.RFC3339))
+			}
+
+			// The spans of the wait are exported once it is over, even when it has been interrupted.
+			tracerProvider, shutdownTracing, err := newTracerProvider(ctx, otlpEndpoint)
+			if err != nil {
+				return err
+			}
+			defer shutdownTracing(cmd)
+
+			// The replayed validation needs neither the token nor the permissions, as no request is sent.
+			var ghClient github.Client
+			var recorder *github.RecordingClient
+			if len(replaySnapshot) != 0 {
+				ghClient, err = newReplayClient(replaySnapshot)
+			} else {
+				// Unchanged responses can only be detected with the ETag cache.
+				ghClient, err = newGitHubClient(ctx,
+					github.WithETagCache(skipUnchanged),
+					github.WithTracerProvider(tracerProvider),
+				)
+			}
+			if err != nil {
+				return err
+			}
+			if len(recordSnapshot) != 0 {
+				recorder = github.NewRecordingClient(ghClient)
+				ghClient = recorder
+			}
+
+			// The permissions are checked before polling, so that the wait does not end in a permission error.
+			if !skipPermCheck && len(replaySnapshot) == 0 {
+				if err := status.CheckPermissions(ctx, ghClient, postStatus,
+					status.WithGitHubOwnerAndRepo(owner, repo),
+					status.WithGitHubRef(ghRef),
+					status.WithPullRequest(prNumber),
+				); err != nil {
+					return fmt.Errorf("permission check failed: %w", err)
+				}
+			}
+
+			jobLists, err := resolveJobLists(ctx, cmd, ghClient, owner, repo)
+			if err != nil {
+				return err
+			}
+
+			statusValidator, err := status.CreateValidator(ghClient,
 				status.WithSelfJob(selfJobName),
+				status.WithRequireSelfJob(requireSelfJob),
+				status.WithMatrixSelfJobMatch(matrixSelfJob),
+				status.WithReportSelfFailure(reportSelfFailure),
 				status.WithGitHubOwnerAndRepo(owner, repo),
 				status.WithGitHubRef(ghRef),
-				status.WithIgnoredJobs(ignoredJobs),
+				status.WithServerVersionDetection(len(ghAPIURL) != 0),
+				status.WithRefResolution(resolveRef),
+				status.WithPullRequest(prNumber),
+				status.WithFollowPullRequestHead(followHead),
+				status.WithMergeRef(mergeRef),
+				status.WithPageConcurrency(pageConcurrency),
+				status.WithRefCacheTTL(time.Duration(refCacheSecond)*time.Second),
+				status.WithPartialResults(allowPartial),
+				status.WithJobAliases(aliases),
+				status.WithIgnoredJobs(strings.Join(jobLists.Ignored, ",")),
+				status.WithOnlyJobs(onlyJobs),
+				status.WithRequiredEnvironments(requiredEnvs),
+				status.WithRequiredJobs(jobLists.Required...),
+				status.WithRequiredMissingAsPending(requiredAsPending),
+				status.WithNonBlockingPendingJobs(nonBlockingPending),
+				status.WithPassUnlessFailing(passUnlessFailing),
+				status.WithHardBlockingJobs(hardBlockingJobs),
+				status.WithAnnotationCheckedJobs(strings.Split(annotationJobs, ",")...),
+				status.WithStages(strings.Split(stages, ",")...),
+				status.WithConditionalJobs(conditionalJobs),
+				status.WithMinDistinctApps(minDistinctApps),
+				status.WithWorkflowRunID(workflowRunID),
+				status.WithDraftSkippedJobs(draftSkipped),
+				status.WithToleratedConclusions(toleratedConclusion),
+				status.WithAppConclusions(appConclusions),
+				status.WithCheckRunStates(runStates),
+				status.WithToleranceWindow(toleranceWindow),
+				status.WithIgnoreSelfCheckSuite(ignoreSelfSuite),
+				status.WithSelfWorkflowRunID(os.Getenv("GITHUB_RUN_ID")),
+				status.WithStrictStates(strictStates),
+				status.WithAllowedTargetHosts(allowedTargetHosts),
+				status.WithAllowedWorkflows(allowedWorkflows),
+				status.WithLatestCheckSuitesOnly(latestCheckSuites),
+				status.WithFailOnUntrustedTarget(failOnUntrusted),
+				status.WithRequiredDescriptions(descriptions),
+				status.WithMaxMatchingJobs(jobCounts),
+				status.WithRequireCompletedRuns(requireCompleted),
+				status.WithIntermediateSHAs(intermediateSHAs),
+				status.WithFailOnIntermediate(failOnIntermediate),
+				status.WithCombinedStateCheck(checkCombinedState),
+				status.WithBaseBranchCheck(checkBaseBranch),
+				status.WithMaxCommitsBehind(maxCommitsBehind),
+				status.WithFailingOnlyReport(failingOnly),
+				status.WithTimingReport(timingReport),
+				status.WithSummaryTemplate(summaryTemplate),
+				status.WithSkipUnchangedPolls(skipUnchanged),
+				status.WithJobTimeouts(timeouts),
+				status.WithHungRunThreshold(time.Duration(hungRunSecond)*time.Second),
+				status.WithPostSuccessReverify(time.Duration(reverifySecond)*time.Second),
+				status.WithMinCommitAge(time.Duration(minCommitAgeSecond)*time.Second),
+				status.WithStableConsecutivePolls(stablePolls),
+				status.WithJobSetShrinkPolicy(status.JobSetShrinkPolicy(onJobSetShrink)),
+				status.WithFailOnRegression(failOnRegression),
+				status.WithHeadSHAMismatchPolicy(status.HeadSHAMismatchPolicy(onHeadSHAMismatch)),
+				status.WithExpectedSHA(expectedSHA),
+				status.WithErrorStatePolicy(status.ErrorStatePolicy(onErrorState)),
+				status.WithErrorStateRetries(errorStateRetries),
+				status.WithTracerProvider(tracerProvider),
 			)
 			if err != nil {
 				return fmt.Errorf("failed to create validator: %w", err)
 			}
 
+			vs := []validators.Validator{statusValidator}
+			if len(requiredLabels) != 0 || len(forbiddenLabels) != 0 {
+				labelValidator, err := label.CreateValidator(ghClient,
+					label.WithGitHubOwnerAndRepo(owner, repo),
+					label.WithPullRequest(prNumber),
+					label.WithRequiredLabels(requiredLabels),
+					label.WithForbiddenLabels(forbiddenLabels),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create label validator: %w", err)
+				}
+				vs = append(vs, labelValidator)
+			}
+			if len(requiredPaths) != 0 || len(forbiddenPaths) != 0 {
+				filesValidator, err := files.CreateValidator(ghClient,
+					files.WithGitHubOwnerAndRepo(owner, repo),
+					files.WithPullRequest(prNumber),
+					files.WithRequiredPaths(requiredPaths),
+					files.WithForbiddenPaths(forbiddenPaths),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create files validator: %w", err)
+				}
+				vs = append(vs, filesValidator)
+			}
+			if minApprovals != 0 || len(requiredReviewers) != 0 || len(requiredTeams) != 0 {
+				approvalValidator, err := approval.CreateValidator(ghClient,
+					approval.WithGitHubOwnerAndRepo(owner, repo),
+					approval.WithPullRequest(prNumber),
+					approval.WithMinApprovals(minApprovals),
+					approval.WithRequiredReviewers(requiredReviewers),
+					approval.WithRequiredTeams(requiredTeams),
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create approval validator: %w", err)
+				}
+				vs = append(vs, approvalValidator)
+			}
+
+			// The status is posted with the self job name, so that the status validator ignores it as the self job.
+			var poster *statusPoster
+			if postStatus {
+				poster = newStatusPoster(ghClient, owner, repo, ghRef, prNumber, selfJobName, followHead)
+			}
+
+			var override *gateOverride
+			if len(overrideLabel) != 0 {
+				if prNumber == 0 {
+					return errors.New("override label requires the pull request number")
+				}
+				override = newGateOverride(ghClient, owner, repo, prNumber, overrideLabel)
+			}
+
+			// The comment is also named after the self job, so that the gates on the same pull request do not collide.
+			var commenter *commentPoster
+			if prComment {
+				if prNumber == 0 {
+					return errors.New("pull request comment requires the pull request number")
+				}
+				commenter = newCommentPoster(ghClient, owner, repo, prNumber, selfJobName)
+			}
+
 			cmd.SilenceUsage = true
-			return doValidateCmd(ctx, cmd, statusValidator)
+			reports := []reportFile{
+				{path: junitReport, format: formatJUnitReport},
+				{path: slackReport, format: formatSlackReport},
+				{path: os.Getenv(actionsOutputEnv), format: formatActionsOutput, append: true},
+				commenter.reportFile(),
+			}
+			result := doValidateCmd(ctx, cmd, tracerProvider, override, poster, reports, len(replaySnapshot) != 0, vs...)
+			if recorder != nil {
+				if err := writeSnapshot(recordSnapshot, recorder); err != nil {
+					if result == nil {
+						return err
+					}
+					cmd.PrintErrf("  WARNING: %v\n", err)
+				}
+			}
+			return result
 		},
 	}
 
 	cmd.PersistentFlags().StringVarP(&selfJobName, "self", "s", defaultSelfJobName, "set self job name")
+	cmd.PersistentFlags().BoolVar(&requireSelfJob, "require-self", false, "fail when the self job is not found, which indicates misconfiguration")
+	cmd.PersistentFlags().BoolVar(&matrixSelfJob, "self-matrix", false, "also disregard the jobs of the matrix of the self job, such as \"merge-gatekeeper (ubuntu-latest)\", rather than only the job of the exact self job name")
+	cmd.PersistentFlags().BoolVar(&reportSelfFailure, "report-self-failure", false, "report the self job when it has failed, e.g. by an earlier step of the same job, while it is still not blocking")
+	cmd.PersistentFlags().BoolVar(&postStatus, "post-status", false, "post the aggregate result as a commit status named after the self job onto the ref")
+	cmd.PersistentFlags().BoolVar(&prComment, "pr-comment", false, "post the summary as a comment on the pull request, which is edited on the later runs instead of posting another one")
+	cmd.PersistentFlags().BoolVar(&skipPermCheck, "skip-permission-check", false, "skip checking the permissions of the token before polling")
 
 	cmd.PersistentFlags().StringVarP(&ghRepo, "repo", "r", "", "set github repository")
 
-	cmd.PersistentFlags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name")
-	cmd.MarkPersistentFlagRequired("ref")
+	cmd.PersistentFlags().StringVar(&ghRef, "ref", "", "set ref of github repository. the ref can be a SHA, a branch name, or tag name. this is required unless the pull request is set")
+	cmd.PersistentFlags().IntVar(&prNumber, "pull-request", 0, "set pull request number to validate its head commit")
+	cmd.PersistentFlags().StringVar(&requiredLabels, "required-labels", "", "set labels which the pull request must have (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&forbiddenLabels, "forbidden-labels", "", "set labels which the pull request must not have (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&requiredPaths, "required-paths", "", "set paths which the pull request must change, e.g. \"CHANGELOG.md\", where a path ending with a slash matches any file under it (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&forbiddenPaths, "forbidden-paths", "", "set paths which the pull request must not change, e.g. \"vendor/\" (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&overrideLabel, "override-label", "", "set label which passes the gate immediately without validating when the pull request has it, e.g. \"override-gatekeeper\"")
+	cmd.PersistentFlags().IntVar(&minApprovals, "min-approvals", 0, "set minimum number of approving reviews on the head commit of the pull request, 0 means no approval is required")
+	cmd.PersistentFlags().StringVar(&requiredReviewers, "required-reviewers", "", "set users who must have approved the head commit of the pull request (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&requiredTeams, "required-teams", "", "set teams of which any member must have approved the head commit of the pull request, either org/team-slug or team-slug (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&followHead, "follow-head", false, "re-resolve the head commit of the pull request on every poll")
+	cmd.PersistentFlags().BoolVar(&mergeRef, "merge-ref", false, "validate the test merge commit of the pull request instead of its head, and fail when it has merge conflicts")
+	cmd.PersistentFlags().StringVar(&intermediateSHAs, "intermediate-shas", "", "set commits of the push range other than the head to report their states (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&failOnIntermediate, "fail-on-intermediate", false, "fail when any job of the intermediate commits has failed")
+	cmd.PersistentFlags().BoolVar(&checkCombinedState, "check-combined-state", false, "require the overall state of the commit statuses aggregated by github to be successful once all the jobs are green")
+	cmd.PersistentFlags().BoolVar(&checkBaseBranch, "check-base-branch", false, "fail when any job of the head of the base branch of the pull request has failed")
+	cmd.PersistentFlags().IntVar(&maxCommitsBehind, "max-commits-behind", -1, "set number of commits which the head of the pull request may be behind its base branch, a negative number means it is not checked")
+	cmd.PersistentFlags().BoolVar(&resolveRef, "resolve-ref", true, "resolve the ref to its commit SHA before validating, so that a moving branch does not affect the result")
 
 	cmd.PersistentFlags().UintVar(&timeoutSecond, "timeout", 600, "set validate timeout second")
+	cmd.PersistentFlags().UintVar(&maxTotalWaitSecond, "max-total-wait", 0, "set maximum total wait second across the invocations sharing the deadline file")
+	cmd.PersistentFlags().StringVar(&deadlineFile, "deadline-file", "", "set path of the state file persisting the deadline of the total wait, created by the first invocation")
 	cmd.PersistentFlags().UintVar(&validateInvalSecond, "interval", 10, "set validate interval second")
+	cmd.PersistentFlags().IntVar(&successExitCode, "success-exit-code", ExitCodeSuccess, "set exit code when all validations are successful, which may be nonzero to trigger a downstream step")
+	cmd.PersistentFlags().IntVar(&timeoutExitCode, "timeout-exit-code", ExitCodeFailure, "set exit code when the validations time out")
+	cmd.PersistentFlags().IntVar(&failureExitCode, "failure-exit-code", ExitCodeFailure, "set exit code when any validation fails")
+	cmd.PersistentFlags().StringVar(&jobTimeouts, "job-timeouts", "", "set timeouts of the named jobs since they started, which fail the validation before the global timeout, e.g. \"lint=5m\" (comma-separated list)")
+	cmd.PersistentFlags().UintVar(&hungRunSecond, "hung-run-threshold", 0, "set second for which a check run may be in progress before it is regarded as hung and fails the validation, 0 means it is not")
+	cmd.PersistentFlags().BoolVar(&skipUnchanged, "skip-unchanged", false, "send conditional requests, and skip recomputing and reporting while nothing has changed")
+	cmd.PersistentFlags().UintVar(&reverifySecond, "reverify", 0, "set second to wait and re-validate after all jobs are green before declaring success")
+	cmd.PersistentFlags().UintVar(&minCommitAgeSecond, "min-commit-age", 0, "set second since the validated commit has been committed before declaring success, so that all its checks have registered, 0 means it is not waited")
+	cmd.PersistentFlags().BoolVar(&failOnRegression, "fail-on-regression", false, "fail as soon as a job which has succeeded in a previous poll no longer does, e.g. as it has been rerun, instead of waiting for it to complete again")
+	cmd.PersistentFlags().StringVar(&onJobSetShrink, "on-job-set-shrink", "", "set behavior when the number of jobs decreases between polls, either \"reset\" or \"fail\"")
+	cmd.PersistentFlags().StringVar(&expectedSHA, "expected-sha", "", "set full commit SHA which the commit statuses and the check runs of the ref must be reported for, and fail otherwise")
+	cmd.PersistentFlags().StringVar(&onHeadSHAMismatch, "on-head-sha-mismatch", "", "set behavior for check runs attached to another head SHA than the validated commit, either \"ignore\" or \"fail\"")
+	cmd.PersistentFlags().StringVar(&onErrorState, "on-error-state", "", "set behavior for commit statuses in error state, which usually indicates infrastructure problems, \"retry\" to keep waiting for them to be retried")
+	cmd.PersistentFlags().IntVar(&errorStateRetries, "error-state-retries", 0, "set number of polls for which a commit status in error state is waited to be retried before failing, 0 means it is waited until the timeout")
+	cmd.PersistentFlags().IntVar(&stablePolls, "stable-polls", 0, "set number of consecutive polls for which all jobs must be green with the identical states before declaring success")
+
+	cmd.PersistentFlags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs, merged with "+ignoredJobsEnv+" (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&jobAliases, "job-aliases", "", "set old names of renamed jobs mapped to their new names, which are matched with either name, e.g. \"test=unit-test\" (comma-separated list)")
+	cmd.PersistentFlags().Int64Var(&workflowRunID, "workflow-run-id", 0, "set workflow run whose jobs are validated instead of all the jobs for the ref, 0 means all the jobs are validated")
+	cmd.PersistentFlags().StringVar(&onlyJobs, "only", "", "set the only jobs to validate, disregarding all the other jobs (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&requiredEnvs, "required-environments", "", "set environments which the ref must be successfully deployed to, the latest deployment to each is validated (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&requiredJobs, "required", "", "set patterns of jobs which must be reported and succeed, merged with "+requiredJobsEnv+", the required checks file and the branch protection (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&maxMatchingJobs, "max-matching-jobs", "", "set maximum numbers of jobs which the patterns may match, failing when more jobs match, e.g. \"test-shard-*=50\" (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&requiredAsPending, "required-missing-as-pending", true, "regard required jobs which are not reported yet as pending until the timeout, instead of failing immediately")
+	cmd.PersistentFlags().StringVar(&requiredChecksFile, "required-checks-file", "", "set path of the file listing patterns of required jobs, one per line")
+	cmd.PersistentFlags().StringVar(&ignoredJobsFile, "ignored-jobs-file", "", "set path of the file listing ignored jobs, one per line, merged with the ignored jobs")
+	cmd.PersistentFlags().StringVar(&protectionBranch, "protection-branch", "", "set branch whose protection's required status checks must be reported and succeed as well, e.g. \"main\"")
+	cmd.PersistentFlags().StringVar(&nonBlockingPending, "non-blocking-pending", "", "set jobs which are ignored only while pending (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&passUnlessFailing, "pass-unless-failing", false, "pass as long as no job is failing and no required job is pending, while the other pending jobs do not block")
+	cmd.PersistentFlags().StringVar(&hardBlockingJobs, "hard-blocking", "", "set jobs which abort the validation at once when they fail, even while the other jobs are pending (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&annotationJobs, "fail-on-error-annotations", "", "set jobs whose check runs fail when they have emitted error annotations, even when they have succeeded (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&stages, "stages", "", "set stages of the pipeline in their order to group the jobs in the summary, each a prefix of the job names or name=pattern, e.g. build,test,deploy (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&conditionalJobs, "conditional", "", "set jobs which are ignored until they are reported, and validated once they are (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&toleratedConclusion, "tolerated-conclusions", "", "set check run conclusions tolerated only for the named jobs, e.g. \"e2e:timed_out\" (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&checkRunStates, "check-run-states", "", "set check run states mapped to success, failure, pending or skipped, overriding the defaults, e.g. \"completed:neutral=failure\" (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&appConclusions, "app-conclusions", "", "set check run conclusions regarded as success only for the named apps while blocking for the others, e.g. \"some-app:neutral\" (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&toleranceWindow, "tolerance-window", "", "set daily window within which the tolerated conclusions are tolerated, e.g. \"06:00-10:00\" in UTC or \"06:00-10:00 Asia/Tokyo\"")
+	cmd.PersistentFlags().IntVar(&minDistinctApps, "min-distinct-apps", 0, "set minimum number of distinct apps which must have posted successful check runs, 0 means any number of apps is allowed")
+	cmd.PersistentFlags().StringVar(&draftSkipped, "draft-skipped", "", "set jobs which are not required while the pull request is a draft (comma-separated list)")
+
+	cmd.PersistentFlags().StringVar(&allowedWorkflows, "allowed-workflows", "", "set names of the only github actions workflows whose check runs are validated, ignoring the check runs of any other workflow (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&latestCheckSuites, "latest-check-suites", false, "only validate the check runs of the latest check suite of each github actions workflow, dropping the runs lingering in the suites which have been re-run")
+	cmd.PersistentFlags().BoolVar(&ignoreSelfSuite, "ignore-self-suite", false, "ignore all check runs in the same check suite as the gatekeeper")
+
+	cmd.PersistentFlags().BoolVar(&strictStates, "strict-states", false, "fail when any job is in a state which is not recognised")
+	cmd.PersistentFlags().StringVar(&allowedTargetHosts, "allowed-target-hosts", "", "set hosts which the target URLs of commit statuses must point to, e.g. \"ci.example.com\" or \"*.example.com\" (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&failOnUntrusted, "fail-on-untrusted-target", false, "fail when a commit status targets a host which is not allowed, instead of only reporting it")
+	cmd.PersistentFlags().StringVar(&requiredDescs, "required-descriptions", "", "set regular expressions which the descriptions of the named commit statuses must match to succeed, e.g. \"license-scan=0 violations\" (comma-separated list)")
+	cmd.PersistentFlags().BoolVar(&requireCompleted, "require-completed", false, "require check runs to be completed even when a commit status of the same name reports success")
+
+	cmd.PersistentFlags().BoolVar(&failingOnly, "failing-only", false, "only list failed and incomplete jobs in the report")
+	cmd.PersistentFlags().BoolVar(&timingReport, "timing-report", false, "report time spent by each job, such as how long it has been queued and run")
+	cmd.PersistentFlags().StringVar(&junitReport, "junit-report", "", "set path of the file to write the job results to in the JUnit XML format")
+	cmd.PersistentFlags().StringVar(&slackReport, "slack-report", "", "set path of the file to write the result to as a slack block kit payload, which can be posted to an incoming webhook")
+	cmd.PersistentFlags().StringVar(&summaryTemplate, "summary-template", "", "set go text/template for the summary at the top of the report, e.g. \"{{ .Completed }}/{{ .Total }} in {{ .Duration }}\"")
 
-	cmd.PersistentFlags().StringVarP(&ignoredJobs, "ignored", "i", "", "set ignored jobs (comma-separated list)")
+	cmd.PersistentFlags().StringVar(&recordSnapshot, "record-snapshot", "", "set path of the file to record the raw responses of the statuses and the check runs to, which can be replayed for debugging")
+	cmd.PersistentFlags().StringVar(&replaySnapshot, "replay-snapshot", "", "set path of the recorded snapshot to validate against instead of the github api, the ref or the pull request must be given as it was recorded")
+
+	cmd.PersistentFlags().IntVar(&pageConcurrency, "page-concurrency", 4, "set maximum number of pages fetched at once from github api")
+	cmd.PersistentFlags().UintVar(&refCacheSecond, "ref-cache-ttl", 0, "set second for which the statuses of a ref are reused instead of fetched again, which should be shorter than the interval, 0 means they are not")
+	cmd.PersistentFlags().BoolVar(&allowPartial, "allow-partial", false, "proceed with the pages fetched before a page failed and keep polling, instead of failing")
+
+	cmd.PersistentFlags().BoolVar(&showProgress, "progress", false, "show progress in place on each poll when attached to a terminal")
+	cmd.PersistentFlags().StringVar(&otlpEndpoint, "otlp-endpoint", "", "set otlp/http endpoint of opentelemetry collector, such as http://localhost:4318, to export the spans of the wait, the polls and the github api calls to")
+	cmd.PersistentFlags().BoolVar(&watchMode, "watch", false, "redraw a board of all the jobs on each poll until the validation is over when attached to a terminal, or append it whenever the jobs change otherwise")
+	cmd.PersistentFlags().BoolVar(&quietMode, "quiet", false, "log nothing on each poll until a job fails, all the jobs turn green or the wait is over, and then print the summary once")
+
+	// The configuration is resolved from the same flags, so that it is printed as the validation would take it.
+	cmd.AddCommand(validateConfigCmd())
 
 	return cmd
 }
@@ -89,6 +506,125 @@ func ownerAndRepository(str string) (owner string, repo string) {
 	}
 }
 
+// parseJobTimeouts parses the per-job timeouts such as "lint=5m,e2e=30m".
+func parseJobTimeouts(str string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range strings.Split(str, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		// The timeout is split at the last equal sign, as job names may contain equal signs.
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid job timeout %q, must be in the form of job=duration", entry)
+		}
+		job := strings.TrimSpace(entry[:i])
+		timeout, err := time.ParseDuration(strings.TrimSpace(entry[i+1:]))
+		if err != nil || len(job) == 0 {
+			return nil, fmt.Errorf("invalid job timeout %q, must be in the form of job=duration", entry)
+		}
+		timeouts[job] = timeout
+	}
+	return timeouts, nil
+}
+
+// parseJobAliases parses the old names of the renamed jobs mapped to their new names such as "test=unit-test".
+func parseJobAliases(str string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	for _, entry := range strings.Split(str, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		// The alias is split at the last equal sign, as with the job timeouts.
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid job alias %q, must be in the form of old=new", entry)
+		}
+		old, renamed := strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		if len(old) == 0 || len(renamed) == 0 {
+			return nil, fmt.Errorf("invalid job alias %q, must be in the form of old=new", entry)
+		}
+		aliases[old] = renamed
+	}
+	return aliases, nil
+}
+
+// parseRequiredDescriptions parses the patterns of the descriptions of the commit statuses such as
+// "license-scan=0 violations".
+func parseRequiredDescriptions(str string) (map[string]string, error) {
+	patterns := make(map[string]string)
+	for _, entry := range strings.Split(str, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		// Unlike the job timeouts, the pattern is split at the first equal sign, as patterns may contain equal signs.
+		i := strings.Index(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid required description %q, must be in the form of context=pattern", entry)
+		}
+		context, pattern := strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		if len(context) == 0 || len(pattern) == 0 {
+			return nil, fmt.Errorf("invalid required description %q, must be in the form of context=pattern", entry)
+		}
+		patterns[context] = pattern
+	}
+	return patterns, nil
+}
+
+// parseMaxMatchingJobs parses the maximum numbers of the jobs which the patterns may match such as "test-shard-*=50".
+func parseMaxMatchingJobs(str string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, entry := range strings.Split(str, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		// The number is split at the last equal sign, as regular expressions may contain equal signs.
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid maximum matching jobs %q, must be in the form of pattern=number", entry)
+		}
+		pattern := strings.TrimSpace(entry[:i])
+		max, err := strconv.Atoi(strings.TrimSpace(entry[i+1:]))
+		if err != nil || len(pattern) == 0 {
+			return nil, fmt.Errorf("invalid maximum matching jobs %q, must be in the form of pattern=number", entry)
+		}
+		limits[pattern] = max
+	}
+	return limits, nil
+}
+
+// parseCheckRunStates parses the check run states mapped to the states of the jobs such as
+// "completed:neutral=failure". The conclusion is omitted for the check runs which are not completed, e.g. "queued".
+func parseCheckRunStates(str string) (map[status.CheckRunState]string, error) {
+	states := make(map[status.CheckRunState]string)
+	for _, entry := range strings.Split(str, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid check run state %q, must be in the form of status[:conclusion]=state", entry)
+		}
+		from, mapped := strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		if len(from) == 0 || len(mapped) == 0 {
+			return nil, fmt.Errorf("invalid check run state %q, must be in the form of status[:conclusion]=state", entry)
+		}
+		var state status.CheckRunState
+		if j := strings.Index(from, ":"); j < 0 {
+			state.Status = from
+		} else {
+			state.Status, state.Conclusion = strings.TrimSpace(from[:j]), strings.TrimSpace(from[j+1:])
+		}
+		states[state] = mapped
+	}
+	return states, nil
+}
+
 func debug(logger logger, name string) func() {
 	logger.Printf("Start processing %s....\n", name)
 	return func() {
@@ -96,29 +632,145 @@ func debug(logger logger, name string) func() {
 	}
 }
 
-func doValidateCmd(ctx context.Context, logger logger, vs ...validators.Validator) error {
-	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecond)*time.Second)
-	defer cancel()
+// doValidateCmd waits for the validations, and writes the reports of the results to the files once the wait is over.
+func doValidateCmd(ctx context.Context, logger logger, tp trace.TracerProvider, override *gateOverride, poster *statusPoster, reports []reportFile, replay bool, vs ...validators.Validator) error {
+	reporter := newFileReporter(len(vs), waitDeadline, reports...)
+	waitCtx, span := startWaitSpan(ctx, tp, len(vs))
+	waitErr := waitValidations(waitCtx, logger, override, poster, reporter, replay, vs...)
+	endWaitSpan(span, waitErr)
+
+	result := poster.finish(logger, waitErr)
+	werr := reporter.write(vs, result, time.Now())
+
+	// The overridden gate succeeds, while it has been reported as such.
+	var oe *overriddenError
+	if errors.As(result, &oe) {
+		result = nil
+	}
+
+	if werr != nil {
+		if result == nil {
+			return werr
+		}
+		logger.PrintErrf("  WARNING: %v\n", werr)
+	}
+	return result
+}
+
+// waitValidations polls the validations until all of them succeed, any of them fails, the gate is overridden,
+// or the wait is stopped. The replayed validations are never waited for, as their responses never change.
+//
+// The timeout is restarted whenever the head of the pull request changes, as the jobs of the new head have just
+// started, while the deadline of the total wait still bounds the wait across the heads.
+func waitValidations(ctx context.Context, logger logger, override *gateOverride, poster *statusPoster, reporter *fileReporter, replay bool, vs ...validators.Validator) error {
+	if !waitDeadline.IsZero() {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithDeadline(ctx, waitDeadline)
+		defer cancelDeadline()
+	}
+	parent := ctx
+	var cancelTimeout context.CancelFunc
+	restartTimeout := func() context.Context {
+		if cancelTimeout != nil {
+			cancelTimeout()
+		}
+		var timeoutCtx context.Context
+		timeoutCtx, cancelTimeout = context.WithTimeout(parent, time.Duration(timeoutSecond)*time.Second)
+		return timeoutCtx
+	}
+	ctx = restartTimeout()
+	defer func() { cancelTimeout() }()
 
 	invalT := ticker.NewInstantTicker(time.Duration(validateInvalSecond) * time.Second)
 	defer invalT.Stop()
 
+	// Progress is rendered in place, and thus only makes sense for interactive terminals.
+	// Otherwise it falls back to the regular logging.
+	inPlace := showProgress && isTerminal(os.Stderr)
+
+	// The quiet mode suppresses even the transitions, which are only logged once they are significant.
+	var quiet *quietLog
+	validateLogger := logger
+	if quietMode {
+		quiet = newQuietLog(len(vs))
+		validateLogger = silentLogger{}
+		inPlace = false
+	}
+	// The watch mode draws the board of all the validations instead, which replaces the other modes.
+	var board *watchBoard
+	if watchMode {
+		board = newWatchBoard(logger, isTerminal(os.Stderr), vs, time.Now())
+		quiet = nil
+		validateLogger = silentLogger{}
+		inPlace = false
+	}
+
+	// The last known statuses are printed when the wait is interrupted, so that cancellations are diagnosable.
+	lastStatuses := make([]validators.Status, len(vs))
+
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return stopped(ctx, logger, vs, lastStatuses)
 		case <-invalT.C():
-			var successCnt int
-			for _, v := range vs {
-				ok, err := validate(ctx, v, logger)
+			if err := override.check(ctx, logger); err != nil {
+				if ctx.Err() != nil {
+					return stopped(ctx, logger, vs, lastStatuses)
+				}
+				return err
+			}
+
+			var successCnt, unchangedCnt int
+			var headChanged bool
+			for i, v := range vs {
+				st, unchanged, err := validate(ctx, v, validateLogger, inPlace)
+				reporter.record(i, st, err)
+				if board != nil {
+					board.record(i, st, err)
+				}
 				if err != nil {
+					// The failed validation is drawn as well, while the interrupted one is reported when it stops.
+					if board != nil && ctx.Err() == nil {
+						board.poll(time.Now())
+					}
+					// The validation fails when the context is done during the API calls.
+					if ctx.Err() != nil {
+						return stopped(ctx, logger, vs, lastStatuses)
+					}
 					return err
 				}
-				if ok {
+				lastStatuses[i] = st
+				if quiet != nil && quiet.significant(i, st) {
+					logger.Println(st.Detail())
+				}
+				if st.IsSuccess() {
 					successCnt++
 				}
+				if unchanged {
+					unchangedCnt++
+				}
+				if r, ok := st.(status.HeadChangeReporter); ok && r.HeadChanged() {
+					headChanged = true
+				}
+			}
+			if board != nil {
+				board.poll(time.Now())
 			}
 			if successCnt != len(vs) {
+				// The replayed responses never change, so the pending result is final.
+				if replay {
+					return errReplayPending
+				}
+				if headChanged {
+					ctx = restartTimeout()
+					logger.Printf("Head has changed, restarting the timeout of %d seconds.\n", timeoutSecond)
+				}
+				poster.pending(ctx, logger, vs, lastStatuses)
+
+				// Nothing is logged while nothing has changed, so that long waits do not flood the logs.
+				if inPlace || quiet != nil || board != nil || unchangedCnt == len(vs) {
+					break
+				}
 				logger.PrintErrln("")
 				logger.PrintErrln("  WARNING: Validation is yet to be completed. This is most likely due to some other jobs still running.")
 				logger.PrintErrf("           Waiting for %d seconds before retrying.\n\n", validateInvalSecond)
@@ -131,18 +783,97 @@ func doValidateCmd(ctx context.Context, logger logger, vs ...validators.Validato
 	}
 }
 
-func validate(ctx context.Context, v validators.Validator, logger logger) (bool, error) {
-	defer debug(logger, "validator: "+v.Name())()
+// stopped reports why the wait has stopped before all validations succeed. The last known statuses are printed
+// when the wait is interrupted, e.g. by a signal on CI cancellation, which is distinguished from the timeout.
+func stopped(ctx context.Context, logger logger, vs []validators.Validator, lastStatuses []validators.Status) error {
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		// Nothing may have been logged while quiet, and thus the summary is printed once the wait is over.
+		if quietMode {
+			logger.PrintErrln("")
+			logger.PrintErrln("  WARNING: Validation timed out before all validations were successful.")
+			printLastStatuses(logger, vs, lastStatuses)
+		}
+		return timedOut(ctx.Err(), lastStatuses)
+	}
+
+	logger.PrintErrln("")
+	logger.PrintErrln("  WARNING: Validation was interrupted before all validations were successful.")
+	printLastStatuses(logger, vs, lastStatuses)
+	return ErrInterrupted
+}
+
+func printLastStatuses(logger logger, vs []validators.Validator, lastStatuses []validators.Status) {
+	for i, v := range vs {
+		if lastStatuses[i] == nil {
+			logger.PrintErrf("Last known status of %s: not validated yet\n", v.Name())
+			continue
+		}
+		logger.PrintErrf("Last known status of %s:\n%s\n", v.Name(), lastStatuses[i].Detail())
+	}
+}
+
+// timedOut returns the error of the timeout, which names the required jobs still missing as the cause, as they
+// were waited for until the timeout.
+func timedOut(err error, lastStatuses []validators.Status) error {
+	var missing []string
+	for _, st := range lastStatuses {
+		if r, ok := st.(status.MissingRequiredJobsReporter); ok {
+			missing = append(missing, r.MissingRequiredJobs()...)
+		}
+	}
+	if len(missing) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w, required jobs are not reported: %s", err, strings.Join(missing, ", "))
+}
+
+type unchangedReporter interface {
+	Unchanged() bool
+}
+
+// validate runs the validation and returns the status, along with whether nothing has changed since
+// the previous poll, in which case nothing is logged.
+func validate(ctx context.Context, v validators.Validator, logger logger, inPlace bool) (validators.Status, bool, error) {
+	if inPlace {
+		st, err := validateInPlace(ctx, v, logger)
+		return st, false, err
+	}
 
 	st, err := v.Validate(ctx)
 	if err != nil {
-		return false, fmt.Errorf("validation failed, err: %v", err)
+		return nil, false, fmt.Errorf("validation failed, err: %w", err)
 	}
 
+	if u, ok := st.(unchangedReporter); ok && u.Unchanged() {
+		return st, true, nil
+	}
+
+	defer debug(logger, "validator: "+v.Name())()
 	logger.Println(st.Detail())
 
-	if !st.IsSuccess() {
-		return false, nil
+	return st, false, nil
+}
+
+type progresser interface {
+	Progress() string
+}
+
+// validateInPlace runs the validation and overwrites the current terminal line with the progress.
+// The full detail is only printed once the validation reaches a terminal state.
+func validateInPlace(ctx context.Context, v validators.Validator, logger logger) (validators.Status, error) {
+	st, err := v.Validate(ctx)
+	if err != nil {
+		logger.Println("")
+		return nil, fmt.Errorf("validation failed, err: %w", err)
+	}
+
+	p, ok := st.(progresser)
+	if !ok || st.IsSuccess() {
+		logger.Println("")
+		logger.Println(st.Detail())
+		return st, nil
 	}
-	return true, nil
+
+	logger.Printf("\r\033[K%s: %s", v.Name(), p.Progress())
+	return st, nil
 }
@@ -1,15 +1,20 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/upsidr/merge-gatekeeper/internal/validators"
 	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
 )
 
 func TestMain(m *testing.M) {
@@ -64,6 +69,202 @@ func Test_ownerAndRepository(t *testing.T) {
 	}
 }
 
+func Test_parseJobTimeouts(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		"returns empty map when str is empty": {
+			str:  "",
+			want: map[string]time.Duration{},
+		},
+		"returns timeouts of the named jobs": {
+			str:  "lint=5m, e2e = 1h30m",
+			want: map[string]time.Duration{"lint": 5 * time.Minute, "e2e": 90 * time.Minute},
+		},
+		"returns error when the duration is missing": {
+			str:     "lint",
+			wantErr: true,
+		},
+		"returns error when the duration is invalid": {
+			str:     "lint=5",
+			wantErr: true,
+		},
+		"returns error when the job name is empty": {
+			str:     "=5m",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseJobTimeouts(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseJobTimeouts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJobTimeouts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseJobAliases(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		want    map[string]string
+		wantErr bool
+	}{
+		"returns empty map when str is empty": {
+			str:  "",
+			want: map[string]string{},
+		},
+		"returns new names of the renamed jobs": {
+			str:  "test=unit-test, build = build (ubuntu)",
+			want: map[string]string{"test": "unit-test", "build": "build (ubuntu)"},
+		},
+		"returns error when the new name is missing": {
+			str:     "test",
+			wantErr: true,
+		},
+		"returns error when the old name is empty": {
+			str:     "=unit-test",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseJobAliases(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseJobAliases() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJobAliases() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseRequiredDescriptions(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		want    map[string]string
+		wantErr bool
+	}{
+		"returns empty map when str is empty": {
+			str:  "",
+			want: map[string]string{},
+		},
+		"returns patterns of the descriptions": {
+			str:  "license-scan=^0 violations$, coverage = total=\\d+%",
+			want: map[string]string{"license-scan": "^0 violations$", "coverage": `total=\d+%`},
+		},
+		"returns error when the pattern is missing": {
+			str:     "license-scan",
+			wantErr: true,
+		},
+		"returns error when the context is empty": {
+			str:     "=0 violations",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseRequiredDescriptions(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRequiredDescriptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRequiredDescriptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseMaxMatchingJobs(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		want    map[string]int
+		wantErr bool
+	}{
+		"returns empty map when str is empty": {
+			str:  "",
+			want: map[string]int{},
+		},
+		"returns maximum numbers of matching jobs": {
+			str:  "test-shard-*=50, /^e2e-(a|b)={2}$/ = 4",
+			want: map[string]int{"test-shard-*": 50, "/^e2e-(a|b)={2}$/": 4},
+		},
+		"returns error when the number is missing": {
+			str:     "test-shard-*",
+			wantErr: true,
+		},
+		"returns error when the number is invalid": {
+			str:     "test-shard-*=many",
+			wantErr: true,
+		},
+		"returns error when the pattern is empty": {
+			str:     "=50",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseMaxMatchingJobs(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseMaxMatchingJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMaxMatchingJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseCheckRunStates(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		want    map[status.CheckRunState]string
+		wantErr bool
+	}{
+		"returns empty map when str is empty": {
+			str:  "",
+			want: map[status.CheckRunState]string{},
+		},
+		"returns mapped states of the check runs": {
+			str: "completed:neutral=failure, queued = skipped",
+			want: map[status.CheckRunState]string{
+				{Status: "completed", Conclusion: "neutral"}: "failure",
+				{Status: "queued"}:                           "skipped",
+			},
+		},
+		"returns error when the mapped state is missing": {
+			str:     "completed:neutral",
+			wantErr: true,
+		},
+		"returns error when the check run state is empty": {
+			str:     "=failure",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseCheckRunStates(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCheckRunStates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCheckRunStates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_doValidateCmd(t *testing.T) {
 	tests := map[string]struct {
 		ctx     context.Context
@@ -138,9 +339,191 @@ func Test_doValidateCmd(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			if err := doValidateCmd(tt.ctx, tt.cmd, tt.vs...); (err != nil) != tt.wantErr {
+			if err := doValidateCmd(tt.ctx, tt.cmd, nil, nil, nil, nil, false, tt.vs...); (err != nil) != tt.wantErr {
 				t.Errorf("doValidateCmd() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+type unchangedStatus struct {
+	mock.Status
+	unchanged bool
+}
+
+func (s *unchangedStatus) Unchanged() bool {
+	return s.unchanged
+}
+
+func Test_doValidateCmd_unchanged(t *testing.T) {
+	var polls int
+	v := &mock.Validator{
+		NameFunc: func() string { return "validator-1" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			polls++
+			return &unchangedStatus{
+				Status: mock.Status{
+					DetailFunc:    func() string { return "pending-detail" },
+					IsSuccessFunc: func() bool { return false },
+				},
+				unchanged: polls > 1,
+			}, nil
+		},
+	}
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	if err := doValidateCmd(context.Background(), cmd, nil, nil, nil, nil, false, v); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("doValidateCmd() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if polls < 2 {
+		t.Fatalf("validated %d times, want at least 2", polls)
+	}
+	if got := strings.Count(out.String(), "pending-detail"); got != 1 {
+		t.Errorf("detail is logged %d times, want 1:\n%s", got, out.String())
+	}
+	if got := strings.Count(out.String(), "WARNING"); got != 1 {
+		t.Errorf("warning is logged %d times, want 1:\n%s", got, out.String())
+	}
+}
+
+func Test_doValidateCmd_interrupted(t *testing.T) {
+	tests := map[string]struct {
+		cancelAfterPolls int
+		wantOutput       string
+	}{
+		"prints the last known status when interrupted while waiting": {
+			cancelAfterPolls: 1,
+			wantOutput:       "Last known status of validator-1:\npending-detail",
+		},
+		"prints that nothing is validated when interrupted before the first validation": {
+			cancelAfterPolls: 0,
+			wantOutput:       "Last known status of validator-1: not validated yet",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelAfterPolls == 0 {
+				cancel()
+			}
+
+			var polls int
+			v := &mock.Validator{
+				NameFunc: func() string { return "validator-1" },
+				ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+					if err := ctx.Err(); err != nil {
+						return nil, err
+					}
+					polls++
+					if polls == tt.cancelAfterPolls {
+						cancel()
+					}
+					return &mock.Status{
+						DetailFunc:    func() string { return "pending-detail" },
+						IsSuccessFunc: func() bool { return false },
+					}, nil
+				},
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			err := doValidateCmd(ctx, cmd, nil, nil, nil, nil, false, v)
+			if !errors.Is(err, ErrInterrupted) {
+				t.Fatalf("doValidateCmd() error = %v, want %v", err, ErrInterrupted)
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("output does not contain %q:\n%s", tt.wantOutput, out.String())
+			}
+		})
+	}
+}
+
+type headChangedStatus struct {
+	mock.Status
+	headChanged bool
+}
+
+func (s *headChangedStatus) HeadChanged() bool {
+	return s.headChanged
+}
+
+func Test_doValidateCmd_headChanged(t *testing.T) {
+	// The head changes on every poll of the first three, each of which restarts the timeout of 2 seconds, and thus the
+	// validation succeeding on the fourth poll after 3 seconds is not timed out.
+	var polls int
+	v := &mock.Validator{
+		NameFunc: func() string { return "validator-1" },
+		ValidateFunc: func(ctx context.Context) (validators.Status, error) {
+			polls++
+			succeeded := polls > 3
+			return &headChangedStatus{
+				Status: mock.Status{
+					DetailFunc:    func() string { return "detail" },
+					IsSuccessFunc: func() bool { return succeeded },
+				},
+				headChanged: !succeeded,
+			}, nil
+		},
+	}
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	if err := doValidateCmd(context.Background(), cmd, nil, nil, nil, nil, false, v); err != nil {
+		t.Fatalf("doValidateCmd() error = %v, want the timeout restarted on the new heads", err)
+	}
+	if got := strings.Count(out.String(), "restarting the timeout"); got != 3 {
+		t.Errorf("timeout is restarted %d times, want 3:\n%s", got, out.String())
+	}
+}
+
+type missingRequiredStatus struct {
+	mock.Status
+	missing []string
+}
+
+func (s *missingRequiredStatus) MissingRequiredJobs() []string {
+	return s.missing
+}
+
+func Test_timedOut(t *testing.T) {
+	tests := map[string]struct {
+		lastStatuses []validators.Status
+		want         string
+	}{
+		"returns the timeout as is when no required job is missing": {
+			lastStatuses: []validators.Status{
+				&missingRequiredStatus{},
+				&mock.Status{},
+				nil,
+			},
+			want: context.DeadlineExceeded.Error(),
+		},
+		"names the missing required jobs as the cause": {
+			lastStatuses: []validators.Status{
+				&missingRequiredStatus{missing: []string{"build", "e2e-*"}},
+				nil,
+			},
+			want: "context deadline exceeded, required jobs are not reported: build, e2e-*",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := timedOut(context.DeadlineExceeded, tt.lastStatuses)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("timedOut() error = %v, want %v", err, context.DeadlineExceeded)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("timedOut() error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+// clearScreen moves the cursor to the top left corner and clears the screen, so that the board is redrawn in place.
+const clearScreen = "\033[H\033[2J"
+
+// watchBoard is the live status board of all the validations in the watch mode. It is redrawn in full on each poll
+// when attached to a terminal, and otherwise appended whenever the jobs have changed, as the output cannot be cleared.
+type watchBoard struct {
+	logger  logger
+	redraw  bool
+	names   []string
+	results []watchResult
+	started time.Time
+	polls   int
+	last    string
+}
+
+// watchResult is the last result of a validation. Neither field is set before the validation is run for the first time.
+type watchResult struct {
+	status validators.Status
+	err    error
+}
+
+func newWatchBoard(logger logger, redraw bool, vs []validators.Validator, now time.Time) *watchBoard {
+	names := make([]string, 0, len(vs))
+	for _, v := range vs {
+		names = append(names, v.Name())
+	}
+	return &watchBoard{
+		logger:  logger,
+		redraw:  redraw,
+		names:   names,
+		results: make([]watchResult, len(vs)),
+		started: now,
+	}
+}
+
+func (b *watchBoard) record(i int, st validators.Status, err error) {
+	b.results[i] = watchResult{status: st, err: err}
+}
+
+// poll draws the board of the results recorded during the poll.
+func (b *watchBoard) poll(now time.Time) {
+	b.polls++
+	board := renderWatchBoard(b.names, b.results)
+	header := fmt.Sprintf("Merge Gatekeeper is watching, poll %d, %s elapsed\n\n", b.polls, now.Sub(b.started).Round(time.Second))
+	if b.redraw {
+		b.logger.Printf("%s%s%s", clearScreen, header, board)
+		return
+	}
+	if board == b.last {
+		return
+	}
+	b.last = board
+	b.logger.Printf("%s%s\n", header, board)
+}
+
+// renderWatchBoard renders the results of the validations, listing the jobs of each validation with the failed jobs
+// first and the successful jobs last, as they matter the least while waiting.
+func renderWatchBoard(names []string, results []watchResult) string {
+	var sb strings.Builder
+	for i, name := range names {
+		if i != 0 {
+			sb.WriteString("\n")
+		}
+		r := results[i]
+		if r.status == nil && r.err == nil {
+			fmt.Fprintf(&sb, "%s: not validated yet\n", name)
+			continue
+		}
+
+		jr := jobResults(r.status, r.err)
+		switch {
+		case jr == nil && r.err != nil:
+			fmt.Fprintf(&sb, "%s: error, %v\n", name, r.err)
+			continue
+		case jr == nil:
+			fmt.Fprintf(&sb, "%s: %s\n", name, watchState(r))
+			for _, line := range strings.Split(strings.TrimSpace(r.status.Detail()), "\n") {
+				fmt.Fprintf(&sb, "  %s\n", line)
+			}
+			continue
+		}
+
+		jobs := append([]status.JobStatus{}, jr.JobStatuses()...)
+		var completed, failed int
+		for _, js := range jobs {
+			switch watchJobRank(js.State) {
+			case 0:
+				failed++
+			case 2:
+				completed++
+			}
+		}
+		fmt.Fprintf(&sb, "%s: %s, %d/%d jobs completed, %d failed\n", name, watchState(r), completed, len(jobs), failed)
+
+		sort.SliceStable(jobs, func(i, j int) bool {
+			return watchJobRank(jobs[i].State) < watchJobRank(jobs[j].State)
+		})
+		width := 0
+		for _, js := range jobs {
+			if len(js.Job) > width {
+				width = len(js.Job)
+			}
+		}
+		for _, js := range jobs {
+			fmt.Fprintf(&sb, "  %s %-*s  %s\n", watchJobMarkers[watchJobRank(js.State)], width, js.Job, js.State)
+		}
+		if ignored := jr.IgnoredJobs(); len(ignored) != 0 {
+			fmt.Fprintf(&sb, "  %d job(s) ignored\n", len(ignored))
+		}
+	}
+	return sb.String()
+}
+
+// watchJobMarkers are the markers of the failed, the pending and the successful jobs.
+var watchJobMarkers = [...]string{"[x]", "[ ]", "[v]"}
+
+func watchJobRank(state string) int {
+	switch state {
+	case commitStatusFailure, commitStatusError:
+		return 0
+	case commitStatusSuccess:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func watchState(r watchResult) string {
+	switch {
+	case r.err != nil:
+		return "failed"
+	case r.status.IsSuccess():
+		return "succeeded"
+	default:
+		return "pending"
+	}
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators/status"
+)
+
+func Test_renderWatchBoard(t *testing.T) {
+	jobs := &jobResultStatus{
+		Status: mock.Status{
+			DetailFunc:    func() string { return "detail" },
+			IsSuccessFunc: func() bool { return false },
+		},
+		jobStatuses: []status.JobStatus{
+			{Job: "build", State: "success"},
+			{Job: "e2e", State: "pending"},
+			{Job: "lint", State: "failure"},
+			{Job: "test", State: "success"},
+		},
+		ignoredJobs: []string{"optional"},
+	}
+	labels := &mock.Status{
+		DetailFunc:    func() string { return "labels are satisfied\n" },
+		IsSuccessFunc: func() bool { return true },
+	}
+	failed := &jobResultError{jobResultStatus: jobResultStatus{
+		jobStatuses: []status.JobStatus{{Job: "build", State: "error"}},
+	}}
+
+	tests := map[string]struct {
+		names   []string
+		results []watchResult
+		want    string
+	}{
+		"lists the failed jobs first and the successful jobs last": {
+			names:   []string{"status"},
+			results: []watchResult{{status: jobs}},
+			want: "status: pending, 2/4 jobs completed, 1 failed\n" +
+				"  [x] lint   failure\n" +
+				"  [ ] e2e    pending\n" +
+				"  [v] build  success\n" +
+				"  [v] test   success\n" +
+				"  1 job(s) ignored\n",
+		},
+		"renders the failed validation from the error": {
+			names:   []string{"status"},
+			results: []watchResult{{err: fmt.Errorf("validation failed, err: %w", failed)}},
+			want: "status: failed, 0/1 jobs completed, 1 failed\n" +
+				"  [x] build  error\n",
+		},
+		"renders the detail of the validation without jobs": {
+			names:   []string{"labels", "approvals"},
+			results: []watchResult{{status: labels}, {err: errors.New("not found")}},
+			want: "labels: succeeded\n" +
+				"  labels are satisfied\n" +
+				"\n" +
+				"approvals: error, not found\n",
+		},
+		"renders the validation not validated yet": {
+			names:   []string{"status"},
+			results: []watchResult{{}},
+			want:    "status: not validated yet\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := renderWatchBoard(tt.names, tt.results); got != tt.want {
+				t.Errorf("renderWatchBoard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_watchBoard_poll(t *testing.T) {
+	pending := &mock.Status{
+		DetailFunc:    func() string { return "pending" },
+		IsSuccessFunc: func() bool { return false },
+	}
+	success := &mock.Status{
+		DetailFunc:    func() string { return "success" },
+		IsSuccessFunc: func() bool { return true },
+	}
+	vs := []validators.Validator{&mock.Validator{NameFunc: func() string { return "status" }}}
+	start := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		redraw    bool
+		want      []string
+		wantCount map[string]int
+	}{
+		"redraws the board on each poll": {
+			redraw:    true,
+			want:      []string{"poll 3, 20s elapsed", "status: succeeded"},
+			wantCount: map[string]int{clearScreen: 3, "status: pending": 2},
+		},
+		"appends the board only when it has changed": {
+			redraw:    false,
+			want:      []string{"poll 1, 0s elapsed", "poll 3, 20s elapsed"},
+			wantCount: map[string]int{clearScreen: 0, "status: pending": 1, "poll 2": 0},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+
+			board := newWatchBoard(cmd, tt.redraw, vs, start)
+			for i, st := range []validators.Status{pending, pending, success} {
+				board.record(0, st, nil)
+				board.poll(start.Add(time.Duration(i) * 10 * time.Second))
+			}
+
+			got := out.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output = %q, want %q", got, want)
+				}
+			}
+			for str, want := range tt.wantCount {
+				if n := strings.Count(got, str); n != want {
+					t.Errorf("output has %d of %q, want %d", n, str, want)
+				}
+			}
+		})
+	}
+}
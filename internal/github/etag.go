@@ -0,0 +1,80 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// CacheStatusHeader is set to "hit" in the responses served from the ETag cache.
+const CacheStatusHeader = "X-Merge-Gatekeeper-Cache"
+
+// etagTransport caches the responses of GET requests along with their ETags and sends conditional requests,
+// so that the responses are served from the cache when GitHub returns 304 Not Modified.
+// Conditional requests answered with 304 do not count against the rate limit.
+type etagTransport struct {
+	base http.RoundTripper
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	etag string
+	dump []byte
+}
+
+func newETagTransport(base http.RoundTripper) *etagTransport {
+	return &etagTransport{
+		base:  base,
+		cache: make(map[string]cachedResponse),
+	}
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	t.mu.Lock()
+	cached, ok := t.cache[key]
+	t.mu.Unlock()
+	if ok && len(req.Header.Get("If-None-Match")) == 0 {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		cachedResp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(cached.dump)), req)
+		if err != nil {
+			return nil, err
+		}
+		cachedResp.Header.Set(CacheStatusHeader, "hit")
+		return cachedResp, nil
+	}
+
+	if etag := resp.Header.Get("ETag"); resp.StatusCode == http.StatusOK && len(etag) != 0 {
+		dump, err := httputil.DumpResponse(resp, true)
+		if err != nil {
+			return nil, err
+		}
+		t.mu.Lock()
+		t.cache[key] = cachedResponse{etag: etag, dump: dump}
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// IsNotModified reports whether the response is served from the ETag cache as the resource has not been modified.
+func IsNotModified(resp *Response) bool {
+	return resp != nil && resp.Response != nil && resp.Header.Get(CacheStatusHeader) == "hit"
+}
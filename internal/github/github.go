@@ -2,9 +2,13 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/google/go-github/v38/github"
 	"golang.org/x/oauth2"
+
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
 )
 
 type (
@@ -12,37 +16,304 @@ type (
 	CombinedStatus = github.CombinedStatus
 	RepoStatus     = github.RepoStatus
 	Response       = github.Response
+
+	Repository           = github.Repository
+	RequiredStatusChecks = github.RequiredStatusChecks
+	CommitsComparison    = github.CommitsComparison
+	RepositoryCommit     = github.RepositoryCommit
+	Commit               = github.Commit
+	CommitAuthor         = github.CommitAuthor
+	Reference            = github.Reference
+	GitObject            = github.GitObject
+	Tag                  = github.Tag
+)
+
+type (
+	Deployment             = github.Deployment
+	DeploymentStatus       = github.DeploymentStatus
+	DeploymentsListOptions = github.DeploymentsListOptions
+)
+
+type (
+	WorkflowRun             = github.WorkflowRun
+	WorkflowJob             = github.WorkflowJob
+	Jobs                    = github.Jobs
+	ListWorkflowJobsOptions = github.ListWorkflowJobsOptions
 )
 
 type (
 	CheckRun             = github.CheckRun
+	CheckSuite           = github.CheckSuite
+	App                  = github.App
+	Timestamp            = github.Timestamp
 	ListCheckRunsOptions = github.ListCheckRunsOptions
 	ListCheckRunsResults = github.ListCheckRunsResults
+	CheckRunOutput       = github.CheckRunOutput
+	CheckRunAnnotation   = github.CheckRunAnnotation
+)
+
+type (
+	PullRequest       = github.PullRequest
+	PullRequestBranch = github.PullRequestBranch
+	Label             = github.Label
+	IssueComment      = github.IssueComment
+	PullRequestReview = github.PullRequestReview
+	CommitFile        = github.CommitFile
+	User              = github.User
+
+	PullRequestListOptions     = github.PullRequestListOptions
+	TeamListTeamMembersOptions = github.TeamListTeamMembersOptions
+	IssueListCommentsOptions   = github.IssueListCommentsOptions
 )
 
 type Client interface {
 	GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error)
+	CreateStatus(ctx context.Context, owner, repo, ref string, status *RepoStatus) (*RepoStatus, *Response, error)
 	ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error)
+	ListCheckRunAnnotations(ctx context.Context, owner, repo string, checkRunID int64, opts *ListOptions) ([]*CheckRunAnnotation, *Response, error)
+	GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *Response, error)
+	CompareCommits(ctx context.Context, owner, repo, base, head string, opts *ListOptions) (*CommitsComparison, *Response, error)
+	GetRef(ctx context.Context, owner, repo, ref string) (*Reference, *Response, error)
+	GetCommit(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*RepositoryCommit, *Response, error)
+	GetTag(ctx context.Context, owner, repo, sha string) (*Tag, *Response, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error)
+	ListPullRequests(ctx context.Context, owner, repo string, opts *PullRequestListOptions) ([]*PullRequest, *Response, error)
+	ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, *Response, error)
+	ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*CommitFile, *Response, error)
+	ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *TeamListTeamMembersOptions) ([]*User, *Response, error)
+	ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*Label, *Response, error)
+	ListIssueComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error)
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error)
+	EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *IssueComment) (*IssueComment, *Response, error)
+	GetRepository(ctx context.Context, owner, repo string) (*Repository, *Response, error)
+	GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*RequiredStatusChecks, *Response, error)
+	ListDeployments(ctx context.Context, owner, repo string, opts *DeploymentsListOptions) ([]*Deployment, *Response, error)
+	ListDeploymentStatuses(ctx context.Context, owner, repo string, deployment int64, opts *ListOptions) ([]*DeploymentStatus, *Response, error)
+	GetServerVersion(ctx context.Context) (string, *Response, error)
+	GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*WorkflowRun, *Response, error)
+	ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, opts *ListWorkflowJobsOptions) (*Jobs, *Response, error)
+	GetWorkflowJobByID(ctx context.Context, owner, repo string, jobID int64) (*WorkflowJob, *Response, error)
 }
 
 type client struct {
 	ghc *github.Client
 }
 
-func NewClient(ctx context.Context, token string) Client {
-	return &client{
-		ghc: github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(
-			&oauth2.Token{
-				AccessToken: token,
-			},
-		))),
+// apiError tags the error of a request with its category, so that it is told apart from the invalid options when
+// the errors are rendered together.
+func apiError(err error) error {
+	return multierror.Categorize(multierror.CategoryAPI, err)
+}
+
+func NewClient(ctx context.Context, token string, opts ...Option) (Client, error) {
+	o := &clientOption{
+		retryableStatusCodes: defaultRetryableStatusCodes,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if err := validateHeaders(o.headers); err != nil {
+		return nil, err
+	}
+	if err := validateRetryableStatusCodes(o.retryableStatusCodes); err != nil {
+		return nil, err
+	}
+	if o.rpm < 0 {
+		return nil, fmt.Errorf("maximum number of requests per minute must not be negative: %d", o.rpm)
+	}
+
+	// The default transport sends the requests through the proxy set by the environment variables such as
+	// HTTPS_PROXY, which is often needed to reach GitHub Enterprise Server.
+	var base http.RoundTripper = http.DefaultTransport
+	if hc, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && hc.Transport != nil {
+		base = hc.Transport
+	}
+	if len(o.headers) != 0 {
+		base = &headerTransport{base: base, headers: o.headers}
 	}
+	if o.rpm != 0 {
+		base = newRateLimitTransport(base, o.rpm)
+	}
+	// Each retry is held back by the rate limit as well, and the cached responses are never retried. The conditional
+	// requests of the cache are not held back, as the responses of 304 Not Modified are not counted by GitHub.
+	if len(o.retryableStatusCodes) != 0 {
+		base = newRetryTransport(base, o.retryableStatusCodes)
+	}
+	if o.etagCache {
+		base = newETagTransport(base)
+	}
+
+	// The auth header is set by the outermost transport before the custom headers are set by the inner one, and it is
+	// preserved only because the custom headers are validated not to override it.
+	hc := &http.Client{
+		Transport: &oauth2.Transport{
+			Base: base,
+			Source: oauth2.StaticTokenSource(
+				&oauth2.Token{
+					AccessToken: token,
+				},
+			),
+		},
+	}
+	ghc := github.NewClient(hc)
+	if len(o.baseURL) != 0 {
+		var err error
+		ghc, err = github.NewEnterpriseClient(o.baseURL, o.baseURL, hc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base url %q: %w", o.baseURL, err)
+		}
+	}
+	if len(o.userAgent) != 0 {
+		ghc.UserAgent = o.userAgent
+	}
+
+	var c Client = &client{
+		ghc: ghc,
+	}
+	if o.tracerProvider != nil {
+		c = &tracingClient{Client: c, tracer: o.tracerProvider.Tracer(tracerName)}
+	}
+	return c, nil
 }
 
 func (c *client) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error) {
-	return c.ghc.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+	combined, resp, err := c.ghc.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+	return combined, resp, apiError(err)
+}
+
+func (c *client) CreateStatus(ctx context.Context, owner, repo, ref string, status *RepoStatus) (*RepoStatus, *Response, error) {
+	created, resp, err := c.ghc.Repositories.CreateStatus(ctx, owner, repo, ref, status)
+	return created, resp, apiError(err)
 }
 
 func (c *client) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error) {
-	return c.ghc.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+	results, resp, err := c.ghc.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+	return results, resp, apiError(err)
+}
+
+func (c *client) ListCheckRunAnnotations(ctx context.Context, owner, repo string, checkRunID int64, opts *ListOptions) ([]*CheckRunAnnotation, *Response, error) {
+	annotations, resp, err := c.ghc.Checks.ListCheckRunAnnotations(ctx, owner, repo, checkRunID, opts)
+	return annotations, resp, apiError(err)
+}
+
+func (c *client) GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *Response, error) {
+	sha, resp, err := c.ghc.Repositories.GetCommitSHA1(ctx, owner, repo, ref, lastSHA)
+	return sha, resp, apiError(err)
+}
+
+func (c *client) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *ListOptions) (*CommitsComparison, *Response, error) {
+	comparison, resp, err := c.ghc.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+	return comparison, resp, apiError(err)
+}
+
+func (c *client) GetRef(ctx context.Context, owner, repo, ref string) (*Reference, *Response, error) {
+	reference, resp, err := c.ghc.Git.GetRef(ctx, owner, repo, ref)
+	return reference, resp, apiError(err)
+}
+
+func (c *client) GetCommit(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*RepositoryCommit, *Response, error) {
+	commit, resp, err := c.ghc.Repositories.GetCommit(ctx, owner, repo, ref, opts)
+	return commit, resp, apiError(err)
+}
+
+func (c *client) GetRepository(ctx context.Context, owner, repo string) (*Repository, *Response, error) {
+	repository, resp, err := c.ghc.Repositories.Get(ctx, owner, repo)
+	return repository, resp, apiError(err)
+}
+
+func (c *client) GetTag(ctx context.Context, owner, repo, sha string) (*Tag, *Response, error) {
+	tag, resp, err := c.ghc.Git.GetTag(ctx, owner, repo, sha)
+	return tag, resp, apiError(err)
+}
+
+func (c *client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error) {
+	pr, resp, err := c.ghc.PullRequests.Get(ctx, owner, repo, number)
+	return pr, resp, apiError(err)
+}
+
+func (c *client) ListPullRequests(ctx context.Context, owner, repo string, opts *PullRequestListOptions) ([]*PullRequest, *Response, error) {
+	prs, resp, err := c.ghc.PullRequests.List(ctx, owner, repo, opts)
+	return prs, resp, apiError(err)
+}
+
+func (c *client) ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, *Response, error) {
+	reviews, resp, err := c.ghc.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+	return reviews, resp, apiError(err)
+}
+
+func (c *client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*CommitFile, *Response, error) {
+	files, resp, err := c.ghc.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+	return files, resp, apiError(err)
+}
+
+func (c *client) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *TeamListTeamMembersOptions) ([]*User, *Response, error) {
+	users, resp, err := c.ghc.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+	return users, resp, apiError(err)
+}
+
+func (c *client) ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*Label, *Response, error) {
+	labels, resp, err := c.ghc.Issues.ListLabelsByIssue(ctx, owner, repo, number, opts)
+	return labels, resp, apiError(err)
+}
+
+func (c *client) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error) {
+	comments, resp, err := c.ghc.Issues.ListComments(ctx, owner, repo, number, opts)
+	return comments, resp, apiError(err)
+}
+
+func (c *client) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error) {
+	created, resp, err := c.ghc.Issues.CreateComment(ctx, owner, repo, number, comment)
+	return created, resp, apiError(err)
+}
+
+func (c *client) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *IssueComment) (*IssueComment, *Response, error) {
+	edited, resp, err := c.ghc.Issues.EditComment(ctx, owner, repo, commentID, comment)
+	return edited, resp, apiError(err)
+}
+
+func (c *client) GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*RequiredStatusChecks, *Response, error) {
+	checks, resp, err := c.ghc.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+	return checks, resp, apiError(err)
+}
+
+func (c *client) ListDeployments(ctx context.Context, owner, repo string, opts *DeploymentsListOptions) ([]*Deployment, *Response, error) {
+	deployments, resp, err := c.ghc.Repositories.ListDeployments(ctx, owner, repo, opts)
+	return deployments, resp, apiError(err)
+}
+
+func (c *client) ListDeploymentStatuses(ctx context.Context, owner, repo string, deployment int64, opts *ListOptions) ([]*DeploymentStatus, *Response, error) {
+	statuses, resp, err := c.ghc.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment, opts)
+	return statuses, resp, apiError(err)
+}
+
+// GetServerVersion returns the installed version of GitHub Enterprise Server from the meta endpoint, or an empty
+// string for GitHub.com, which does not report its version.
+func (c *client) GetServerVersion(ctx context.Context) (string, *Response, error) {
+	req, err := c.ghc.NewRequest(http.MethodGet, "meta", nil)
+	if err != nil {
+		return "", nil, err
+	}
+	var meta struct {
+		InstalledVersion string `json:"installed_version"`
+	}
+	resp, err := c.ghc.Do(ctx, req, &meta)
+	if err != nil {
+		return "", resp, apiError(err)
+	}
+	return meta.InstalledVersion, resp, nil
+}
+
+func (c *client) GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*WorkflowRun, *Response, error) {
+	run, resp, err := c.ghc.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+	return run, resp, apiError(err)
+}
+
+func (c *client) ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, opts *ListWorkflowJobsOptions) (*Jobs, *Response, error) {
+	jobs, resp, err := c.ghc.Actions.ListWorkflowJobs(ctx, owner, repo, runID, opts)
+	return jobs, resp, apiError(err)
+}
+
+func (c *client) GetWorkflowJobByID(ctx context.Context, owner, repo string, jobID int64) (*WorkflowJob, *Response, error) {
+	job, resp, err := c.ghc.Actions.GetWorkflowJobByID(ctx, owner, repo, jobID)
+	return job, resp, apiError(err)
 }
@@ -0,0 +1,429 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(context.Background(), "test-token", opts...)
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	u, _ := url.Parse(srv.URL + "/")
+	inner := c
+	if tc, ok := c.(*tracingClient); ok {
+		inner = tc.Client
+	}
+	inner.(*client).ghc.BaseURL = u
+	return c
+}
+
+func TestNewClient_headers(t *testing.T) {
+	var got http.Header
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	},
+		WithUserAgent("test-agent"),
+		WithHeaders(map[string]string{"X-Correlation-Id": "abc"}),
+	)
+
+	if _, _, err := c.GetCombinedStatus(context.Background(), "owner", "repo", "main", nil); err != nil {
+		t.Fatalf("GetCombinedStatus() unexpected error: %v", err)
+	}
+	if ua := got.Get("User-Agent"); ua != "test-agent" {
+		t.Errorf("User-Agent = %s, want test-agent", ua)
+	}
+	if v := got.Get("X-Correlation-Id"); v != "abc" {
+		t.Errorf("X-Correlation-Id = %s, want abc", v)
+	}
+	if auth := got.Get("Authorization"); auth != "Bearer test-token" {
+		t.Errorf("Authorization = %s, want Bearer test-token", auth)
+	}
+}
+
+func TestNewClient_invalidHeaders(t *testing.T) {
+	tests := map[string]map[string]string{
+		"returns error when header name is empty":        {"": "abc"},
+		"returns error when header name has space":       {"X Correlation": "abc"},
+		"returns error when overriding the auth header":  {"authorization": "token abc"},
+		"returns error when header name has a delimiter": {"X-Id:": "abc"},
+	}
+	for name, headers := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient(context.Background(), "test-token", WithHeaders(headers)); err == nil {
+				t.Errorf("NewClient() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNewClient_maxRequestsPerMinute(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}, WithMaxRequestsPerMinute(1200))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, _, err := c.GetCombinedStatus(context.Background(), "owner", "repo", "main", nil); err != nil {
+			t.Fatalf("GetCombinedStatus() #%d unexpected error: %v", i, err)
+		}
+	}
+	// 1200 requests per minute allow a request every 50ms.
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests took %v, want at least 100ms", elapsed)
+	}
+}
+
+func TestNewClient_invalidMaxRequestsPerMinute(t *testing.T) {
+	if _, err := NewClient(context.Background(), "test-token", WithMaxRequestsPerMinute(-1)); err == nil {
+		t.Errorf("NewClient() error = nil, want error")
+	}
+}
+
+func TestNewClient_maxRequestsPerMinute_cancel(t *testing.T) {
+	var requested int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requested++
+		w.Write([]byte(`{}`))
+	}, WithMaxRequestsPerMinute(1))
+
+	if _, _, err := c.GetCombinedStatus(context.Background(), "owner", "repo", "main", nil); err != nil {
+		t.Fatalf("GetCombinedStatus() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, _, err := c.GetCombinedStatus(ctx, "owner", "repo", "main", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetCombinedStatus() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if requested != 1 {
+		t.Errorf("requested %d times, want 1", requested)
+	}
+}
+
+func TestNewClient_maxRequestsPerMinute_notModified(t *testing.T) {
+	var requested int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requested++
+		if r.Header.Get("If-None-Match") == `"pending"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"pending"`)
+		w.Write([]byte(`{"state":"pending"}`))
+	}, WithMaxRequestsPerMinute(1), WithETagCache(true))
+
+	if _, _, err := c.GetCombinedStatus(context.Background(), "owner", "repo", "main", nil); err != nil {
+		t.Fatalf("GetCombinedStatus() unexpected error: %v", err)
+	}
+
+	// The first request takes the only token of the minute, which does not hold back the conditional requests.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		if _, resp, err := c.GetCombinedStatus(ctx, "owner", "repo", "main", nil); err != nil || !IsNotModified(resp) {
+			t.Fatalf("GetCombinedStatus() #%d = %v, %v, want not modified", i, resp, err)
+		}
+	}
+	if requested != 4 {
+		t.Errorf("requested %d times, want 4", requested)
+	}
+}
+
+func TestNewClient_etagCache(t *testing.T) {
+	var state string
+	var gotIfNoneMatch []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotIfNoneMatch = append(gotIfNoneMatch, r.Header.Get("If-None-Match"))
+		etag := `"` + state + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(`{"state":"` + state + `"}`))
+	}, WithETagCache(true))
+
+	polls := []struct {
+		state           string
+		wantNotModified bool
+	}{
+		{state: "pending", wantNotModified: false},
+		{state: "pending", wantNotModified: true},
+		{state: "success", wantNotModified: false},
+	}
+	for i, p := range polls {
+		state = p.state
+		got, resp, err := c.GetCombinedStatus(context.Background(), "owner", "repo", "main", nil)
+		if err != nil {
+			t.Fatalf("GetCombinedStatus() #%d unexpected error: %v", i, err)
+		}
+		if got.GetState() != p.state {
+			t.Errorf("GetCombinedStatus() #%d state = %s, want %s", i, got.GetState(), p.state)
+		}
+		if IsNotModified(resp) != p.wantNotModified {
+			t.Errorf("IsNotModified() #%d = %v, want %v", i, IsNotModified(resp), p.wantNotModified)
+		}
+	}
+
+	want := []string{"", `"pending"`, `"pending"`}
+	for i := range want {
+		if gotIfNoneMatch[i] != want[i] {
+			t.Errorf("If-None-Match #%d = %s, want %s", i, gotIfNoneMatch[i], want[i])
+		}
+	}
+}
+
+func TestNewClient_retryableStatusCodes(t *testing.T) {
+	retryBackoff = time.Millisecond
+	defer func() { retryBackoff = time.Second }()
+
+	tests := map[string]struct {
+		codes         []int
+		status        int
+		failures      int
+		wantRequested int
+		wantErr       bool
+	}{
+		"retries the server error by default": {
+			status:        http.StatusServiceUnavailable,
+			failures:      2,
+			wantRequested: 3,
+		},
+		"does not retry the client error by default": {
+			status:        http.StatusNotFound,
+			failures:      1,
+			wantRequested: 1,
+			wantErr:       true,
+		},
+		"returns the last error when the retries are exhausted": {
+			status:        http.StatusBadGateway,
+			failures:      10,
+			wantRequested: maxRetries + 1,
+			wantErr:       true,
+		},
+		"retries the configured client error": {
+			codes:         []int{http.StatusForbidden},
+			status:        http.StatusForbidden,
+			failures:      1,
+			wantRequested: 2,
+		},
+		"does not retry the server error which is not configured": {
+			codes:         []int{http.StatusServiceUnavailable},
+			status:        http.StatusBadGateway,
+			failures:      1,
+			wantRequested: 1,
+			wantErr:       true,
+		},
+		"does not retry when the codes are empty": {
+			codes:         []int{},
+			status:        http.StatusServiceUnavailable,
+			failures:      1,
+			wantRequested: 1,
+			wantErr:       true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requested int
+			var opts []Option
+			if tt.codes != nil {
+				opts = append(opts, WithRetryableStatusCodes(tt.codes))
+			}
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requested++
+				if requested <= tt.failures {
+					w.WriteHeader(tt.status)
+					w.Write([]byte(`{"message":"error"}`))
+					return
+				}
+				w.Write([]byte(`{}`))
+			}, opts...)
+
+			_, _, err := c.GetCombinedStatus(context.Background(), "owner", "repo", "main", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetCombinedStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requested != tt.wantRequested {
+				t.Errorf("requested %d times, want %d", requested, tt.wantRequested)
+			}
+		})
+	}
+}
+
+func TestNewClient_retryAfter(t *testing.T) {
+	var requested int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requested++
+		if requested == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	// The Retry-After header of 0 seconds takes precedence over the default backoff of a second.
+	start := time.Now()
+	if _, _, err := c.GetCombinedStatus(context.Background(), "owner", "repo", "main", nil); err != nil {
+		t.Fatalf("GetCombinedStatus() unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= retryBackoff {
+		t.Errorf("retry took %v, want less than %v", elapsed, retryBackoff)
+	}
+	if requested != 2 {
+		t.Errorf("requested %d times, want 2", requested)
+	}
+}
+
+func TestNewClient_invalidRetryableStatusCodes(t *testing.T) {
+	tests := map[string][]int{
+		"returns error when the code is successful": {http.StatusServiceUnavailable, http.StatusOK},
+		"returns error when the code is redirect":   {http.StatusNotModified},
+		"returns error when the code is unknown":    {600},
+	}
+	for name, codes := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient(context.Background(), "test-token", WithRetryableStatusCodes(codes)); err == nil {
+				t.Error("NewClient() expected error")
+			}
+		})
+	}
+}
+
+func TestClient_apiErrorCategory(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not Found"}`))
+	})
+
+	_, resp, err := c.GetPullRequest(context.Background(), "owner", "repo", 1)
+	if c := multierror.CategoryOf(err); c != multierror.CategoryAPI {
+		t.Errorf("CategoryOf() = %q, want %q", c, multierror.CategoryAPI)
+	}
+	// The message and the response are kept as they are.
+	if !strings.Contains(err.Error(), "404 Not Found") || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetPullRequest() error = %v, want the error response", err)
+	}
+}
+
+func TestNewClient_tracerProvider(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			w.Write([]byte(`{"statuses":[{"context":"build"},{"context":"test"}]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"Internal Server Error"}`))
+		}
+	},
+		WithTracerProvider(tp),
+		WithRetryableStatusCodes(nil),
+	)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "poll")
+	if _, _, err := c.GetCombinedStatus(ctx, "owner", "repo", "main", &ListOptions{Page: 2}); err != nil {
+		t.Fatalf("GetCombinedStatus() unexpected error: %v", err)
+	}
+	if _, _, err := c.ListCheckRunsForRef(ctx, "owner", "repo", "main", nil); err == nil {
+		t.Fatal("ListCheckRunsForRef() expected error")
+	}
+	// The other calls are not traced.
+	c.GetPullRequest(ctx, "owner", "repo", 1)
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Ended() = %d spans, want the two calls and the parent", len(spans))
+	}
+	statuses, checkRuns := spans[0], spans[1]
+	if statuses.Name() != "GetCombinedStatus" || checkRuns.Name() != "ListCheckRunsForRef" {
+		t.Fatalf("Ended() names = %s, %s, want GetCombinedStatus, ListCheckRunsForRef", statuses.Name(), checkRuns.Name())
+	}
+	for _, span := range []sdktrace.ReadOnlySpan{statuses, checkRuns} {
+		if parentID := span.Parent().SpanID(); parentID != spans[2].SpanContext().SpanID() {
+			t.Errorf("%s parent = %s, want %s", span.Name(), parentID, spans[2].SpanContext().SpanID())
+		}
+		if ref := spanAttribute(span, "github.ref").AsString(); ref != "main" {
+			t.Errorf("%s github.ref = %v, want main", span.Name(), ref)
+		}
+	}
+	if page := spanAttribute(statuses, "github.page").AsInt64(); page != 2 {
+		t.Errorf("GetCombinedStatus github.page = %v, want 2", page)
+	}
+	if n := spanAttribute(statuses, "github.statuses").AsInt64(); n != 2 {
+		t.Errorf("GetCombinedStatus github.statuses = %v, want 2", n)
+	}
+	if statuses.Status().Code == codes.Error || checkRuns.Status().Code != codes.Error {
+		t.Errorf("status codes = %v, %v, want only ListCheckRunsForRef to fail", statuses.Status().Code, checkRuns.Status().Code)
+	}
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestNewClient_baseURL(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"installed_version":"3.1.4"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(context.Background(), "test-token", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	got, _, err := c.GetServerVersion(context.Background())
+	if err != nil {
+		t.Fatalf("GetServerVersion() unexpected error: %v", err)
+	}
+	if got != "3.1.4" {
+		t.Errorf("GetServerVersion() = %s, want 3.1.4", got)
+	}
+	if gotPath != "/api/v3/meta" {
+		t.Errorf("path = %s, want /api/v3/meta", gotPath)
+	}
+}
+
+func TestClient_GetServerVersion_github(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"verifiable_password_authentication":true}`))
+	})
+
+	got, _, err := c.GetServerVersion(context.Background())
+	if err != nil {
+		t.Fatalf("GetServerVersion() unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("GetServerVersion() = %s, want empty", got)
+	}
+}
@@ -7,18 +7,138 @@ import (
 )
 
 type Client struct {
-	GetCombinedStatusFunc   func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error)
-	ListCheckRunsForRefFunc func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+	GetCombinedStatusFunc       func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error)
+	CreateStatusFunc            func(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error)
+	ListCheckRunsForRefFunc     func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+	ListCheckRunAnnotationsFunc func(ctx context.Context, owner, repo string, checkRunID int64, opts *github.ListOptions) ([]*github.CheckRunAnnotation, *github.Response, error)
+	GetCommitSHA1Func           func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error)
+	CompareCommitsFunc          func(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error)
+	GetRefFunc                  func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error)
+	GetCommitFunc               func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error)
+	GetTagFunc                  func(ctx context.Context, owner, repo, sha string) (*github.Tag, *github.Response, error)
+	GetPullRequestFunc          func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error)
+	ListReviewsFunc             func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error)
+	ListPullRequestsFunc        func(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+	ListPullRequestFilesFunc    func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error)
+	ListTeamMembersBySlugFunc   func(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error)
+	ListLabelsByIssueFunc       func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error)
+	ListIssueCommentsFunc       func(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+	CreateIssueCommentFunc      func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	EditIssueCommentFunc        func(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	GetRepositoryFunc           func(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+	GetRequiredStatusChecksFunc func(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error)
+	ListDeploymentsFunc         func(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, *github.Response, error)
+	ListDeploymentStatusesFunc  func(ctx context.Context, owner, repo string, deployment int64, opts *github.ListOptions) ([]*github.DeploymentStatus, *github.Response, error)
+	GetServerVersionFunc        func(ctx context.Context) (string, *github.Response, error)
+	GetWorkflowRunByIDFunc      func(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error)
+	ListWorkflowJobsFunc        func(ctx context.Context, owner, repo string, runID int64, opts *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error)
+	GetWorkflowJobByIDFunc      func(ctx context.Context, owner, repo string, jobID int64) (*github.WorkflowJob, *github.Response, error)
 }
 
 func (c *Client) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
 	return c.GetCombinedStatusFunc(ctx, owner, repo, ref, opts)
 }
 
+func (c *Client) CreateStatus(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+	return c.CreateStatusFunc(ctx, owner, repo, ref, status)
+}
+
 func (c *Client) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
 	return c.ListCheckRunsForRefFunc(ctx, owner, repo, ref, opts)
 }
 
+func (c *Client) ListCheckRunAnnotations(ctx context.Context, owner, repo string, checkRunID int64, opts *github.ListOptions) ([]*github.CheckRunAnnotation, *github.Response, error) {
+	return c.ListCheckRunAnnotationsFunc(ctx, owner, repo, checkRunID, opts)
+}
+
+func (c *Client) GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+	return c.GetCommitSHA1Func(ctx, owner, repo, ref, lastSHA)
+}
+
+func (c *Client) GetRef(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+	return c.GetRefFunc(ctx, owner, repo, ref)
+}
+
+func (c *Client) GetCommit(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	return c.GetCommitFunc(ctx, owner, repo, ref, opts)
+}
+
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return c.GetRepositoryFunc(ctx, owner, repo)
+}
+
+func (c *Client) GetTag(ctx context.Context, owner, repo, sha string) (*github.Tag, *github.Response, error) {
+	return c.GetTagFunc(ctx, owner, repo, sha)
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return c.GetPullRequestFunc(ctx, owner, repo, number)
+}
+
+func (c *Client) ListReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+	return c.ListReviewsFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) ListPullRequests(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	return c.ListPullRequestsFunc(ctx, owner, repo, opts)
+}
+
+func (c *Client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	return c.ListPullRequestFilesFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
+	return c.ListTeamMembersBySlugFunc(ctx, org, slug, opts)
+}
+
+func (c *Client) ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+	return c.ListLabelsByIssueFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+	return c.GetRequiredStatusChecksFunc(ctx, owner, repo, branch)
+}
+
+func (c *Client) ListDeployments(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, *github.Response, error) {
+	return c.ListDeploymentsFunc(ctx, owner, repo, opts)
+}
+
+func (c *Client) ListDeploymentStatuses(ctx context.Context, owner, repo string, deployment int64, opts *github.ListOptions) ([]*github.DeploymentStatus, *github.Response, error) {
+	return c.ListDeploymentStatusesFunc(ctx, owner, repo, deployment, opts)
+}
+
+func (c *Client) GetServerVersion(ctx context.Context) (string, *github.Response, error) {
+	return c.GetServerVersionFunc(ctx)
+}
+
+func (c *Client) GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+	return c.GetWorkflowRunByIDFunc(ctx, owner, repo, runID)
+}
+
+func (c *Client) ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, opts *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error) {
+	return c.ListWorkflowJobsFunc(ctx, owner, repo, runID, opts)
+}
+
+func (c *Client) GetWorkflowJobByID(ctx context.Context, owner, repo string, jobID int64) (*github.WorkflowJob, *github.Response, error) {
+	return c.GetWorkflowJobByIDFunc(ctx, owner, repo, jobID)
+}
+
+func (c *Client) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	return c.CompareCommitsFunc(ctx, owner, repo, base, head, opts)
+}
+
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	return c.ListIssueCommentsFunc(ctx, owner, repo, number, opts)
+}
+
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return c.CreateIssueCommentFunc(ctx, owner, repo, number, comment)
+}
+
+func (c *Client) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return c.EditIssueCommentFunc(ctx, owner, repo, commentID, comment)
+}
+
 var (
 	_ github.Client = &Client{}
 )
@@ -0,0 +1,83 @@
+package github
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Option func(o *clientOption)
+
+type clientOption struct {
+	userAgent string
+	headers   http.Header
+	rpm       int
+	etagCache bool
+	baseURL   string
+
+	retryableStatusCodes []int
+	tracerProvider       trace.TracerProvider
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(o *clientOption) {
+		if len(ua) != 0 {
+			o.userAgent = ua
+		}
+	}
+}
+
+// WithHeaders adds the given headers to every request. The Authorization header cannot be overridden.
+func WithHeaders(headers map[string]string) Option {
+	return func(o *clientOption) {
+		if o.headers == nil {
+			o.headers = make(http.Header, len(headers))
+		}
+		for name, value := range headers {
+			o.headers.Set(name, value)
+		}
+	}
+}
+
+// WithMaxRequestsPerMinute limits the number of requests sent per minute, so that gatekeepers sharing a token stay
+// within the API budget. Requests beyond the limit wait until they can be sent. Zero means unlimited.
+func WithMaxRequestsPerMinute(rpm int) Option {
+	return func(o *clientOption) {
+		o.rpm = rpm
+	}
+}
+
+// WithETagCache caches the responses and sends conditional requests, so that unchanged resources are served from
+// the cache without consuming the rate limit. IsNotModified reports whether a response is served from the cache.
+func WithETagCache(enabled bool) Option {
+	return func(o *clientOption) {
+		o.etagCache = enabled
+	}
+}
+
+// WithBaseURL sets the URL of the API to send the requests to, such as "https://github.example.com/api/v3/" for
+// GitHub Enterprise Server. The "/api/v3/" path is appended when it is omitted for an enterprise host.
+func WithBaseURL(url string) Option {
+	return func(o *clientOption) {
+		if len(url) != 0 {
+			o.baseURL = url
+		}
+	}
+}
+
+// WithRetryableStatusCodes replaces the status codes of the responses which are retried, such as adding 429 behind
+// a proxy which throttles the requests. The codes must be 4xx or 5xx. An empty list disables the retries.
+func WithRetryableStatusCodes(codes []int) Option {
+	return func(o *clientOption) {
+		o.retryableStatusCodes = append([]int{}, codes...)
+	}
+}
+
+// WithTracerProvider starts a span with the tracer of the provider around each call listing the commit statuses or
+// the check runs of a ref. Nothing is traced by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *clientOption) {
+		o.tracerProvider = tp
+	}
+}
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimitTransport holds requests back so that no more than the configured number of requests are sent per minute.
+// It is a token bucket holding a single token, which spaces the requests evenly, so that the limit is respected in any
+// minute window even when the requests are sent concurrently.
+type rateLimitTransport struct {
+	base     http.RoundTripper
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimitTransport(base http.RoundTripper, rpm int) *rateLimitTransport {
+	return &rateLimitTransport{
+		base:     base,
+		interval: time.Minute / time.Duration(rpm),
+	}
+}
+
+// RoundTrip holds the request back until the token is available. The conditional requests are sent right away
+// instead, as GitHub does not count them against the rate limit when they are answered with 304 Not Modified, and
+// they take the token only once they are answered otherwise, which holds the later requests back in their place.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("If-None-Match") != "" {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil && resp.StatusCode != http.StatusNotModified {
+			t.reserve()
+		}
+		return resp, err
+	}
+
+	if d := t.reserve(); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// reserve takes the token and returns the duration to wait until it is available.
+// The reservation is not returned when the request is cancelled while waiting, which only delays later requests.
+func (t *rateLimitTransport) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	d := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+	return d
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxRetries is the number of times a request is retried after its first attempt.
+	maxRetries = 3
+
+	// maxRetryAfter caps the wait requested by the Retry-After header, so that a misbehaving proxy cannot hold the
+	// gatekeeper for the whole timeout with a single response.
+	maxRetryAfter = time.Minute
+)
+
+// defaultRetryableStatusCodes are the status codes which are retried when the codes are not configured. They are the
+// transient errors of the server and the proxies in front of it.
+var defaultRetryableStatusCodes = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryBackoff is the wait before the first retry, which is doubled for each later retry.
+var retryBackoff = time.Second
+
+// retryTransport retries the requests whose responses have one of the retryable status codes.
+// The response of the last attempt is returned as is when all of the retries fail.
+type retryTransport struct {
+	base    http.RoundTripper
+	codes   map[int]struct{}
+	backoff time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, codes []int) *retryTransport {
+	t := &retryTransport{
+		base:    base,
+		codes:   make(map[int]struct{}, len(codes)),
+		backoff: retryBackoff,
+	}
+	for _, code := range codes {
+		t.codes[code] = struct{}{}
+	}
+	return t
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || attempt == maxRetries || !t.retryable(resp.StatusCode) {
+			return resp, err
+		}
+		// A request whose body cannot be sent again is not retried.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait := backoff
+		if d, ok := retryAfter(resp.Header); ok {
+			wait = d
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func (t *retryTransport) retryable(code int) bool {
+	_, ok := t.codes[code]
+	return ok
+}
+
+// retryAfter returns the wait requested by the Retry-After header in seconds, capped at maxRetryAfter.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	d := time.Duration(seconds) * time.Second
+	if d > maxRetryAfter {
+		d = maxRetryAfter
+	}
+	return d, true
+}
+
+// validateRetryableStatusCodes rejects the codes which are not errors, since retrying a successful or redirected
+// response would only repeat it.
+func validateRetryableStatusCodes(codes []int) error {
+	for _, code := range codes {
+		if code < 400 || code > 599 {
+			return fmt.Errorf("invalid retryable status code: %d, must be between 400 and 599", code)
+		}
+	}
+	return nil
+}
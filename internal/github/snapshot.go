@@ -0,0 +1,311 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrNotInSnapshot is returned by the replaying client for the requests whose responses are not in the snapshot.
+var ErrNotInSnapshot = errors.New("response is not in the snapshot")
+
+// Snapshot is the raw responses of the combined statuses and the check runs, keyed by the requests, so that
+// a validation can be replayed offline against them. The commits which the refs are resolved to and the pull
+// requests are recorded as well, so that the ref resolution and the head of the pull request are replayed too, along
+// with the workflow jobs and runs which the check runs of GitHub Actions are looked up with.
+type Snapshot struct {
+	CombinedStatuses map[string]*CombinedStatus       `json:"combined_statuses"`
+	CheckRuns        map[string]*ListCheckRunsResults `json:"check_runs"`
+	Commits          map[string]string                `json:"commits,omitempty"`
+	PullRequests     map[string]*PullRequest          `json:"pull_requests,omitempty"`
+	WorkflowJobs     map[string]*WorkflowJob          `json:"workflow_jobs,omitempty"`
+	WorkflowRuns     map[string]*WorkflowRun          `json:"workflow_runs,omitempty"`
+}
+
+func newSnapshot() *Snapshot {
+	return &Snapshot{
+		CombinedStatuses: make(map[string]*CombinedStatus),
+		CheckRuns:        make(map[string]*ListCheckRunsResults),
+		Commits:          make(map[string]string),
+		PullRequests:     make(map[string]*PullRequest),
+		WorkflowJobs:     make(map[string]*WorkflowJob),
+		WorkflowRuns:     make(map[string]*WorkflowRun),
+	}
+}
+
+// ReadSnapshot reads the snapshot written by RecordingClient.WriteSnapshot.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	s := newSnapshot()
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	return s, nil
+}
+
+func combinedStatusKey(owner, repo, ref string, opts *ListOptions) string {
+	var page, perPage int
+	if opts != nil {
+		page, perPage = opts.Page, opts.PerPage
+	}
+	return fmt.Sprintf("%s/%s@%s?page=%d&per_page=%d", owner, repo, ref, page, perPage)
+}
+
+func commitKey(owner, repo, ref string) string {
+	return fmt.Sprintf("%s/%s@%s", owner, repo, ref)
+}
+
+func pullRequestKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
+
+func workflowKey(owner, repo string, id int64) string {
+	return fmt.Sprintf("%s/%s/%d", owner, repo, id)
+}
+
+func checkRunsKey(owner, repo, ref string, opts *ListCheckRunsOptions) string {
+	if opts == nil {
+		return combinedStatusKey(owner, repo, ref, nil)
+	}
+	key := combinedStatusKey(owner, repo, ref, &opts.ListOptions)
+	if opts.CheckName != nil {
+		key += "&check_name=" + *opts.CheckName
+	}
+	return key
+}
+
+// RecordingClient records the responses of the combined statuses, the check runs, the commits of the refs, the pull
+// requests and the workflow jobs and runs into a snapshot. The latest response is kept for each request, so that the snapshot reproduces the
+// last poll. The other requests are sent as is without being recorded.
+type RecordingClient struct {
+	Client
+
+	mu       sync.Mutex
+	snapshot *Snapshot
+}
+
+func NewRecordingClient(c Client) *RecordingClient {
+	return &RecordingClient{
+		Client:   c,
+		snapshot: newSnapshot(),
+	}
+}
+
+func (c *RecordingClient) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error) {
+	combined, resp, err := c.Client.GetCombinedStatus(ctx, owner, repo, ref, opts)
+	if err == nil {
+		c.mu.Lock()
+		c.snapshot.CombinedStatuses[combinedStatusKey(owner, repo, ref, opts)] = combined
+		c.mu.Unlock()
+	}
+	return combined, resp, err
+}
+
+func (c *RecordingClient) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error) {
+	runs, resp, err := c.Client.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+	if err == nil {
+		c.mu.Lock()
+		c.snapshot.CheckRuns[checkRunsKey(owner, repo, ref, opts)] = runs
+		c.mu.Unlock()
+	}
+	return runs, resp, err
+}
+
+func (c *RecordingClient) GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *Response, error) {
+	sha, resp, err := c.Client.GetCommitSHA1(ctx, owner, repo, ref, lastSHA)
+	if err == nil && len(sha) != 0 {
+		c.mu.Lock()
+		c.snapshot.Commits[commitKey(owner, repo, ref)] = sha
+		c.mu.Unlock()
+	}
+	return sha, resp, err
+}
+
+func (c *RecordingClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error) {
+	pr, resp, err := c.Client.GetPullRequest(ctx, owner, repo, number)
+	if err == nil {
+		c.mu.Lock()
+		c.snapshot.PullRequests[pullRequestKey(owner, repo, number)] = pr
+		c.mu.Unlock()
+	}
+	return pr, resp, err
+}
+
+func (c *RecordingClient) GetWorkflowJobByID(ctx context.Context, owner, repo string, jobID int64) (*WorkflowJob, *Response, error) {
+	job, resp, err := c.Client.GetWorkflowJobByID(ctx, owner, repo, jobID)
+	if err == nil {
+		c.mu.Lock()
+		c.snapshot.WorkflowJobs[workflowKey(owner, repo, jobID)] = job
+		c.mu.Unlock()
+	}
+	return job, resp, err
+}
+
+func (c *RecordingClient) GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*WorkflowRun, *Response, error) {
+	run, resp, err := c.Client.GetWorkflowRunByID(ctx, owner, repo, runID)
+	if err == nil {
+		c.mu.Lock()
+		c.snapshot.WorkflowRuns[workflowKey(owner, repo, runID)] = run
+		c.mu.Unlock()
+	}
+	return run, resp, err
+}
+
+// WriteSnapshot writes the recorded responses as JSON.
+func (c *RecordingClient) WriteSnapshot(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c.snapshot)
+}
+
+type replayClient struct {
+	snapshot *Snapshot
+}
+
+// NewReplayClient returns the client which serves the recorded responses from the snapshot instead of the API. All
+// the other requests fail with ErrNotInSnapshot, as they are not recorded.
+func NewReplayClient(s *Snapshot) Client {
+	return &replayClient{snapshot: s}
+}
+
+func (c *replayClient) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error) {
+	key := combinedStatusKey(owner, repo, ref, opts)
+	combined, ok := c.snapshot.CombinedStatuses[key]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: combined status of %s", ErrNotInSnapshot, key)
+	}
+	return combined, nil, nil
+}
+
+func (c *replayClient) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error) {
+	key := checkRunsKey(owner, repo, ref, opts)
+	runs, ok := c.snapshot.CheckRuns[key]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: check runs of %s", ErrNotInSnapshot, key)
+	}
+	return runs, nil, nil
+}
+
+func (c *replayClient) ListCheckRunAnnotations(ctx context.Context, owner, repo string, checkRunID int64, opts *ListOptions) ([]*CheckRunAnnotation, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: annotations of check run %d of %s/%s", ErrNotInSnapshot, checkRunID, owner, repo)
+}
+
+func (c *replayClient) CreateStatus(ctx context.Context, owner, repo, ref string, status *RepoStatus) (*RepoStatus, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: creating status of %s/%s@%s", ErrNotInSnapshot, owner, repo, ref)
+}
+
+func (c *replayClient) GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *Response, error) {
+	key := commitKey(owner, repo, ref)
+	sha, ok := c.snapshot.Commits[key]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: commit of %s", ErrNotInSnapshot, key)
+	}
+	return sha, nil, nil
+}
+
+func (c *replayClient) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *ListOptions) (*CommitsComparison, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: comparison of %s...%s of %s/%s", ErrNotInSnapshot, base, head, owner, repo)
+}
+
+func (c *replayClient) GetRef(ctx context.Context, owner, repo, ref string) (*Reference, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: ref %s of %s/%s", ErrNotInSnapshot, ref, owner, repo)
+}
+
+func (c *replayClient) GetCommit(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*RepositoryCommit, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: commit %s of %s/%s", ErrNotInSnapshot, ref, owner, repo)
+}
+
+func (c *replayClient) GetRepository(ctx context.Context, owner, repo string) (*Repository, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: repository %s/%s", ErrNotInSnapshot, owner, repo)
+}
+
+func (c *replayClient) GetTag(ctx context.Context, owner, repo, sha string) (*Tag, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: tag %s of %s/%s", ErrNotInSnapshot, sha, owner, repo)
+}
+
+func (c *replayClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error) {
+	key := pullRequestKey(owner, repo, number)
+	pr, ok := c.snapshot.PullRequests[key]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: pull request %s", ErrNotInSnapshot, key)
+	}
+	return pr, nil, nil
+}
+
+func (c *replayClient) ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: reviews of %s/%s#%d", ErrNotInSnapshot, owner, repo, number)
+}
+
+func (c *replayClient) ListPullRequests(ctx context.Context, owner, repo string, opts *PullRequestListOptions) ([]*PullRequest, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: pull requests of %s/%s", ErrNotInSnapshot, owner, repo)
+}
+
+func (c *replayClient) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*CommitFile, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: files of %s/%s#%d", ErrNotInSnapshot, owner, repo, number)
+}
+
+func (c *replayClient) ListTeamMembersBySlug(ctx context.Context, org, slug string, opts *TeamListTeamMembersOptions) ([]*User, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: members of %s/%s", ErrNotInSnapshot, org, slug)
+}
+
+func (c *replayClient) ListLabelsByIssue(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*Label, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: labels of %s/%s#%d", ErrNotInSnapshot, owner, repo, number)
+}
+
+func (c *replayClient) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: comments of %s/%s#%d", ErrNotInSnapshot, owner, repo, number)
+}
+
+func (c *replayClient) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: creating comment of %s/%s#%d", ErrNotInSnapshot, owner, repo, number)
+}
+
+func (c *replayClient) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *IssueComment) (*IssueComment, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: editing comment %d of %s/%s", ErrNotInSnapshot, commentID, owner, repo)
+}
+
+func (c *replayClient) GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*RequiredStatusChecks, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: required status checks of %s/%s@%s", ErrNotInSnapshot, owner, repo, branch)
+}
+
+func (c *replayClient) ListDeployments(ctx context.Context, owner, repo string, opts *DeploymentsListOptions) ([]*Deployment, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: deployments of %s/%s", ErrNotInSnapshot, owner, repo)
+}
+
+func (c *replayClient) ListDeploymentStatuses(ctx context.Context, owner, repo string, deployment int64, opts *ListOptions) ([]*DeploymentStatus, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: statuses of deployment %d of %s/%s", ErrNotInSnapshot, deployment, owner, repo)
+}
+
+func (c *replayClient) GetServerVersion(ctx context.Context) (string, *Response, error) {
+	return "", nil, fmt.Errorf("%w: server version", ErrNotInSnapshot)
+}
+
+func (c *replayClient) GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*WorkflowRun, *Response, error) {
+	run, ok := c.snapshot.WorkflowRuns[workflowKey(owner, repo, runID)]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: workflow run %d of %s/%s", ErrNotInSnapshot, runID, owner, repo)
+	}
+	return run, nil, nil
+}
+
+func (c *replayClient) ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, opts *ListWorkflowJobsOptions) (*Jobs, *Response, error) {
+	return nil, nil, fmt.Errorf("%w: jobs of workflow run %d of %s/%s", ErrNotInSnapshot, runID, owner, repo)
+}
+
+func (c *replayClient) GetWorkflowJobByID(ctx context.Context, owner, repo string, jobID int64) (*WorkflowJob, *Response, error) {
+	job, ok := c.snapshot.WorkflowJobs[workflowKey(owner, repo, jobID)]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: workflow job %d of %s/%s", ErrNotInSnapshot, jobID, owner, repo)
+	}
+	return job, nil, nil
+}
+
+var (
+	_ Client = &RecordingClient{}
+	_ Client = &replayClient{}
+)
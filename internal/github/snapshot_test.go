@@ -0,0 +1,120 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v38/github"
+)
+
+type liveClient struct {
+	Client
+}
+
+func (c *liveClient) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error) {
+	return &CombinedStatus{
+		TotalCount: github.Int(1),
+		Statuses:   []*RepoStatus{{Context: github.String("lint"), State: github.String("success")}},
+	}, nil, nil
+}
+
+func (c *liveClient) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error) {
+	return &ListCheckRunsResults{
+		Total:     github.Int(1),
+		CheckRuns: []*CheckRun{{Name: opts.CheckName, Status: github.String("in_progress")}},
+	}, nil, nil
+}
+
+func (c *liveClient) GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *Response, error) {
+	return "0123456789abcdef0123456789abcdef01234567", nil, nil
+}
+
+func (c *liveClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error) {
+	return &PullRequest{
+		Number: github.Int(number),
+		Head:   &PullRequestBranch{SHA: github.String("0123456789abcdef0123456789abcdef01234567")},
+	}, nil, nil
+}
+
+func (c *liveClient) GetWorkflowJobByID(ctx context.Context, owner, repo string, jobID int64) (*WorkflowJob, *Response, error) {
+	return &WorkflowJob{ID: github.Int64(jobID), RunID: github.Int64(101)}, nil, nil
+}
+
+func (c *liveClient) GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*WorkflowRun, *Response, error) {
+	return &WorkflowRun{ID: github.Int64(runID), Name: github.String("CI")}, nil, nil
+}
+
+func TestReplayClient(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRecordingClient(&liveClient{})
+	wantCombined, _, _ := rc.GetCombinedStatus(ctx, "owner", "repo", "main", &ListOptions{Page: 1, PerPage: 100})
+	wantRuns, _, _ := rc.ListCheckRunsForRef(ctx, "owner", "repo", "main", &ListCheckRunsOptions{
+		CheckName:   github.String("e2e"),
+		ListOptions: ListOptions{Page: 1, PerPage: 100},
+	})
+	wantSHA, _, _ := rc.GetCommitSHA1(ctx, "owner", "repo", "main", "")
+	wantPR, _, _ := rc.GetPullRequest(ctx, "owner", "repo", 1)
+	wantJob, _, _ := rc.GetWorkflowJobByID(ctx, "owner", "repo", 11)
+	wantRun, _, _ := rc.GetWorkflowRunByID(ctx, "owner", "repo", 101)
+
+	var buf bytes.Buffer
+	if err := rc.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("WriteSnapshot() unexpected error: %v", err)
+	}
+	s, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() unexpected error: %v", err)
+	}
+	c := NewReplayClient(s)
+
+	combined, _, err := c.GetCombinedStatus(ctx, "owner", "repo", "main", &ListOptions{Page: 1, PerPage: 100})
+	if err != nil {
+		t.Fatalf("GetCombinedStatus() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(combined, wantCombined) {
+		t.Errorf("GetCombinedStatus() = %v, want %v", combined, wantCombined)
+	}
+	runs, _, err := c.ListCheckRunsForRef(ctx, "owner", "repo", "main", &ListCheckRunsOptions{
+		CheckName:   github.String("e2e"),
+		ListOptions: ListOptions{Page: 1, PerPage: 100},
+	})
+	if err != nil {
+		t.Fatalf("ListCheckRunsForRef() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(runs, wantRuns) {
+		t.Errorf("ListCheckRunsForRef() = %v, want %v", runs, wantRuns)
+	}
+
+	if sha, _, err := c.GetCommitSHA1(ctx, "owner", "repo", "main", ""); err != nil || sha != wantSHA {
+		t.Errorf("GetCommitSHA1() = %s, %v, want %s", sha, err, wantSHA)
+	}
+	if pr, _, err := c.GetPullRequest(ctx, "owner", "repo", 1); err != nil || !reflect.DeepEqual(pr, wantPR) {
+		t.Errorf("GetPullRequest() = %v, %v, want %v", pr, err, wantPR)
+	}
+
+	if job, _, err := c.GetWorkflowJobByID(ctx, "owner", "repo", 11); err != nil || !reflect.DeepEqual(job, wantJob) {
+		t.Errorf("GetWorkflowJobByID() = %v, %v, want %v", job, err, wantJob)
+	}
+	if run, _, err := c.GetWorkflowRunByID(ctx, "owner", "repo", 101); err != nil || !reflect.DeepEqual(run, wantRun) {
+		t.Errorf("GetWorkflowRunByID() = %v, %v, want %v", run, err, wantRun)
+	}
+
+	if _, _, err := c.GetCombinedStatus(ctx, "owner", "repo", "main", &ListOptions{Page: 2, PerPage: 100}); !errors.Is(err, ErrNotInSnapshot) {
+		t.Errorf("GetCombinedStatus() error = %v, want %v for the page not recorded", err, ErrNotInSnapshot)
+	}
+	if _, _, err := c.ListCheckRunsForRef(ctx, "owner", "repo", "main", &ListCheckRunsOptions{ListOptions: ListOptions{Page: 1, PerPage: 100}}); !errors.Is(err, ErrNotInSnapshot) {
+		t.Errorf("ListCheckRunsForRef() error = %v, want %v for the name not recorded", err, ErrNotInSnapshot)
+	}
+	if _, _, err := c.GetCommitSHA1(ctx, "owner", "repo", "develop", ""); !errors.Is(err, ErrNotInSnapshot) {
+		t.Errorf("GetCommitSHA1() error = %v, want %v for the ref not recorded", err, ErrNotInSnapshot)
+	}
+	if _, _, err := c.GetPullRequest(ctx, "owner", "repo", 2); !errors.Is(err, ErrNotInSnapshot) {
+		t.Errorf("GetPullRequest() error = %v, want %v for the pull request not recorded", err, ErrNotInSnapshot)
+	}
+	if _, _, err := c.GetWorkflowJobByID(ctx, "owner", "repo", 12); !errors.Is(err, ErrNotInSnapshot) {
+		t.Errorf("GetWorkflowJobByID() error = %v, want %v for the workflow job not recorded", err, ErrNotInSnapshot)
+	}
+}
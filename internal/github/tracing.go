@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/upsidr/merge-gatekeeper/internal/github"
+
+// tracingClient starts a span around each call listing the jobs of a ref, which dominate the API calls of a poll.
+type tracingClient struct {
+	Client
+	tracer trace.Tracer
+}
+
+func (c *tracingClient) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *ListOptions) (*CombinedStatus, *Response, error) {
+	ctx, span := c.tracer.Start(ctx, "GetCombinedStatus", trace.WithAttributes(refAttributes(owner, repo, ref, listPage(opts))...))
+	defer span.End()
+
+	combined, resp, err := c.Client.GetCombinedStatus(ctx, owner, repo, ref, opts)
+	if combined != nil {
+		span.SetAttributes(attribute.Int("github.statuses", len(combined.Statuses)))
+	}
+	recordError(span, err)
+	return combined, resp, err
+}
+
+func (c *tracingClient) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*ListCheckRunsResults, *Response, error) {
+	var page int
+	if opts != nil {
+		page = listPage(&opts.ListOptions)
+	}
+	ctx, span := c.tracer.Start(ctx, "ListCheckRunsForRef", trace.WithAttributes(refAttributes(owner, repo, ref, page)...))
+	defer span.End()
+
+	results, resp, err := c.Client.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+	if results != nil {
+		span.SetAttributes(attribute.Int("github.check_runs", len(results.CheckRuns)))
+	}
+	recordError(span, err)
+	return results, resp, err
+}
+
+func refAttributes(owner, repo, ref string, page int) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("github.owner", owner),
+		attribute.String("github.repo", repo),
+		attribute.String("github.ref", ref),
+		attribute.Int("github.page", page),
+	}
+}
+
+// listPage returns the page being listed, where zero means the first page.
+func listPage(opts *ListOptions) int {
+	if opts == nil || opts.Page == 0 {
+		return 1
+	}
+	return opts.Page
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
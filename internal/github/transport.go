@@ -0,0 +1,47 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// headerTransport sets the default headers to each request before passing it to the base transport.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
+
+func validateHeaders(headers http.Header) error {
+	for name := range headers {
+		if !isValidHeaderName(name) {
+			return fmt.Errorf("invalid header name: %q", name)
+		}
+		if strings.EqualFold(name, "Authorization") {
+			return fmt.Errorf("header %s cannot be overridden", name)
+		}
+	}
+	return nil
+}
+
+// isValidHeaderName reports whether the name is a valid token as defined in RFC 7230.
+func isValidHeaderName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for _, r := range name {
+		if r >= 0x80 || !strings.ContainsRune("!#$%&'*+-.^_`|~", r) &&
+			!('0' <= r && r <= '9') && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
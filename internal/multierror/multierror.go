@@ -5,8 +5,61 @@ import (
 	"fmt"
 )
 
+// Category classifies the errors, so that the errors of the same category are rendered together.
+type Category string
+
+const (
+	// CategoryConfig is the category of the invalid options, which are fixed by changing the configuration.
+	CategoryConfig Category = "configuration"
+	// CategoryAPI is the category of the failed requests of the GitHub API, which may be transient.
+	CategoryAPI Category = "github api"
+
+	// uncategorized is the heading of the errors without a category.
+	uncategorized Category = "other"
+)
+
+type categorizedError struct {
+	category Category
+	err      error
+}
+
+func (e *categorizedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *categorizedError) Unwrap() error {
+	return e.err
+}
+
+// Categorize tags the error with the category, without changing its message. It returns nil for a nil error.
+func Categorize(category Category, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &categorizedError{category: category, err: err}
+}
+
+// CategoryOf returns the category the error is tagged with, which may be wrapped by another error, or an empty
+// category when it is not tagged.
+func CategoryOf(err error) Category {
+	var ce *categorizedError
+	if errors.As(err, &ce) {
+		return ce.category
+	}
+	return ""
+}
+
 type Errors []error
 
+// Categorize tags all the errors with the category.
+func (es Errors) Categorize(category Category) Errors {
+	categorized := make(Errors, 0, len(es))
+	for _, e := range es {
+		categorized = append(categorized, Categorize(category, e))
+	}
+	return categorized
+}
+
 func (es Errors) Error() string {
 	switch len(es) {
 	case 0:
@@ -15,15 +68,47 @@ func (es Errors) Error() string {
 		return fmt.Sprintf("%v", es[0])
 	}
 
+	categories, grouped := es.group()
 	rt := "composite error:"
-	for _, e := range es {
-		if e != nil {
+	if len(categories) == 1 {
+		for _, e := range grouped[categories[0]] {
 			rt = fmt.Sprintf("%s\n\t%v", rt, e)
 		}
+		return rt
+	}
+	for _, c := range categories {
+		rt = fmt.Sprintf("%s\n\t%s errors:", rt, c)
+		for _, e := range grouped[c] {
+			rt = fmt.Sprintf("%s\n\t\t%v", rt, e)
+		}
 	}
 	return rt
 }
 
+// group returns the categories of the errors in the order of their first errors, followed by the uncategorized
+// errors, along with the errors of each category.
+func (es Errors) group() ([]Category, map[Category][]error) {
+	var categories []Category
+	grouped := make(map[Category][]error)
+	for _, e := range es {
+		if e == nil {
+			continue
+		}
+		c := CategoryOf(e)
+		if len(c) == 0 {
+			c = uncategorized
+		}
+		if _, ok := grouped[c]; !ok && c != uncategorized {
+			categories = append(categories, c)
+		}
+		grouped[c] = append(grouped[c], e)
+	}
+	if _, ok := grouped[uncategorized]; ok {
+		categories = append(categories, uncategorized)
+	}
+	return categories, grouped
+}
+
 func (es Errors) Is(target error) bool {
 	if len(es) == 0 {
 		return false
@@ -0,0 +1,71 @@
+package multierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrors_Error(t *testing.T) {
+	errCanceled := errors.New("canceled")
+
+	tests := map[string]struct {
+		errs Errors
+		want string
+	}{
+		"returns empty string when there is no error": {
+			errs: Errors{},
+			want: "",
+		},
+		"returns the message of the only error": {
+			errs: Errors{Categorize(CategoryAPI, errors.New("not found"))},
+			want: "not found",
+		},
+		"renders a flat list when all the errors are uncategorized": {
+			errs: Errors{errors.New("a"), nil, errors.New("b")},
+			want: "composite error:\n\ta\n\tb",
+		},
+		"renders a flat list when all the errors are of the same category": {
+			errs: Errors{errors.New("a"), errors.New("b")}.Categorize(CategoryConfig),
+			want: "composite error:\n\ta\n\tb",
+		},
+		"groups the errors by category in the order of their first errors": {
+			errs: Errors{
+				Categorize(CategoryAPI, errors.New("rate limited")),
+				errCanceled,
+				Categorize(CategoryConfig, errors.New("repository name is empty")),
+				fmt.Errorf("status: %w", Categorize(CategoryAPI, errors.New("not found"))),
+				Categorize(CategoryConfig, errors.New("self job name is empty")),
+			},
+			want: "composite error:" +
+				"\n\tgithub api errors:\n\t\trate limited\n\t\tstatus: not found" +
+				"\n\tconfiguration errors:\n\t\trepository name is empty\n\t\tself job name is empty" +
+				"\n\tother errors:\n\t\tcanceled",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.errs.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategorize(t *testing.T) {
+	if err := Categorize(CategoryAPI, nil); err != nil {
+		t.Errorf("Categorize() of nil = %v, want nil", err)
+	}
+
+	errNotFound := errors.New("not found")
+	err := fmt.Errorf("failed to get pull request: %w", Categorize(CategoryAPI, errNotFound))
+	if c := CategoryOf(err); c != CategoryAPI {
+		t.Errorf("CategoryOf() = %q, want %q", c, CategoryAPI)
+	}
+	if !errors.Is(err, errNotFound) {
+		t.Errorf("errors.Is() = false, want the categorized error to be unwrapped")
+	}
+	if c := CategoryOf(errNotFound); c != "" {
+		t.Errorf("CategoryOf() of uncategorized error = %q, want empty", c)
+	}
+}
@@ -0,0 +1,74 @@
+package approval
+
+import "strings"
+
+type Option func(a *approvalValidator)
+
+// WithName sets the name of the validator, which is used in the logs.
+func WithName(name string) Option {
+	return func(a *approvalValidator) {
+		if len(name) != 0 {
+			a.name = name
+		}
+	}
+}
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(a *approvalValidator) {
+		if len(owner) != 0 {
+			a.owner = owner
+		}
+		if len(repo) != 0 {
+			a.repo = repo
+		}
+	}
+}
+
+// WithPullRequest sets the pull request number, whose reviews are validated.
+func WithPullRequest(number int) Option {
+	return func(a *approvalValidator) {
+		if number > 0 {
+			a.prNumber = number
+		}
+	}
+}
+
+// WithMinApprovals sets the minimum number of approving reviews required on the head commit of the pull request.
+func WithMinApprovals(n int) Option {
+	return func(a *approvalValidator) {
+		a.minApprovals = n
+	}
+}
+
+// WithRequiredReviewers sets the users who must have approved the head commit of the pull request.
+func WithRequiredReviewers(logins string) Option {
+	return func(a *approvalValidator) {
+		if len(logins) == 0 {
+			return
+		}
+		a.requiredReviewers = splitNames(logins)
+	}
+}
+
+// WithRequiredTeams sets the teams of which any member must have approved the head commit of the pull request.
+// A team is either "org/team-slug", or "team-slug" of the organization owning the repository.
+func WithRequiredTeams(teams string) Option {
+	return func(a *approvalValidator) {
+		if len(teams) == 0 {
+			return
+		}
+		a.requiredTeams = splitNames(teams)
+	}
+}
+
+func splitNames(names string) []string {
+	ns := []string{}
+	for _, s := range strings.Split(names, ",") {
+		name := strings.TrimSpace(s)
+		if len(name) == 0 {
+			continue
+		}
+		ns = append(ns, name)
+	}
+	return ns
+}
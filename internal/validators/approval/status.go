@@ -0,0 +1,64 @@
+package approval
+
+import "fmt"
+
+type status struct {
+	minApprovals       int
+	approvedBy         []string
+	staleBy            []string
+	changesRequestedBy []string
+	missingReviewers   []string
+	missingTeams       []string
+	succeeded          bool
+}
+
+func prettyPrintReviewerList(reviewers []string) string {
+	result := ""
+	if len(reviewers) == 0 {
+		result = "[]"
+	}
+	for i, reviewer := range reviewers {
+		result += fmt.Sprintf("- %s", reviewer)
+		if i != len(reviewers)-1 {
+			result += "\n"
+		}
+	}
+	return result
+}
+
+func (s *status) Detail() string {
+	return fmt.Sprintf(`%d out of %d approvals
+
+::group::Counted approvals
+%s
+::endgroup::
+
+::group::Stale approvals
+%s
+::endgroup::
+
+::group::Changes requested
+%s
+::endgroup::
+
+::group::Missing required reviewers
+%s
+::endgroup::
+
+::group::Missing required teams
+%s
+::endgroup::
+`,
+		len(s.approvedBy),
+		s.minApprovals,
+		prettyPrintReviewerList(s.approvedBy),
+		prettyPrintReviewerList(s.staleBy),
+		prettyPrintReviewerList(s.changesRequestedBy),
+		prettyPrintReviewerList(s.missingReviewers),
+		prettyPrintReviewerList(s.missingTeams),
+	)
+}
+
+func (s *status) IsSuccess() bool {
+	return s.succeeded
+}
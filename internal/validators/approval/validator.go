@@ -0,0 +1,237 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const (
+	defaultName = "approvals"
+
+	maxReviewsPerPage     = 100
+	maxTeamMembersPerPage = 100
+)
+
+// Review states, which are described in https://docs.github.com/en/rest/pulls/reviews.
+const (
+	approvedState         = "APPROVED"
+	changesRequestedState = "CHANGES_REQUESTED"
+	dismissedState        = "DISMISSED"
+)
+
+type approvalValidator struct {
+	name         string
+	owner        string
+	repo         string
+	prNumber     int
+	minApprovals int
+	client       github.Client
+
+	requiredReviewers []string
+	requiredTeams     []string
+
+	// teamMembers caches the members of the required teams, as they rarely change while waiting.
+	teamMembers map[string][]string
+}
+
+// CreateValidator creates the validator of the approving reviews. It succeeds when the head commit of the pull request
+// has at least the minimum number of approvals, including the ones of the required reviewers and teams.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	av := &approvalValidator{
+		name:   defaultName,
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(av)
+	}
+	if err := av.validateFields(); err != nil {
+		return nil, err
+	}
+	return av, nil
+}
+
+func (av *approvalValidator) Name() string {
+	return av.name
+}
+
+func (av *approvalValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(av.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(av.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if av.prNumber == 0 {
+		errs = append(errs, errors.New("pull request number is empty"))
+	}
+	if av.minApprovals < 0 {
+		errs = append(errs, fmt.Errorf("minimum number of approvals must not be negative: %d", av.minApprovals))
+	}
+	if av.minApprovals == 0 && len(av.requiredReviewers) == 0 && len(av.requiredTeams) == 0 {
+		errs = append(errs, errors.New("neither minimum number of approvals nor required reviewers or teams are set"))
+	}
+	for _, team := range av.requiredTeams {
+		if _, _, err := av.teamOrgAndSlug(team); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs.Categorize(multierror.CategoryConfig)
+	}
+	return nil
+}
+
+// Validate counts the approvals on every poll. Only the latest review of each reviewer counts, so that dismissed
+// approvals and approvals superseded by requested changes are excluded. Approvals of older commits are stale, and
+// thus excluded as well. Missing approvals do not fail the validation, as they are expected to be given while waiting.
+func (av *approvalValidator) Validate(ctx context.Context) (validators.Status, error) {
+	pr, _, err := av.client.GetPullRequest(ctx, av.owner, av.repo, av.prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request #%d: %w", av.prNumber, err)
+	}
+	head := pr.GetHead().GetSHA()
+
+	reviews, err := av.listReviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reviews are listed in chronological order, so the later ones override the earlier ones of the same reviewer.
+	// Comments neither approve nor withdraw an approval.
+	latest := make(map[string]*github.PullRequestReview)
+	for _, review := range reviews {
+		switch review.GetState() {
+		case approvedState, changesRequestedState, dismissedState:
+			latest[review.GetUser().GetLogin()] = review
+		}
+	}
+
+	st := &status{
+		minApprovals:       av.minApprovals,
+		approvedBy:         []string{},
+		staleBy:            []string{},
+		changesRequestedBy: []string{},
+		missingReviewers:   []string{},
+		missingTeams:       []string{},
+	}
+	for reviewer, review := range latest {
+		switch {
+		case review.GetState() == changesRequestedState:
+			st.changesRequestedBy = append(st.changesRequestedBy, reviewer)
+		case review.GetState() != approvedState:
+		case review.GetCommitID() != head:
+			st.staleBy = append(st.staleBy, reviewer)
+		default:
+			st.approvedBy = append(st.approvedBy, reviewer)
+		}
+	}
+	sort.Strings(st.approvedBy)
+	sort.Strings(st.staleBy)
+	sort.Strings(st.changesRequestedBy)
+
+	// A required reviewer who has requested changes after approving is missing, as only the latest review counts.
+	for _, reviewer := range av.requiredReviewers {
+		if !containsLogin(st.approvedBy, reviewer) {
+			st.missingReviewers = append(st.missingReviewers, reviewer)
+		}
+	}
+	for _, team := range av.requiredTeams {
+		members, err := av.listTeamMembers(ctx, team)
+		if err != nil {
+			return nil, err
+		}
+		if !anyApproved(st.approvedBy, members) {
+			st.missingTeams = append(st.missingTeams, team)
+		}
+	}
+
+	st.succeeded = len(st.approvedBy) >= av.minApprovals && len(st.missingReviewers) == 0 && len(st.missingTeams) == 0
+	return st, nil
+}
+
+// teamOrgAndSlug splits the team into the organization and the slug. The organization defaults to the owner.
+func (av *approvalValidator) teamOrgAndSlug(team string) (string, string, error) {
+	org, slug := av.owner, team
+	if i := strings.Index(team, "/"); i >= 0 {
+		org, slug = team[:i], team[i+1:]
+	}
+	if len(org) == 0 || len(slug) == 0 || strings.Contains(slug, "/") {
+		return "", "", fmt.Errorf("invalid team %q, must be either org/team-slug or team-slug", team)
+	}
+	return org, slug, nil
+}
+
+func (av *approvalValidator) listTeamMembers(ctx context.Context, team string) ([]string, error) {
+	if members, ok := av.teamMembers[team]; ok {
+		return members, nil
+	}
+
+	org, slug, err := av.teamOrgAndSlug(team)
+	if err != nil {
+		return nil, err
+	}
+	var members []string
+	for page := 1; ; page++ {
+		users, _, err := av.client.ListTeamMembersBySlug(ctx, org, slug, &github.TeamListTeamMembersOptions{
+			ListOptions: github.ListOptions{PerPage: maxTeamMembersPerPage, Page: page},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members of team %s: %w", team, err)
+		}
+		for _, u := range users {
+			members = append(members, u.GetLogin())
+		}
+		if len(users) < maxTeamMembersPerPage {
+			break
+		}
+	}
+
+	if av.teamMembers == nil {
+		av.teamMembers = make(map[string][]string)
+	}
+	av.teamMembers[team] = members
+	return members, nil
+}
+
+// containsLogin reports whether the login is in the logins. Logins are compared case-insensitively as GitHub does.
+func containsLogin(logins []string, login string) bool {
+	for _, l := range logins {
+		if strings.EqualFold(l, login) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyApproved(approvedBy, members []string) bool {
+	for _, member := range members {
+		if containsLogin(approvedBy, member) {
+			return true
+		}
+	}
+	return false
+}
+
+func (av *approvalValidator) listReviews(ctx context.Context) ([]*github.PullRequestReview, error) {
+	var reviews []*github.PullRequestReview
+	for page := 1; ; page++ {
+		rs, _, err := av.client.ListReviews(ctx, av.owner, av.repo, av.prNumber, &github.ListOptions{PerPage: maxReviewsPerPage, Page: page})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reviews of pull request #%d: %w", av.prNumber, err)
+		}
+		reviews = append(reviews, rs...)
+		if len(rs) < maxReviewsPerPage {
+			return reviews, nil
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func review(login, state, commitID string) *github.PullRequestReview {
+	return &github.PullRequestReview{
+		User:     &github.User{Login: stringPtr(login)},
+		State:    stringPtr(state),
+		CommitID: stringPtr(commitID),
+	}
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		want    validators.Validator
+		wantErr bool
+	}{
+		"returns Validator when option is not empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+				WithMinApprovals(2),
+			},
+			want: &approvalValidator{
+				name:         defaultName,
+				owner:        "test",
+				repo:         "test-repo",
+				prNumber:     1,
+				minApprovals: 2,
+				client:       &mock.Client{},
+			},
+			wantErr: false,
+		},
+		"returns Validator when only required reviewers and teams are set": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+				WithRequiredReviewers("alice, bob"),
+				WithRequiredTeams("security, other-org/release"),
+			},
+			want: &approvalValidator{
+				name:              defaultName,
+				owner:             "test",
+				repo:              "test-repo",
+				prNumber:          1,
+				client:            &mock.Client{},
+				requiredReviewers: []string{"alice", "bob"},
+				requiredTeams:     []string{"security", "other-org/release"},
+			},
+			wantErr: false,
+		},
+		"returns error when minimum number of approvals is negative": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+				WithMinApprovals(-1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when no approval is required": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+				WithMinApprovals(0),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when required team is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+				WithRequiredTeams("org/"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when option is empty": {
+			c:       &mock.Client{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := CreateValidator(tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateValidator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_approvalValidator_Validate(t *testing.T) {
+	getPullRequest := func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+		return &github.PullRequest{Head: &github.PullRequestBranch{SHA: stringPtr("head")}}, nil, nil
+	}
+	listReviews := func(reviews ...*github.PullRequestReview) func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+		return func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+			return reviews, nil, nil
+		}
+	}
+
+	tests := map[string]struct {
+		client     github.Client
+		wantErr    bool
+		wantStatus validators.Status
+	}{
+		"returns succeeded status when the approvals reach the minimum": {
+			client: &mock.Client{
+				GetPullRequestFunc: getPullRequest,
+				ListReviewsFunc: listReviews(
+					review("bob", approvedState, "head"),
+					review("alice", approvedState, "head"),
+					review("carol", "COMMENTED", "head"),
+				),
+			},
+			wantStatus: &status{
+				minApprovals:       2,
+				approvedBy:         []string{"alice", "bob"},
+				staleBy:            []string{},
+				changesRequestedBy: []string{},
+				missingReviewers:   []string{},
+				missingTeams:       []string{},
+				succeeded:          true,
+			},
+		},
+		"returns pending status excluding dismissed, superseded and stale approvals": {
+			client: &mock.Client{
+				GetPullRequestFunc: getPullRequest,
+				ListReviewsFunc: listReviews(
+					review("alice", approvedState, "head"),
+					review("bob", approvedState, "head"),
+					review("bob", changesRequestedState, "head"),
+					review("carol", dismissedState, "head"),
+					review("dave", approvedState, "old"),
+				),
+			},
+			wantStatus: &status{
+				minApprovals:       2,
+				approvedBy:         []string{"alice"},
+				staleBy:            []string{"dave"},
+				changesRequestedBy: []string{"bob"},
+				missingReviewers:   []string{},
+				missingTeams:       []string{},
+				succeeded:          false,
+			},
+		},
+		"returns succeeded status when an approval is given again after requesting changes": {
+			client: &mock.Client{
+				GetPullRequestFunc: getPullRequest,
+				ListReviewsFunc: listReviews(
+					review("alice", approvedState, "head"),
+					review("bob", changesRequestedState, "old"),
+					review("bob", "COMMENTED", "head"),
+					review("bob", approvedState, "head"),
+				),
+			},
+			wantStatus: &status{
+				minApprovals:       2,
+				approvedBy:         []string{"alice", "bob"},
+				staleBy:            []string{},
+				changesRequestedBy: []string{},
+				missingReviewers:   []string{},
+				missingTeams:       []string{},
+				succeeded:          true,
+			},
+		},
+		"returns error when the reviews cannot be listed": {
+			client: &mock.Client{
+				GetPullRequestFunc: getPullRequest,
+				ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		"returns error when the pull request cannot be fetched": {
+			client: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			av := &approvalValidator{
+				name:         defaultName,
+				owner:        "test-owner",
+				repo:         "test-repo",
+				prNumber:     1,
+				minApprovals: 2,
+				client:       tt.client,
+			}
+			got, err := av.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("approvalValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("approvalValidator.Validate() = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_approvalValidator_Validate_requiredApprovers(t *testing.T) {
+	var teamCalls int
+	client := func(reviews ...*github.PullRequestReview) *mock.Client {
+		return &mock.Client{
+			GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+				return &github.PullRequest{Head: &github.PullRequestBranch{SHA: stringPtr("head")}}, nil, nil
+			},
+			ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+				return reviews, nil, nil
+			},
+			ListTeamMembersBySlugFunc: func(ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
+				teamCalls++
+				if org != "test-owner" || slug != "security" {
+					return nil, nil, fmt.Errorf("unexpected team %s/%s", org, slug)
+				}
+				return []*github.User{{Login: stringPtr("carol")}, {Login: stringPtr("dave")}}, nil, nil
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		client           github.Client
+		wantSucceeded    bool
+		wantMissing      []string
+		wantMissingTeams []string
+	}{
+		"returns succeeded status when the required reviewer and a team member have approved": {
+			client: client(
+				review("Alice", approvedState, "head"),
+				review("dave", approvedState, "head"),
+			),
+			wantSucceeded:    true,
+			wantMissing:      []string{},
+			wantMissingTeams: []string{},
+		},
+		"returns pending status when no team member has approved": {
+			client: client(
+				review("alice", approvedState, "head"),
+				review("erin", approvedState, "head"),
+			),
+			wantSucceeded:    false,
+			wantMissing:      []string{},
+			wantMissingTeams: []string{"security"},
+		},
+		"returns pending status when the required reviewer has requested changes after approving": {
+			client: client(
+				review("alice", approvedState, "head"),
+				review("carol", approvedState, "head"),
+				review("alice", changesRequestedState, "head"),
+			),
+			wantSucceeded:    false,
+			wantMissing:      []string{"alice"},
+			wantMissingTeams: []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			teamCalls = 0
+			av := &approvalValidator{
+				name:              defaultName,
+				owner:             "test-owner",
+				repo:              "test-repo",
+				prNumber:          1,
+				client:            tt.client,
+				requiredReviewers: []string{"alice"},
+				requiredTeams:     []string{"security"},
+			}
+			for i := 0; i < 2; i++ {
+				got, err := av.Validate(context.Background())
+				if err != nil {
+					t.Fatalf("approvalValidator.Validate() unexpected error: %v", err)
+				}
+				st := got.(*status)
+				if st.IsSuccess() != tt.wantSucceeded {
+					t.Errorf("approvalValidator.Validate() IsSuccess() = %v, want %v", st.IsSuccess(), tt.wantSucceeded)
+				}
+				if !reflect.DeepEqual(st.missingReviewers, tt.wantMissing) {
+					t.Errorf("approvalValidator.Validate() missing reviewers = %v, want %v", st.missingReviewers, tt.wantMissing)
+				}
+				if !reflect.DeepEqual(st.missingTeams, tt.wantMissingTeams) {
+					t.Errorf("approvalValidator.Validate() missing teams = %v, want %v", st.missingTeams, tt.wantMissingTeams)
+				}
+			}
+			if teamCalls != 1 {
+				t.Errorf("team members are listed %d times, want 1", teamCalls)
+			}
+		})
+	}
+}
+
+func Test_approvalValidator_listReviews(t *testing.T) {
+	var pages []int
+	av := &approvalValidator{
+		owner:    "test-owner",
+		repo:     "test-repo",
+		prNumber: 1,
+		client: &mock.Client{
+			ListReviewsFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+				pages = append(pages, opts.Page)
+				n := maxReviewsPerPage
+				if opts.Page == 2 {
+					n = 1
+				}
+				reviews := make([]*github.PullRequestReview, n)
+				for i := range reviews {
+					reviews[i] = review(fmt.Sprintf("user-%d-%d", opts.Page, i), approvedState, "head")
+				}
+				return reviews, nil, nil
+			},
+		},
+	}
+	got, err := av.listReviews(context.Background())
+	if err != nil {
+		t.Fatalf("approvalValidator.listReviews() unexpected error: %v", err)
+	}
+	if len(got) != maxReviewsPerPage+1 {
+		t.Errorf("approvalValidator.listReviews() returned %d reviews, want %d", len(got), maxReviewsPerPage+1)
+	}
+	if !reflect.DeepEqual(pages, []int{1, 2}) {
+		t.Errorf("approvalValidator.listReviews() fetched pages %v, want [1 2]", pages)
+	}
+}
@@ -0,0 +1,136 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+)
+
+// Logic is how the results of the validators are combined by CompositeValidator.
+type Logic string
+
+const (
+	// LogicAnd succeeds only when all the validators succeed, and fails when any of them fails.
+	LogicAnd Logic = "and"
+	// LogicOr succeeds when any of the validators succeeds, and fails only when all of them fail.
+	LogicOr Logic = "or"
+)
+
+func (l Logic) validate() error {
+	switch l {
+	case LogicAnd, LogicOr:
+		return nil
+	default:
+		return fmt.Errorf("invalid logic %q, must be either %q or %q", l, LogicAnd, LogicOr)
+	}
+}
+
+// CompositeValidator runs several validators, and combines their results with the logic.
+type CompositeValidator struct {
+	name       string
+	logic      Logic
+	validators []Validator
+}
+
+var _ Validator = (*CompositeValidator)(nil)
+
+// NewCompositeValidator creates the validator combining the results of the given validators with the logic.
+func NewCompositeValidator(name string, logic Logic, vs ...Validator) (*CompositeValidator, error) {
+	if len(name) == 0 {
+		return nil, errors.New("composite validator name is empty")
+	}
+	if err := logic.validate(); err != nil {
+		return nil, err
+	}
+	if len(vs) == 0 {
+		return nil, errors.New("composite validator has no validator")
+	}
+	return &CompositeValidator{
+		name:       name,
+		logic:      logic,
+		validators: vs,
+	}, nil
+}
+
+func (cv *CompositeValidator) Name() string {
+	return cv.name
+}
+
+// Validate runs all the validators, so that the aggregated status describes every one of them. A validator returning
+// an error has failed, which fails the composite with LogicAnd, and only when all of them fail with LogicOr.
+func (cv *CompositeValidator) Validate(ctx context.Context) (Status, error) {
+	st := &compositeStatus{
+		results: make([]compositeResult, 0, len(cv.validators)),
+	}
+	var errs multierror.Errors
+	var successCnt int
+	for _, v := range cv.validators {
+		vst, err := v.Validate(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", v.Name(), err))
+			st.results = append(st.results, compositeResult{name: v.Name(), err: err})
+			continue
+		}
+		if vst.IsSuccess() {
+			successCnt++
+		}
+		st.results = append(st.results, compositeResult{name: v.Name(), status: vst})
+	}
+
+	switch cv.logic {
+	case LogicOr:
+		if successCnt == 0 && len(errs) == len(cv.validators) {
+			return nil, errs
+		}
+		st.succeeded = successCnt != 0
+	default:
+		if len(errs) != 0 {
+			return nil, errs
+		}
+		st.succeeded = successCnt == len(cv.validators)
+	}
+	return st, nil
+}
+
+type compositeResult struct {
+	name   string
+	status Status
+	err    error
+}
+
+type compositeStatus struct {
+	results   []compositeResult
+	succeeded bool
+}
+
+var _ Status = (*compositeStatus)(nil)
+
+func (s *compositeStatus) Detail() string {
+	details := make([]string, 0, len(s.results))
+	for _, r := range s.results {
+		if r.err != nil {
+			details = append(details, fmt.Sprintf("Validator %s failed: %v", r.name, r.err))
+			continue
+		}
+		details = append(details, fmt.Sprintf("Validator %s:\n%s", r.name, r.status.Detail()))
+	}
+	return strings.Join(details, "\n\n")
+}
+
+func (s *compositeStatus) IsSuccess() bool {
+	return s.succeeded
+}
+
+// Unchanged reports whether nothing has changed for all the validators reporting it since the previous poll.
+func (s *compositeStatus) Unchanged() bool {
+	for _, r := range s.results {
+		u, ok := r.status.(interface{ Unchanged() bool })
+		if !ok || !u.Unchanged() {
+			return false
+		}
+	}
+	return true
+}
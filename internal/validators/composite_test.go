@@ -0,0 +1,152 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeStatus struct {
+	detail    string
+	succeeded bool
+}
+
+func (s *fakeStatus) Detail() string  { return s.detail }
+func (s *fakeStatus) IsSuccess() bool { return s.succeeded }
+
+type fakeValidator struct {
+	name   string
+	status *fakeStatus
+	err    error
+}
+
+func (v *fakeValidator) Name() string { return v.name }
+
+func (v *fakeValidator) Validate(ctx context.Context) (Status, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return v.status, nil
+}
+
+func succeeding(name string) Validator {
+	return &fakeValidator{name: name, status: &fakeStatus{detail: name + " succeeded", succeeded: true}}
+}
+
+func pending(name string) Validator {
+	return &fakeValidator{name: name, status: &fakeStatus{detail: name + " pending"}}
+}
+
+func failing(name string) Validator {
+	return &fakeValidator{name: name, err: errors.New(name + " failed")}
+}
+
+func TestNewCompositeValidator(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		logic   Logic
+		vs      []Validator
+		wantErr bool
+	}{
+		"returns validator when the fields are valid": {
+			name:  "composite",
+			logic: LogicAnd,
+			vs:    []Validator{succeeding("status")},
+		},
+		"returns error when the name is empty": {
+			logic:   LogicAnd,
+			vs:      []Validator{succeeding("status")},
+			wantErr: true,
+		},
+		"returns error when the logic is invalid": {
+			name:    "composite",
+			logic:   Logic("xor"),
+			vs:      []Validator{succeeding("status")},
+			wantErr: true,
+		},
+		"returns error when no validator is given": {
+			name:    "composite",
+			logic:   LogicOr,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewCompositeValidator(tt.name, tt.logic, tt.vs...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCompositeValidator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Name() != tt.name {
+				t.Errorf("NewCompositeValidator() name = %s, want %s", got.Name(), tt.name)
+			}
+		})
+	}
+}
+
+func TestCompositeValidator_Validate(t *testing.T) {
+	tests := map[string]struct {
+		logic         Logic
+		vs            []Validator
+		wantErr       bool
+		wantSucceeded bool
+		wantDetail    []string
+	}{
+		"and: succeeds when all validators succeed": {
+			logic:         LogicAnd,
+			vs:            []Validator{succeeding("status"), succeeding("approval")},
+			wantSucceeded: true,
+			wantDetail:    []string{"Validator status:\nstatus succeeded", "Validator approval:\napproval succeeded"},
+		},
+		"and: keeps pending when any validator is pending": {
+			logic:         LogicAnd,
+			vs:            []Validator{succeeding("status"), pending("approval")},
+			wantSucceeded: false,
+			wantDetail:    []string{"Validator approval:\napproval pending"},
+		},
+		"and: returns error when any validator fails": {
+			logic:   LogicAnd,
+			vs:      []Validator{succeeding("status"), failing("approval")},
+			wantErr: true,
+		},
+		"or: succeeds when any validator succeeds": {
+			logic:         LogicOr,
+			vs:            []Validator{failing("status"), pending("approval"), succeeding("override")},
+			wantSucceeded: true,
+			wantDetail:    []string{"Validator status failed: status failed", "Validator override:\noverride succeeded"},
+		},
+		"or: keeps pending when no validator succeeds but some are pending": {
+			logic:         LogicOr,
+			vs:            []Validator{failing("status"), pending("approval")},
+			wantSucceeded: false,
+		},
+		"or: returns error when all validators fail": {
+			logic:   LogicOr,
+			vs:      []Validator{failing("status"), failing("approval")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cv, err := NewCompositeValidator("composite", tt.logic, tt.vs...)
+			if err != nil {
+				t.Fatalf("NewCompositeValidator() unexpected error: %v", err)
+			}
+			got, err := cv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompositeValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.IsSuccess() != tt.wantSucceeded {
+				t.Errorf("CompositeValidator.Validate() IsSuccess() = %v, want %v", got.IsSuccess(), tt.wantSucceeded)
+			}
+			for _, want := range tt.wantDetail {
+				if !strings.Contains(got.Detail(), want) {
+					t.Errorf("CompositeValidator.Validate() detail does not contain %q:\n%s", want, got.Detail())
+				}
+			}
+		})
+	}
+}
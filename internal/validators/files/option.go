@@ -0,0 +1,68 @@
+package files
+
+import "strings"
+
+type Option func(f *filesValidator)
+
+// WithName sets the name of the validator, which is used in the logs.
+func WithName(name string) Option {
+	return func(f *filesValidator) {
+		if len(name) != 0 {
+			f.name = name
+		}
+	}
+}
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(f *filesValidator) {
+		if len(owner) != 0 {
+			f.owner = owner
+		}
+		if len(repo) != 0 {
+			f.repo = repo
+		}
+	}
+}
+
+// WithPullRequest sets the pull request number, whose changed files are validated.
+func WithPullRequest(number int) Option {
+	return func(f *filesValidator) {
+		if number > 0 {
+			f.prNumber = number
+		}
+	}
+}
+
+// WithRequiredPaths sets the paths which the pull request must change, e.g. "CHANGELOG.md". Glob patterns such as
+// "docs/*.md" are supported, and a path ending with a slash such as "migrations/" matches any file under it.
+func WithRequiredPaths(paths string) Option {
+	return func(f *filesValidator) {
+		if len(paths) == 0 {
+			return
+		}
+		f.requiredPaths = splitPaths(paths)
+	}
+}
+
+// WithForbiddenPaths sets the paths which the pull request must not change, e.g. "vendor/". The patterns are the
+// same as the required paths.
+func WithForbiddenPaths(paths string) Option {
+	return func(f *filesValidator) {
+		if len(paths) == 0 {
+			return
+		}
+		f.forbiddenPaths = splitPaths(paths)
+	}
+}
+
+func splitPaths(paths string) []string {
+	result := []string{}
+	for _, s := range strings.Split(paths, ",") {
+		p := strings.TrimSpace(s)
+		if len(p) == 0 {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
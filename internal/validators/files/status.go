@@ -0,0 +1,49 @@
+package files
+
+import "fmt"
+
+type status struct {
+	files          []string
+	missingPaths   []string
+	forbiddenFiles []string
+	succeeded      bool
+}
+
+func prettyPrintList(items []string) string {
+	result := ""
+	if len(items) == 0 {
+		result = "[]"
+	}
+	for i, item := range items {
+		result += fmt.Sprintf("- %s", item)
+		if i != len(items)-1 {
+			result += "\n"
+		}
+	}
+	return result
+}
+
+func (s *status) Detail() string {
+	return fmt.Sprintf(`Changed file count:          %d
+Missing required path count: %d
+Forbidden file count:        %d
+
+::group::Missing required paths
+%s
+::endgroup::
+
+::group::Forbidden files
+%s
+::endgroup::
+`,
+		len(s.files),
+		len(s.missingPaths),
+		len(s.forbiddenFiles),
+		prettyPrintList(s.missingPaths),
+		prettyPrintList(s.forbiddenFiles),
+	)
+}
+
+func (s *status) IsSuccess() bool {
+	return s.succeeded
+}
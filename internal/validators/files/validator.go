@@ -0,0 +1,144 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const defaultName = "files"
+
+const maxFilesPerPage = 100
+
+type filesValidator struct {
+	name     string
+	owner    string
+	repo     string
+	prNumber int
+	client   github.Client
+
+	requiredPaths  []string
+	forbiddenPaths []string
+}
+
+// CreateValidator creates the validator of the files changed by the pull request. It succeeds when the pull request
+// changes a file matching each of the required paths, and none matching the forbidden paths.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	fv := &filesValidator{
+		name:   defaultName,
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(fv)
+	}
+	if err := fv.validateFields(); err != nil {
+		return nil, err
+	}
+	return fv, nil
+}
+
+func (fv *filesValidator) Name() string {
+	return fv.name
+}
+
+func (fv *filesValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(fv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(fv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if fv.prNumber == 0 {
+		errs = append(errs, errors.New("pull request number is empty"))
+	}
+	if len(fv.requiredPaths) == 0 && len(fv.forbiddenPaths) == 0 {
+		errs = append(errs, errors.New("neither required nor forbidden paths are set"))
+	}
+	for _, p := range append(append([]string{}, fv.requiredPaths...), fv.forbiddenPaths...) {
+		if _, err := path.Match(p, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid path pattern %q: %w", p, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs.Categorize(multierror.CategoryConfig)
+	}
+	return nil
+}
+
+// Validate lists the files changed by the pull request on every poll. A missing required path or a forbidden file
+// does not fail the validation, as the files are expected to be changed by another push while waiting.
+func (fv *filesValidator) Validate(ctx context.Context) (validators.Status, error) {
+	files, err := fv.listFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &status{
+		files:          files,
+		missingPaths:   []string{},
+		forbiddenFiles: []string{},
+	}
+	for _, required := range fv.requiredPaths {
+		if !containsMatch(files, required) {
+			st.missingPaths = append(st.missingPaths, required)
+		}
+	}
+	for _, file := range files {
+		for _, forbidden := range fv.forbiddenPaths {
+			if matchPath(forbidden, file) {
+				st.forbiddenFiles = append(st.forbiddenFiles, file)
+				break
+			}
+		}
+	}
+	st.succeeded = len(st.missingPaths) == 0 && len(st.forbiddenFiles) == 0
+	return st, nil
+}
+
+// listFiles returns the paths of all the files changed by the pull request. The files renamed by the pull request are
+// listed by both the new and the previous paths, as either is changed.
+func (fv *filesValidator) listFiles(ctx context.Context) ([]string, error) {
+	files := []string{}
+	for page := 1; ; page++ {
+		changed, _, err := fv.client.ListPullRequestFiles(ctx, fv.owner, fv.repo, fv.prNumber, &github.ListOptions{PerPage: maxFilesPerPage, Page: page})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of pull request #%d: %w", fv.prNumber, err)
+		}
+		for _, f := range changed {
+			files = append(files, f.GetFilename())
+			if prev := f.GetPreviousFilename(); len(prev) != 0 {
+				files = append(files, prev)
+			}
+		}
+		if len(changed) < maxFilesPerPage {
+			return files, nil
+		}
+	}
+}
+
+func containsMatch(files []string, pattern string) bool {
+	for _, file := range files {
+		if matchPath(pattern, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath reports whether the file matches the pattern, which matches any file under it when it ends with a slash.
+func matchPath(pattern, file string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(file, pattern)
+	}
+	ok, _ := path.Match(pattern, file)
+	return ok
+}
@@ -0,0 +1,210 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		want    validators.Validator
+		wantErr bool
+	}{
+		"returns Validator when option is not empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+				WithRequiredPaths("CHANGELOG.md"),
+				WithForbiddenPaths("vendor/, *.pem"),
+			},
+			want: &filesValidator{
+				name:           defaultName,
+				owner:          "test",
+				repo:           "test-repo",
+				prNumber:       1,
+				client:         &mock.Client{},
+				requiredPaths:  []string{"CHANGELOG.md"},
+				forbiddenPaths: []string{"vendor/", "*.pem"},
+			},
+			wantErr: false,
+		},
+		"returns error when pull request is not set": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithRequiredPaths("CHANGELOG.md"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when no path is set": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when path pattern is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+				WithRequiredPaths("CHANGELOG[.md"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when option is empty": {
+			c:       &mock.Client{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := CreateValidator(tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateValidator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_filesValidator_Validate(t *testing.T) {
+	prWithFiles := func(files ...*github.CommitFile) *mock.Client {
+		return &mock.Client{
+			ListPullRequestFilesFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+				return files, nil, nil
+			},
+		}
+	}
+	file := func(name string) *github.CommitFile {
+		return &github.CommitFile{Filename: stringPtr(name)}
+	}
+
+	tests := map[string]struct {
+		client     github.Client
+		wantErr    bool
+		wantStatus validators.Status
+	}{
+		"returns succeeded status when the required path is changed and no forbidden path is changed": {
+			client: prWithFiles(file("CHANGELOG.md"), file("internal/cli/validate.go")),
+			wantStatus: &status{
+				files:          []string{"CHANGELOG.md", "internal/cli/validate.go"},
+				missingPaths:   []string{},
+				forbiddenFiles: []string{},
+				succeeded:      true,
+			},
+		},
+		"returns pending status with the missing required path": {
+			client: prWithFiles(file("internal/cli/validate.go")),
+			wantStatus: &status{
+				files:          []string{"internal/cli/validate.go"},
+				missingPaths:   []string{"CHANGELOG.md"},
+				forbiddenFiles: []string{},
+				succeeded:      false,
+			},
+		},
+		"returns pending status with the files under the forbidden directory": {
+			client: prWithFiles(file("CHANGELOG.md"), file("vendor/github.com/foo/foo.go"), file("vendor.go")),
+			wantStatus: &status{
+				files:          []string{"CHANGELOG.md", "vendor/github.com/foo/foo.go", "vendor.go"},
+				missingPaths:   []string{},
+				forbiddenFiles: []string{"vendor/github.com/foo/foo.go"},
+				succeeded:      false,
+			},
+		},
+		"returns pending status when the forbidden file is renamed": {
+			client: prWithFiles(file("CHANGELOG.md"), &github.CommitFile{Filename: stringPtr("certs/server.key"), PreviousFilename: stringPtr("server.pem")}),
+			wantStatus: &status{
+				files:          []string{"CHANGELOG.md", "certs/server.key", "server.pem"},
+				missingPaths:   []string{},
+				forbiddenFiles: []string{"server.pem"},
+				succeeded:      false,
+			},
+		},
+		"returns error when the files cannot be listed": {
+			client: &mock.Client{
+				ListPullRequestFilesFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fv := &filesValidator{
+				name:           defaultName,
+				owner:          "test-owner",
+				repo:           "test-repo",
+				prNumber:       1,
+				client:         tt.client,
+				requiredPaths:  []string{"CHANGELOG.md"},
+				forbiddenPaths: []string{"vendor/", "*.pem"},
+			}
+			got, err := fv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filesValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("filesValidator.Validate() = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_filesValidator_Validate_pages(t *testing.T) {
+	c := &mock.Client{
+		ListPullRequestFilesFunc: func(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+			if opts.Page == 2 {
+				return []*github.CommitFile{{Filename: stringPtr("CHANGELOG.md")}}, nil, nil
+			}
+			files := make([]*github.CommitFile, maxFilesPerPage)
+			for i := range files {
+				files[i] = &github.CommitFile{Filename: stringPtr(fmt.Sprintf("file-%d.go", i))}
+			}
+			return files, nil, nil
+		},
+	}
+	fv := &filesValidator{prNumber: 1, client: c, requiredPaths: []string{"CHANGELOG.md"}}
+	got, err := fv.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("filesValidator.Validate() error = %v", err)
+	}
+	if !got.IsSuccess() {
+		t.Errorf("filesValidator.Validate() = %v, want the required path found on the second page", got)
+	}
+}
+
+func Test_status_Detail(t *testing.T) {
+	st := &status{
+		files:          []string{"main.go"},
+		missingPaths:   []string{"CHANGELOG.md"},
+		forbiddenFiles: []string{},
+	}
+	if detail := st.Detail(); !strings.Contains(detail, "::group::Missing required paths\n- CHANGELOG.md\n::endgroup::") {
+		t.Errorf("status.Detail() = %s, want the missing required path", detail)
+	}
+}
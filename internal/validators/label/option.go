@@ -0,0 +1,66 @@
+package label
+
+import "strings"
+
+type Option func(l *labelValidator)
+
+// WithName sets the name of the validator, which is used in the logs.
+func WithName(name string) Option {
+	return func(l *labelValidator) {
+		if len(name) != 0 {
+			l.name = name
+		}
+	}
+}
+
+func WithGitHubOwnerAndRepo(owner, repo string) Option {
+	return func(l *labelValidator) {
+		if len(owner) != 0 {
+			l.owner = owner
+		}
+		if len(repo) != 0 {
+			l.repo = repo
+		}
+	}
+}
+
+// WithPullRequest sets the pull request number, whose labels are validated.
+func WithPullRequest(number int) Option {
+	return func(l *labelValidator) {
+		if number > 0 {
+			l.prNumber = number
+		}
+	}
+}
+
+// WithRequiredLabels sets the labels which the pull request must have, e.g. "ready-to-merge".
+func WithRequiredLabels(names string) Option {
+	return func(l *labelValidator) {
+		if len(names) == 0 {
+			return
+		}
+		l.requiredLabels = splitLabelNames(names)
+	}
+}
+
+// WithForbiddenLabels sets the labels which the pull request must not have, e.g. "do-not-merge".
+func WithForbiddenLabels(names string) Option {
+	return func(l *labelValidator) {
+		if len(names) == 0 {
+			return
+		}
+		l.forbiddenLabels = splitLabelNames(names)
+	}
+}
+
+func splitLabelNames(names string) []string {
+	labels := []string{}
+	for _, s := range strings.Split(names, ",") {
+		label := strings.TrimSpace(s)
+		if len(label) == 0 {
+			continue
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
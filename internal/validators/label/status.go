@@ -0,0 +1,52 @@
+package label
+
+import "fmt"
+
+type status struct {
+	labels          []string
+	missingLabels   []string
+	forbiddenLabels []string
+	succeeded       bool
+}
+
+func prettyPrintLabelList(labels []string) string {
+	result := ""
+	if len(labels) == 0 {
+		result = "[]"
+	}
+	for i, label := range labels {
+		result += fmt.Sprintf("- %s", label)
+		if i != len(labels)-1 {
+			result += "\n"
+		}
+	}
+	return result
+}
+
+func (s *status) Detail() string {
+	return fmt.Sprintf(`Missing required label count: %d
+Forbidden label count:        %d
+
+::group::Missing required labels
+%s
+::endgroup::
+
+::group::Forbidden labels
+%s
+::endgroup::
+
+::group::All labels
+%s
+::endgroup::
+`,
+		len(s.missingLabels),
+		len(s.forbiddenLabels),
+		prettyPrintLabelList(s.missingLabels),
+		prettyPrintLabelList(s.forbiddenLabels),
+		prettyPrintLabelList(s.labels),
+	)
+}
+
+func (s *status) IsSuccess() bool {
+	return s.succeeded
+}
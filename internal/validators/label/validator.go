@@ -0,0 +1,110 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const defaultName = "labels"
+
+type labelValidator struct {
+	name     string
+	owner    string
+	repo     string
+	prNumber int
+	client   github.Client
+
+	requiredLabels  []string
+	forbiddenLabels []string
+}
+
+// CreateValidator creates the validator of the pull request labels. It succeeds when the pull request has all
+// the required labels and none of the forbidden labels.
+func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
+	lv := &labelValidator{
+		name:   defaultName,
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(lv)
+	}
+	if err := lv.validateFields(); err != nil {
+		return nil, err
+	}
+	return lv, nil
+}
+
+func (lv *labelValidator) Name() string {
+	return lv.name
+}
+
+func (lv *labelValidator) validateFields() error {
+	errs := make(multierror.Errors, 0, 4)
+
+	if len(lv.repo) == 0 {
+		errs = append(errs, errors.New("repository name is empty"))
+	}
+	if len(lv.owner) == 0 {
+		errs = append(errs, errors.New("repository owner is empty"))
+	}
+	if lv.prNumber == 0 {
+		errs = append(errs, errors.New("pull request number is empty"))
+	}
+	if len(lv.requiredLabels) == 0 && len(lv.forbiddenLabels) == 0 {
+		errs = append(errs, errors.New("neither required nor forbidden labels are set"))
+	}
+
+	if len(errs) != 0 {
+		return errs.Categorize(multierror.CategoryConfig)
+	}
+	return nil
+}
+
+// Validate fetches the labels of the pull request on every poll. A missing required label or a forbidden label
+// does not fail the validation, as the labels are expected to be changed while waiting.
+func (lv *labelValidator) Validate(ctx context.Context) (validators.Status, error) {
+	pr, _, err := lv.client.GetPullRequest(ctx, lv.owner, lv.repo, lv.prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request #%d: %w", lv.prNumber, err)
+	}
+
+	st := &status{
+		labels:          []string{},
+		missingLabels:   []string{},
+		forbiddenLabels: []string{},
+	}
+	for _, l := range pr.Labels {
+		st.labels = append(st.labels, l.GetName())
+	}
+	sort.Strings(st.labels)
+
+	for _, required := range lv.requiredLabels {
+		if !containsLabel(st.labels, required) {
+			st.missingLabels = append(st.missingLabels, required)
+		}
+	}
+	for _, forbidden := range lv.forbiddenLabels {
+		if containsLabel(st.labels, forbidden) {
+			st.forbiddenLabels = append(st.forbiddenLabels, forbidden)
+		}
+	}
+	st.succeeded = len(st.missingLabels) == 0 && len(st.forbiddenLabels) == 0
+	return st, nil
+}
+
+// containsLabel reports whether the label is in the labels. Labels are compared case-insensitively as GitHub does.
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+func stringPtr(str string) *string {
+	return &str
+}
+
+func TestCreateValidator(t *testing.T) {
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		want    validators.Validator
+		wantErr bool
+	}{
+		"returns Validator when option is not empty": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+				WithRequiredLabels("ready-to-merge"),
+				WithForbiddenLabels("do-not-merge, wip"),
+			},
+			want: &labelValidator{
+				name:            defaultName,
+				owner:           "test",
+				repo:            "test-repo",
+				prNumber:        1,
+				client:          &mock.Client{},
+				requiredLabels:  []string{"ready-to-merge"},
+				forbiddenLabels: []string{"do-not-merge", "wip"},
+			},
+			wantErr: false,
+		},
+		"returns error when pull request is not set": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithRequiredLabels("ready-to-merge"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when no label is set": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithPullRequest(1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when option is empty": {
+			c:       &mock.Client{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := CreateValidator(tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateValidator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_labelValidator_Validate(t *testing.T) {
+	prWithLabels := func(names ...string) *mock.Client {
+		return &mock.Client{
+			GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+				pr := &github.PullRequest{}
+				for _, name := range names {
+					pr.Labels = append(pr.Labels, &github.Label{Name: stringPtr(name)})
+				}
+				return pr, nil, nil
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		client     github.Client
+		wantErr    bool
+		wantStatus validators.Status
+	}{
+		"returns succeeded status when the required label is set and no forbidden label is set": {
+			client: prWithLabels("Ready-To-Merge", "enhancement"),
+			wantStatus: &status{
+				labels:          []string{"Ready-To-Merge", "enhancement"},
+				missingLabels:   []string{},
+				forbiddenLabels: []string{},
+				succeeded:       true,
+			},
+		},
+		"returns pending status when the required label is missing": {
+			client: prWithLabels("enhancement"),
+			wantStatus: &status{
+				labels:          []string{"enhancement"},
+				missingLabels:   []string{"ready-to-merge"},
+				forbiddenLabels: []string{},
+				succeeded:       false,
+			},
+		},
+		"returns pending status when the forbidden label is set": {
+			client: prWithLabels("ready-to-merge", "do-not-merge"),
+			wantStatus: &status{
+				labels:          []string{"do-not-merge", "ready-to-merge"},
+				missingLabels:   []string{},
+				forbiddenLabels: []string{"do-not-merge"},
+				succeeded:       false,
+			},
+		},
+		"returns error when the pull request cannot be fetched": {
+			client: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			lv := &labelValidator{
+				name:            defaultName,
+				owner:           "test-owner",
+				repo:            "test-repo",
+				prNumber:        1,
+				client:          tt.client,
+				requiredLabels:  []string{"ready-to-merge"},
+				forbiddenLabels: []string{"do-not-merge"},
+			}
+			got, err := lv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("labelValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("labelValidator.Validate() = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
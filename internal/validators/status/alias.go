@@ -0,0 +1,72 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateJobAliases validates the aliases, and maps the old names in all the job lists to the new names, so that
+// the lists written before the jobs are renamed keep working. Patterns are only mapped when they are exact names.
+func (sv *statusValidator) validateJobAliases() error {
+	for old, renamed := range sv.jobAliases {
+		if len(strings.TrimSpace(old)) == 0 || len(strings.TrimSpace(renamed)) == 0 {
+			return fmt.Errorf("invalid job alias %q to %q, both names must not be empty", old, renamed)
+		}
+		if _, ok := sv.jobAliases[renamed]; ok {
+			return fmt.Errorf("invalid job alias %q to %q, the new name must not be aliased again", old, renamed)
+		}
+	}
+	if len(sv.jobAliases) == 0 {
+		return nil
+	}
+
+	sv.ignoredJobs = sv.canonicalJobs(sv.ignoredJobs)
+	sv.onlyJobs = sv.canonicalJobs(sv.onlyJobs)
+	sv.nonBlockingPendingJobs = sv.canonicalJobs(sv.nonBlockingPendingJobs)
+	sv.conditionalJobs = sv.canonicalJobs(sv.conditionalJobs)
+	sv.draftSkippedJobs = sv.canonicalJobs(sv.draftSkippedJobs)
+	sv.requiredJobPatterns = sv.canonicalJobs(sv.requiredJobPatterns)
+	for i := range sv.toleratedConclusions {
+		sv.toleratedConclusions[i].job = sv.canonicalJob(sv.toleratedConclusions[i].job)
+	}
+	for job, timeout := range sv.jobTimeouts {
+		if renamed := sv.canonicalJob(job); renamed != job {
+			delete(sv.jobTimeouts, job)
+			sv.jobTimeouts[renamed] = timeout
+		}
+	}
+	return nil
+}
+
+// isSelfJob reports whether the job is the self job, which may be configured with either of the names. When the
+// matrix is matched, the job of a matrix named after the self job, e.g. "self (ubuntu, 1.16)", is also the self job,
+// as GitHub Actions suffixes the matrix values to the name of each job.
+func (sv *statusValidator) isSelfJob(job string) bool {
+	job, self := sv.canonicalJob(job), sv.canonicalJob(sv.selfJobName)
+	if job == self {
+		return true
+	}
+	if !sv.matrixSelfJob {
+		return false
+	}
+	return strings.HasPrefix(job, self+" (") && strings.HasSuffix(job, ")")
+}
+
+// canonicalJob returns the new name of the job when it has been renamed, and the name as is otherwise.
+func (sv *statusValidator) canonicalJob(job string) string {
+	if renamed, ok := sv.jobAliases[job]; ok {
+		return renamed
+	}
+	return job
+}
+
+func (sv *statusValidator) canonicalJobs(jobs []string) []string {
+	if jobs == nil {
+		return nil
+	}
+	canonical := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		canonical = append(canonical, sv.canonicalJob(job))
+	}
+	return canonical
+}
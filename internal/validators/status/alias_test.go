@@ -0,0 +1,163 @@
+package status
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_jobAliases(t *testing.T) {
+	tests := map[string]struct {
+		opts       []Option
+		statuses   []*github.RepoStatus
+		wantErr    bool
+		wantStatus *status
+	}{
+		"succeeds when the required job is renamed and reported with the new name": {
+			opts: []Option{
+				WithRequiredJobs("test"),
+			},
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("unit-test"), State: stringPtr(successState)},
+			},
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"unit-test"},
+				completeJobs: []string{"unit-test"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+			},
+		},
+		"succeeds when the required job is reported with the old name": {
+			opts: []Option{
+				WithRequiredJobs("unit-test"),
+			},
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("test"), State: stringPtr(successState)},
+			},
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"unit-test"},
+				completeJobs: []string{"unit-test"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+			},
+		},
+		"returns pending status when the renamed required job is not reported yet": {
+			opts: []Option{
+				WithRequiredJobs("test"),
+			},
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("lint"), State: stringPtr(successState)},
+			},
+			wantStatus: &status{
+				succeeded:           false,
+				totalJobs:           []string{"lint", "unit-test"},
+				completeJobs:        []string{"lint"},
+				errJobs:             []string{},
+				ignoredJobs:         []string{},
+				notes:               []string{"Required job unit-test is not reported yet"},
+				missingRequiredJobs: []string{"unit-test"},
+			},
+		},
+		"ignores the renamed job by the old name": {
+			opts: []Option{
+				WithIgnoredJobs("test"),
+			},
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("unit-test"), State: stringPtr(failureState)},
+				{Context: stringPtr("lint"), State: stringPtr(successState)},
+			},
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"lint"},
+				completeJobs: []string{"lint"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{"unit-test"},
+			},
+		},
+		"regards the renamed self job as the self job": {
+			opts: []Option{
+				WithSelfJob("old-gatekeeper"),
+				WithRequireSelfJob(true),
+			},
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("gatekeeper"), State: stringPtr(pendingState)},
+				{Context: stringPtr("lint"), State: stringPtr(successState)},
+			},
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"lint"},
+				completeJobs: []string{"lint"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{Statuses: tt.statuses}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			opts := append([]Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("self-job"),
+				WithJobAliases(map[string]string{
+					"test":           "unit-test",
+					"old-gatekeeper": "gatekeeper",
+				}),
+			}, tt.opts...)
+			v, err := CreateValidator(c, opts...)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			st := got.(*status)
+			st.jobStatuses = nil
+			if !reflect.DeepEqual(st, tt.wantStatus) {
+				t.Errorf("statusValidator.Validate() status = %v, want %v", st, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_canonicalJob(t *testing.T) {
+	sv := &statusValidator{jobAliases: map[string]string{"test": "unit-test"}}
+	tests := map[string]struct {
+		job  string
+		want string
+	}{
+		"returns the new name of the renamed job": {
+			job:  "test",
+			want: "unit-test",
+		},
+		"returns the new name as is": {
+			job:  "unit-test",
+			want: "unit-test",
+		},
+		"returns the name of the job which is not renamed as is": {
+			job:  "lint",
+			want: "lint",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := sv.canonicalJob(tt.job); got != tt.want {
+				t.Errorf("statusValidator.canonicalJob() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
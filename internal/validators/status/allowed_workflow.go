@@ -0,0 +1,71 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// disallowedWorkflowNote returns the note when the check run is posted by a GitHub Actions workflow which is not
+// allowed, in which case the job is ignored so that any other workflow cannot influence the gate. The check runs of
+// the other apps and the commit statuses are not run by any workflow, and thus they are validated regardless.
+func (sv *statusValidator) disallowedWorkflowNote(ctx context.Context, s *ghaStatus) (string, error) {
+	if len(sv.allowedWorkflows) == 0 || s.Source != JobSourceCheckRun || s.App != workflowJobApp || s.CheckRunID == 0 {
+		return "", nil
+	}
+	workflow, err := sv.suiteWorkflowOf(ctx, s.CheckSuiteID, s.CheckRunID, s.Job)
+	if err != nil {
+		return "", err
+	}
+	if containsJob(sv.allowedWorkflows, workflow.name) {
+		return "", nil
+	}
+	return fmt.Sprintf("Check run %s is ignored, as it is run by workflow %s which is not allowed", s.Job, workflow.name), nil
+}
+
+// suiteWorkflow is the workflow run of a check suite of GitHub Actions, each of which is run in its own suite.
+type suiteWorkflow struct {
+	name       string
+	workflowID int64
+	createdAt  time.Time
+}
+
+// suiteWorkflowOf returns the workflow run of the check suite of the check run. The check run of GitHub Actions is
+// the workflow job of the same ID, which tells the workflow run. As all the check runs of a check suite belong to the
+// same workflow run, it is looked up only once for each suite, or once for each check run when its suite is unknown.
+func (sv *statusValidator) suiteWorkflowOf(ctx context.Context, suiteID, checkRunID int64, job string) (suiteWorkflow, error) {
+	cache, key := sv.suiteWorkflows, suiteID
+	if suiteID == 0 {
+		cache, key = sv.runWorkflows, checkRunID
+	}
+	if workflow, ok := cache[key]; ok {
+		return workflow, nil
+	}
+
+	wj, _, err := sv.client.GetWorkflowJobByID(ctx, sv.owner, sv.repo, checkRunID)
+	if err != nil {
+		return suiteWorkflow{}, fmt.Errorf("failed to get workflow job of check run %s: %w", job, err)
+	}
+	run, _, err := sv.client.GetWorkflowRunByID(ctx, sv.owner, sv.repo, wj.GetRunID())
+	if err != nil {
+		return suiteWorkflow{}, fmt.Errorf("failed to get workflow run of check run %s: %w", job, err)
+	}
+	workflow := suiteWorkflow{
+		name:       run.GetName(),
+		workflowID: run.GetWorkflowID(),
+		createdAt:  run.GetCreatedAt().Time,
+	}
+
+	if suiteID == 0 {
+		if sv.runWorkflows == nil {
+			sv.runWorkflows = make(map[int64]suiteWorkflow)
+		}
+		sv.runWorkflows[checkRunID] = workflow
+		return workflow, nil
+	}
+	if sv.suiteWorkflows == nil {
+		sv.suiteWorkflows = make(map[int64]suiteWorkflow)
+	}
+	sv.suiteWorkflows[suiteID] = workflow
+	return workflow, nil
+}
@@ -0,0 +1,181 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func allowedWorkflowClient(jobRequests *int) *mock.Client {
+	actions := &github.App{Slug: stringPtr(workflowJobApp)}
+	ciSuite, rogueSuite := &github.CheckSuite{ID: int64Ptr(1)}, &github.CheckSuite{ID: int64Ptr(2)}
+	runs := map[int64]int64{11: 101, 12: 101, 21: 201}
+	workflows := map[int64]string{101: "CI", 201: "Rogue"}
+	return &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{
+				CheckRuns: []*github.CheckRun{
+					{ID: int64Ptr(11), Name: stringPtr("build"), App: actions, CheckSuite: ciSuite, Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion)},
+					{ID: int64Ptr(12), Name: stringPtr("test"), App: actions, CheckSuite: ciSuite, Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion)},
+					{ID: int64Ptr(21), Name: stringPtr("rogue"), App: actions, CheckSuite: rogueSuite, Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailureConclusion)},
+					{ID: int64Ptr(31), Name: stringPtr("external"), App: &github.App{Slug: stringPtr("external-ci")}, CheckSuite: &github.CheckSuite{ID: int64Ptr(3)}, Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion)},
+				},
+			}, nil, nil
+		},
+		GetWorkflowJobByIDFunc: func(ctx context.Context, owner, repo string, jobID int64) (*github.WorkflowJob, *github.Response, error) {
+			*jobRequests++
+			return &github.WorkflowJob{ID: int64Ptr(jobID), RunID: int64Ptr(runs[jobID])}, nil, nil
+		},
+		GetWorkflowRunByIDFunc: func(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+			return &github.WorkflowRun{ID: int64Ptr(runID), Name: stringPtr(workflows[runID])}, nil, nil
+		},
+	}
+}
+
+func Test_statusValidator_Validate_allowedWorkflows(t *testing.T) {
+	tests := map[string]struct {
+		allowed          string
+		wantFailure      bool
+		wantIgnored      []string
+		wantNote         string
+		wantJobRequests  int
+		wantCompleteJobs int
+	}{
+		"ignores the check runs of the workflows which are not allowed": {
+			allowed:          "CI",
+			wantIgnored:      []string{"rogue"},
+			wantNote:         "Check run rogue is ignored, as it is run by workflow Rogue which is not allowed",
+			wantJobRequests:  2,
+			wantCompleteJobs: 3,
+		},
+		"validates only the check runs of the allowed workflows and the other apps": {
+			allowed:         "Rogue",
+			wantFailure:     true,
+			wantIgnored:     []string{"build", "test"},
+			wantNote:        "Check run build is ignored, as it is run by workflow CI which is not allowed",
+			wantJobRequests: 2,
+		},
+		"validates all the check runs without the allowed workflows": {
+			wantFailure:     true,
+			wantJobRequests: 0,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var jobRequests int
+			v, err := CreateValidator(allowedWorkflowClient(&jobRequests),
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithAllowedWorkflows(tt.allowed),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			// The workflow of each check suite is looked up only once across the polls.
+			for i := 0; i < 2; i++ {
+				got, err := v.Validate(context.Background())
+				var st *status
+				if tt.wantFailure {
+					var fe *failureError
+					if !errors.As(err, &fe) {
+						t.Fatalf("Validate() error = %v, want failure", err)
+					}
+					st = fe.status
+				} else {
+					if err != nil || !got.IsSuccess() {
+						t.Fatalf("Validate() = %v, %v, want success", got, err)
+					}
+					st = got.(*status)
+					if len(st.completeJobs) != tt.wantCompleteJobs {
+						t.Errorf("Validate() complete jobs = %v, want %d jobs", st.completeJobs, tt.wantCompleteJobs)
+					}
+				}
+				for _, job := range tt.wantIgnored {
+					if !containsJob(st.ignoredJobs, job) {
+						t.Errorf("Validate() ignored jobs = %v, want %s", st.ignoredJobs, job)
+					}
+				}
+				if containsJob(st.ignoredJobs, "external") {
+					t.Errorf("Validate() ignored jobs = %v, want the check run of the other app validated", st.ignoredJobs)
+				}
+				if len(tt.wantNote) != 0 && !containsJob(st.notes, tt.wantNote) {
+					t.Errorf("Validate() notes = %v, want %q", st.notes, tt.wantNote)
+				}
+			}
+			if jobRequests != tt.wantJobRequests {
+				t.Errorf("GetWorkflowJobByID() called %d times, want %d", jobRequests, tt.wantJobRequests)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_allowedWorkflowsLookupError(t *testing.T) {
+	var jobRequests int
+	c := allowedWorkflowClient(&jobRequests)
+	c.GetWorkflowRunByIDFunc = func(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+		return nil, nil, errors.New("err")
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+		WithAllowedWorkflows("CI"),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+	var fe *failureError
+	if _, err := v.Validate(context.Background()); err == nil || errors.As(err, &fe) {
+		t.Errorf("Validate() error = %v, want the error of the request", err)
+	}
+}
+
+func Test_statusValidator_suiteWorkflowOf_unknownSuite(t *testing.T) {
+	var jobRequests int
+	sv := &statusValidator{client: allowedWorkflowClient(&jobRequests)}
+
+	// The check runs without their suites are looked up once for each check run, across the polls.
+	for i := 0; i < 2; i++ {
+		for _, id := range []int64{11, 21} {
+			if _, err := sv.suiteWorkflowOf(context.Background(), 0, id, "job"); err != nil {
+				t.Fatalf("suiteWorkflowOf() error = %v", err)
+			}
+		}
+	}
+	if jobRequests != 2 {
+		t.Errorf("GetWorkflowJobByID() called %d times, want 2", jobRequests)
+	}
+	if workflow, _ := sv.suiteWorkflowOf(context.Background(), 0, 21, "job"); workflow.name != "Rogue" {
+		t.Errorf("suiteWorkflowOf() = %+v, want the workflow of the check run", workflow)
+	}
+}
+
+func Test_statusValidator_Validate_allowedWorkflowsOnlyJobs(t *testing.T) {
+	var jobRequests int
+	v, err := CreateValidator(allowedWorkflowClient(&jobRequests),
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+		WithAllowedWorkflows("CI"),
+		WithOnlyJobs("build,test,external,lint"),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+
+	if got, err := v.Validate(context.Background()); err != nil || !got.IsSuccess() {
+		t.Fatalf("Validate() = %v, %v, want success", got, err)
+	}
+	// The workflow of the job which is not validated anyway is not looked up.
+	if jobRequests != 1 {
+		t.Errorf("GetWorkflowJobByID() called %d times, want 1 for the suite of build and test", jobRequests)
+	}
+}
@@ -0,0 +1,52 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// annotationFailureLevel is the level of the error annotations, which GitHub names failure.
+const annotationFailureLevel = "failure"
+
+const maxAnnotationsPerPage = 100
+
+// errorAnnotationNote returns the note when the check run of a job whose annotations are checked has succeeded, but
+// has emitted error annotations, e.g. a linter which passes regardless of its findings. The annotations of a run are
+// only fetched once it has succeeded with any annotation, and the result is kept as the completed run never changes.
+func (sv *statusValidator) errorAnnotationNote(ctx context.Context, s *ghaStatus) (string, error) {
+	if s.Source != JobSourceCheckRun || s.State != successState || s.Annotations == 0 || !containsJob(sv.annotationJobs, s.Job) {
+		return "", nil
+	}
+	if note, ok := sv.annotationNotes[s.CheckRunID]; ok {
+		return note, nil
+	}
+
+	var failures []*github.CheckRunAnnotation
+	for page := 1; ; page++ {
+		annotations, _, err := sv.client.ListCheckRunAnnotations(ctx, sv.owner, sv.repo, s.CheckRunID, &github.ListOptions{PerPage: maxAnnotationsPerPage, Page: page})
+		if err != nil {
+			return "", fmt.Errorf("failed to list annotations of check run %s: %w", s.Job, err)
+		}
+		for _, a := range annotations {
+			if a.GetAnnotationLevel() == annotationFailureLevel {
+				failures = append(failures, a)
+			}
+		}
+		if len(annotations) < maxAnnotationsPerPage {
+			break
+		}
+	}
+
+	var note string
+	if len(failures) != 0 {
+		note = fmt.Sprintf("Check run %s has succeeded with %d error annotation(s), e.g. %s:%d: %s",
+			s.Job, len(failures), failures[0].GetPath(), failures[0].GetStartLine(), failures[0].GetMessage())
+	}
+	if sv.annotationNotes == nil {
+		sv.annotationNotes = make(map[int64]string)
+	}
+	sv.annotationNotes[s.CheckRunID] = note
+	return note, nil
+}
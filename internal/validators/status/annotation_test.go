@@ -0,0 +1,136 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_errorAnnotations(t *testing.T) {
+	const note = "Check run lint has succeeded with 1 error annotation(s), e.g. main.go:12: unused variable"
+
+	tests := map[string]struct {
+		checkedJobs  []string
+		annotations  []*github.CheckRunAnnotation
+		wantFailure  bool
+		wantRequests int
+	}{
+		"fails the passing run carrying error annotations": {
+			checkedJobs: []string{"lint"},
+			annotations: []*github.CheckRunAnnotation{
+				{Path: stringPtr("main.go"), StartLine: intPtr(10), AnnotationLevel: stringPtr("warning"), Message: stringPtr("long line")},
+				{Path: stringPtr("main.go"), StartLine: intPtr(12), AnnotationLevel: stringPtr(annotationFailureLevel), Message: stringPtr("unused variable")},
+			},
+			wantFailure:  true,
+			wantRequests: 1,
+		},
+		"succeeds when the annotations are not errors": {
+			checkedJobs: []string{"lint"},
+			annotations: []*github.CheckRunAnnotation{
+				{Path: stringPtr("main.go"), StartLine: intPtr(10), AnnotationLevel: stringPtr("notice"), Message: stringPtr("deprecated")},
+			},
+			wantRequests: 1,
+		},
+		"does not fetch the annotations of the jobs which are not checked": {
+			checkedJobs: []string{"build"},
+			annotations: []*github.CheckRunAnnotation{
+				{Path: stringPtr("main.go"), StartLine: intPtr(12), AnnotationLevel: stringPtr(annotationFailureLevel), Message: stringPtr("unused variable")},
+			},
+			wantRequests: 0,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								ID:         int64Ptr(42),
+								Name:       stringPtr("lint"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								Output:     &github.CheckRunOutput{AnnotationsCount: intPtr(len(tt.annotations))},
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunAnnotationsFunc: func(ctx context.Context, owner, repo string, checkRunID int64, opts *github.ListOptions) ([]*github.CheckRunAnnotation, *github.Response, error) {
+					requests++
+					if checkRunID != 42 {
+						t.Errorf("ListCheckRunAnnotations() check run = %d, want 42", checkRunID)
+					}
+					return tt.annotations, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithAnnotationCheckedJobs(tt.checkedJobs...),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			// The annotations of the completed run are fetched only once across the polls.
+			for i := 0; i < 2; i++ {
+				got, err := v.Validate(context.Background())
+				if !tt.wantFailure {
+					if err != nil || !got.IsSuccess() {
+						t.Fatalf("Validate() = %v, %v, want success", got, err)
+					}
+					continue
+				}
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() error = %v, want failure", err)
+				}
+				if !containsJob(fe.status.notes, note) {
+					t.Errorf("Validate() notes = %v, want %q", fe.status.notes, note)
+				}
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("ListCheckRunAnnotations() called %d times, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_errorAnnotationsListError(t *testing.T) {
+	c := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{
+				CheckRuns: []*github.CheckRun{
+					{ID: int64Ptr(42), Name: stringPtr("lint"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), Output: &github.CheckRunOutput{AnnotationsCount: intPtr(1)}},
+				},
+			}, nil, nil
+		},
+		ListCheckRunAnnotationsFunc: func(ctx context.Context, owner, repo string, checkRunID int64, opts *github.ListOptions) ([]*github.CheckRunAnnotation, *github.Response, error) {
+			return nil, nil, errors.New("err")
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+		WithAnnotationCheckedJobs("lint"),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+	var fe *failureError
+	if _, err := v.Validate(context.Background()); err == nil || errors.As(err, &fe) {
+		t.Errorf("Validate() error = %v, want the error of the request", err)
+	}
+}
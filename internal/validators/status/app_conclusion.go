@@ -0,0 +1,43 @@
+package status
+
+import "fmt"
+
+// appConclusion is a check run conclusion which is regarded as success only for the check runs posted by the app.
+type appConclusion struct {
+	app        string
+	conclusion string
+}
+
+func (ac appConclusion) validate() error {
+	if len(ac.app) == 0 || len(ac.conclusion) == 0 {
+		return fmt.Errorf("app conclusion must be in the form of app:conclusion, got %q", ac.app+":"+ac.conclusion)
+	}
+	if _, ok := knownCheckRunConclusions[ac.conclusion]; !ok {
+		return fmt.Errorf("unknown conclusion %q is set for app %s", ac.conclusion, ac.app)
+	}
+	if ac.conclusion == checkRunSuccessConclusion {
+		return fmt.Errorf("conclusion %q is always regarded as success, and cannot be set for app %s", ac.conclusion, ac.app)
+	}
+	return nil
+}
+
+// appConclusion returns the conclusion of the check run posted by the app, after applying the app conclusions.
+// A conclusion set for any app is regarded as success for the apps it is set for, and as failure for the other apps,
+// e.g. neutral conclusions of a third-party app are regarded as success while those of our own CI block. The other
+// conclusions are returned as is.
+func (sv *statusValidator) appConclusion(app, conclusion string) string {
+	var restricted bool
+	for _, ac := range sv.appConclusions {
+		if ac.conclusion != conclusion {
+			continue
+		}
+		if ac.app == app {
+			return checkRunSuccessConclusion
+		}
+		restricted = true
+	}
+	if restricted {
+		return checkRunFailureConclusion
+	}
+	return conclusion
+}
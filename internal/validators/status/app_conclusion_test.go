@@ -0,0 +1,126 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_appConclusions(t *testing.T) {
+	neutralRun := func(name, app string) *github.CheckRun {
+		return &github.CheckRun{
+			Name:       stringPtr(name),
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(checkRunNeutralConclusion),
+			App:        &github.App{Slug: stringPtr(app)},
+		}
+	}
+
+	tests := map[string]struct {
+		rules        string
+		tolerated    string
+		runs         []*github.CheckRun
+		wantErr      bool
+		wantErrJobs  []string
+		wantComplete []string
+	}{
+		"regards neutral as success for any app without the rules": {
+			runs: []*github.CheckRun{
+				neutralRun("scan", "third-party"),
+				neutralRun("test", "github-actions"),
+			},
+			wantComplete: []string{"scan", "test"},
+		},
+		"regards neutral as success only for the named app": {
+			rules: "third-party:neutral",
+			runs: []*github.CheckRun{
+				neutralRun("scan", "third-party"),
+				neutralRun("test", "github-actions"),
+			},
+			wantErr:      true,
+			wantErrJobs:  []string{"test"},
+			wantComplete: []string{"scan"},
+		},
+		"regards neutral as success for any of the named apps": {
+			rules: "third-party:neutral,github-actions:neutral",
+			runs: []*github.CheckRun{
+				neutralRun("scan", "third-party"),
+				neutralRun("test", "github-actions"),
+			},
+			wantComplete: []string{"scan", "test"},
+		},
+		"regards a failing conclusion as success for the named app": {
+			rules: "third-party:action_required",
+			runs: []*github.CheckRun{
+				{
+					Name:       stringPtr("scan"),
+					Status:     stringPtr(checkRunCompletedStatus),
+					Conclusion: stringPtr(checkRunActionRequiredConclusion),
+					App:        &github.App{Slug: stringPtr("third-party")},
+				},
+				neutralRun("test", "github-actions"),
+			},
+			wantComplete: []string{"scan", "test"},
+		},
+		"tolerates the blocked conclusion for the job": {
+			rules:     "third-party:neutral",
+			tolerated: "test:neutral",
+			runs: []*github.CheckRun{
+				neutralRun("scan", "third-party"),
+				neutralRun("test", "github-actions"),
+			},
+			wantComplete: []string{"scan", "test"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := CreateValidator(&mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: tt.runs}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithAppConclusions(tt.rules),
+				WithToleratedConclusions(tt.tolerated),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			var st *status
+			if tt.wantErr {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("statusValidator.Validate() error = %v, want failure", err)
+				}
+				st = fe.status
+			} else {
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() unexpected error: %v", err)
+				}
+				st = got.(*status)
+				if !st.IsSuccess() {
+					t.Errorf("statusValidator.Validate() IsSuccess() = false, want true")
+				}
+			}
+			if len(st.errJobs) != 0 || len(tt.wantErrJobs) != 0 {
+				if !reflect.DeepEqual(st.errJobs, tt.wantErrJobs) {
+					t.Errorf("statusValidator.Validate() errJobs = %v, want %v", st.errJobs, tt.wantErrJobs)
+				}
+			}
+			if !reflect.DeepEqual(st.completeJobs, tt.wantComplete) {
+				t.Errorf("statusValidator.Validate() completeJobs = %v, want %v", st.completeJobs, tt.wantComplete)
+			}
+		})
+	}
+}
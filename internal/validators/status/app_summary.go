@@ -0,0 +1,68 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	commitStatusAppLabel = "commit statuses"
+	unknownAppLabel      = "unknown app"
+)
+
+// summarizeByApp counts the completed jobs out of all jobs for each app reporting them, e.g. "circleci-checks: 12/12",
+// which helps to identify the provider slowing merges. It returns nil when no job is reported by an app, as the
+// summary would only repeat the overall counts.
+func summarizeByApp(jobStatuses []JobStatus) []string {
+	type count struct {
+		complete int
+		total    int
+	}
+
+	counts := make(map[string]*count)
+	var hasApp bool
+	for _, js := range jobStatuses {
+		label := js.App
+		switch {
+		case js.Source == JobSourceCommitStatus:
+			label = commitStatusAppLabel
+		case len(label) == 0:
+			label = unknownAppLabel
+		default:
+			hasApp = true
+		}
+
+		c, ok := counts[label]
+		if !ok {
+			c = &count{}
+			counts[label] = c
+		}
+		c.total++
+		if js.State == successState {
+			c.complete++
+		}
+	}
+	if !hasApp {
+		return nil
+	}
+
+	summaries := make([]string, 0, len(counts))
+	for label, c := range counts {
+		summaries = append(summaries, fmt.Sprintf("%s: %d/%d", label, c.complete, c.total))
+	}
+	sort.Strings(summaries)
+	return summaries
+}
+
+// countSuccessfulApps returns the number of distinct apps which posted successful check runs. Commit statuses are
+// not counted, as they are not associated with any app.
+func countSuccessfulApps(jobStatuses []JobStatus) int {
+	apps := make(map[string]struct{})
+	for _, js := range jobStatuses {
+		if js.Source != JobSourceCheckRun || len(js.App) == 0 || js.State != successState {
+			continue
+		}
+		apps[js.App] = struct{}{}
+	}
+	return len(apps)
+}
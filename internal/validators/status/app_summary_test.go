@@ -0,0 +1,81 @@
+package status
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_summarizeByApp(t *testing.T) {
+	tests := map[string]struct {
+		jobStatuses []JobStatus
+		want        []string
+	}{
+		"returns counts per app": {
+			jobStatuses: []JobStatus{
+				{Job: "job-01", State: successState, Source: JobSourceCheckRun, App: "github-actions"},
+				{Job: "job-02", State: pendingState, Source: JobSourceCheckRun, App: "github-actions"},
+				{Job: "job-03", State: successState, Source: JobSourceCheckRun, App: "circleci-checks"},
+				{Job: "job-04", State: errorState, Source: JobSourceCheckRun},
+				{Job: "job-05", State: successState, Source: JobSourceCommitStatus},
+			},
+			want: []string{
+				"circleci-checks: 1/1",
+				"commit statuses: 1/1",
+				"github-actions: 1/2",
+				"unknown app: 0/1",
+			},
+		},
+		"returns nil when no job is reported by an app": {
+			jobStatuses: []JobStatus{
+				{Job: "job-01", State: successState, Source: JobSourceCheckRun},
+				{Job: "job-02", State: successState, Source: JobSourceCommitStatus},
+			},
+			want: nil,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := summarizeByApp(tt.jobStatuses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("summarizeByApp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_countSuccessfulApps(t *testing.T) {
+	tests := map[string]struct {
+		jobStatuses []JobStatus
+		want        int
+	}{
+		"counts the distinct apps posting successful check runs": {
+			jobStatuses: []JobStatus{
+				{Job: "job-01", State: successState, Source: JobSourceCheckRun, App: "github-actions"},
+				{Job: "job-02", State: successState, Source: JobSourceCheckRun, App: "github-actions"},
+				{Job: "job-03", State: successState, Source: JobSourceCheckRun, App: "circleci-checks"},
+			},
+			want: 2,
+		},
+		"does not count the apps without successful check runs": {
+			jobStatuses: []JobStatus{
+				{Job: "job-01", State: successState, Source: JobSourceCheckRun, App: "github-actions"},
+				{Job: "job-02", State: pendingState, Source: JobSourceCheckRun, App: "circleci-checks"},
+				{Job: "job-03", State: failureState, Source: JobSourceCheckRun, App: "buildkite"},
+			},
+			want: 1,
+		},
+		"does not count commit statuses and check runs of unknown apps": {
+			jobStatuses: []JobStatus{
+				{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+				{Job: "job-02", State: successState, Source: JobSourceCheckRun},
+			},
+			want: 0,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := countSuccessfulApps(tt.jobStatuses); got != tt.want {
+				t.Errorf("countSuccessfulApps() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package status
+
+import (
+	"context"
+	"fmt"
+)
+
+// checkBaseBranch validates the head of the base branch of the pull request, so that the pull request is not merged
+// into a broken base. It returns the failed jobs of the base along with the note describing its state. The ignored
+// jobs and the self job are disregarded as they are for the head, while pending jobs of the base do not block. All
+// the jobs of the base are validated regardless of the only jobs, which only narrow the jobs of the head.
+//
+// The head of the base branch is resolved only once for the run, so that the polls validate the same base.
+func (sv *statusValidator) checkBaseBranch(ctx context.Context) ([]string, string, error) {
+	if len(sv.baseBranch) == 0 {
+		return nil, "", fmt.Errorf("base branch of pull request #%d is unknown", sv.prNumber)
+	}
+	sha, ok := sv.baseSHAs[sv.baseBranch]
+	if !ok {
+		var err error
+		sha, _, err = sv.client.GetCommitSHA1(ctx, sv.owner, sv.repo, sv.baseBranch, "")
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get head of base branch %s: %w", sv.baseBranch, err)
+		}
+		if sv.baseSHAs == nil {
+			sv.baseSHAs = make(map[string]string)
+		}
+		sv.baseSHAs[sv.baseBranch] = sha
+	}
+
+	ghaStatuses, _, err := sv.listGhaStatusesForRef(ctx, sha)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list statuses of base branch %s: %w", sv.baseBranch, err)
+	}
+
+	var failed []string
+	var pending int
+	for _, ghaStatus := range ghaStatuses {
+		if sv.isSelfJob(ghaStatus.Job) || containsJob(sv.ignoredJobs, ghaStatus.Job) {
+			continue
+		}
+		switch ghaStatus.State {
+		case successState:
+		case errorState, failureState:
+			failed = append(failed, ghaStatus.Job)
+		default:
+			pending++
+		}
+	}
+
+	switch {
+	case len(failed) != 0:
+		return failed, fmt.Sprintf("Base branch %s at %s has %d failed job(s)", sv.baseBranch, sha, len(failed)), nil
+	case pending != 0:
+		return nil, fmt.Sprintf("Base branch %s at %s has %d incomplete job(s)", sv.baseBranch, sha, pending), nil
+	default:
+		return nil, fmt.Sprintf("Base branch %s at %s has no failed job", sv.baseBranch, sha), nil
+	}
+}
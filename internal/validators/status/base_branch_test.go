@@ -0,0 +1,177 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_baseBranch(t *testing.T) {
+	const headSHA, baseSHA = "head-sha", "base-sha"
+
+	tests := map[string]struct {
+		baseStatuses     []*github.RepoStatus
+		headStatuses     []*github.RepoStatus
+		wantErr          bool
+		wantBaseFailures []string
+		wantNote         string
+	}{
+		"fails with a green pull request when the base branch is red": {
+			headStatuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			baseStatuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+				{Context: stringPtr("e2e"), State: stringPtr(failureState)},
+				{Context: stringPtr("self-job"), State: stringPtr(errorState)},
+			},
+			wantErr:          true,
+			wantBaseFailures: []string{"e2e"},
+			wantNote:         "Base branch main at base-sha has 1 failed job(s)",
+		},
+		"succeeds with a green pull request when the base branch is still running": {
+			headStatuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			baseStatuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(pendingState)},
+			},
+			wantNote: "Base branch main at base-sha has 1 incomplete job(s)",
+		},
+		"fails with a red pull request even when the base branch is green": {
+			headStatuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(failureState)},
+			},
+			baseStatuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			wantErr:  true,
+			wantNote: "Base branch main at base-sha has no failed job",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{
+						Head: &github.PullRequestBranch{SHA: stringPtr(headSHA)},
+						Base: &github.PullRequestBranch{Ref: stringPtr("main")},
+					}, nil, nil
+				},
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					if ref != "main" {
+						t.Errorf("GetCommitSHA1() ref = %s, want main", ref)
+					}
+					return baseSHA, nil, nil
+				},
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					if ref == baseSHA {
+						return &github.CombinedStatus{Statuses: tt.baseStatuses}, nil, nil
+					}
+					return &github.CombinedStatus{Statuses: tt.headStatuses}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequest(1),
+				WithSelfJob("self-job"),
+				WithBaseBranchCheck(true),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			var st *status
+			if tt.wantErr {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() error = %v, want failure", err)
+				}
+				st = fe.status
+			} else {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				st = got.(*status)
+				if !st.IsSuccess() {
+					t.Errorf("Validate() IsSuccess() = false, want true")
+				}
+			}
+			if !reflect.DeepEqual(st.baseFailures, tt.wantBaseFailures) {
+				t.Errorf("Validate() baseFailures = %v, want %v", st.baseFailures, tt.wantBaseFailures)
+			}
+			if !containsJob(st.notes, tt.wantNote) {
+				t.Errorf("Validate() notes = %v, want %q", st.notes, tt.wantNote)
+			}
+			if detail := st.Detail(); (len(tt.wantBaseFailures) != 0) != strings.Contains(detail, "::group::Base branch failures") {
+				t.Errorf("Detail() = %s, want the base branch failures reported separately", detail)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_baseBranchOnlyJobs(t *testing.T) {
+	const headSHA, baseSHA = "head-sha", "base-sha"
+
+	var shaRequests int
+	c := &mock.Client{
+		GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+			return &github.PullRequest{
+				Head: &github.PullRequestBranch{SHA: stringPtr(headSHA)},
+				Base: &github.PullRequestBranch{Ref: stringPtr("main")},
+			}, nil, nil
+		},
+		GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+			shaRequests++
+			return baseSHA, nil, nil
+		},
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			if ref == baseSHA && opts.CheckName != nil {
+				t.Errorf("ListCheckRunsForRef() check name = %s, want the base branch unfiltered", *opts.CheckName)
+			}
+			runs := []*github.CheckRun{
+				{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion)},
+			}
+			if ref == baseSHA {
+				runs = append(runs, &github.CheckRun{Name: stringPtr("e2e"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailureConclusion)})
+			}
+			return &github.ListCheckRunsResults{CheckRuns: runs}, nil, nil
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithPullRequest(1),
+		WithSelfJob("self-job"),
+		WithOnlyJobs("build"),
+		WithBaseBranchCheck(true),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := v.Validate(context.Background())
+		var fe *failureError
+		if !errors.As(err, &fe) {
+			t.Fatalf("Validate() error = %v, want failure", err)
+		}
+		if !reflect.DeepEqual(fe.status.baseFailures, []string{"e2e"}) {
+			t.Errorf("Validate() baseFailures = %v, want e2e beyond the only jobs", fe.status.baseFailures)
+		}
+	}
+	if shaRequests != 1 {
+		t.Errorf("GetCommitSHA1() called %d times, want 1 for the run", shaRequests)
+	}
+}
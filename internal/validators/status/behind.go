@@ -0,0 +1,29 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// checkCommitsBehind compares the head of the pull request with its base branch, so that a stale pull request is
+// rebased before it is merged. It returns the note with the behind and ahead counts, and whether the head is behind
+// the base by more commits than allowed.
+func (sv *statusValidator) checkCommitsBehind(ctx context.Context) (string, bool, error) {
+	if len(sv.baseBranch) == 0 || len(sv.prHead) == 0 {
+		return "", false, fmt.Errorf("base branch or head of pull request #%d is unknown", sv.prNumber)
+	}
+	// Only the counts are needed, so the commits are listed as few as possible.
+	cmp, _, err := sv.client.CompareCommits(ctx, sv.owner, sv.repo, sv.baseBranch, sv.prHead, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to compare head of pull request #%d with base branch %s: %w", sv.prNumber, sv.baseBranch, err)
+	}
+
+	behind, ahead := cmp.GetBehindBy(), cmp.GetAheadBy()
+	note := fmt.Sprintf("Pull request #%d is %d commit(s) behind and %d commit(s) ahead of base branch %s", sv.prNumber, behind, ahead, sv.baseBranch)
+	if behind > sv.maxCommitsBehind {
+		return fmt.Sprintf("%s, more than the allowed %d", note, sv.maxCommitsBehind), true, nil
+	}
+	return note, false, nil
+}
@@ -0,0 +1,98 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_maxCommitsBehind(t *testing.T) {
+	const headSHA = "head-sha"
+
+	tests := map[string]struct {
+		maxBehind int
+		behindBy  int
+		aheadBy   int
+		wantErr   bool
+		wantNote  string
+	}{
+		"succeeds when the pull request is up to date": {
+			maxBehind: 0,
+			behindBy:  0,
+			aheadBy:   2,
+			wantNote:  "Pull request #1 is 0 commit(s) behind and 2 commit(s) ahead of base branch main",
+		},
+		"succeeds when the pull request is behind within the limit": {
+			maxBehind: 5,
+			behindBy:  5,
+			aheadBy:   1,
+			wantNote:  "Pull request #1 is 5 commit(s) behind and 1 commit(s) ahead of base branch main",
+		},
+		"fails when the pull request is behind by more than the limit": {
+			maxBehind: 5,
+			behindBy:  6,
+			aheadBy:   1,
+			wantErr:   true,
+			wantNote:  "Pull request #1 is 6 commit(s) behind and 1 commit(s) ahead of base branch main, more than the allowed 5",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{
+						Head: &github.PullRequestBranch{SHA: stringPtr(headSHA)},
+						Base: &github.PullRequestBranch{Ref: stringPtr("main")},
+					}, nil, nil
+				},
+				CompareCommitsFunc: func(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+					if base != "main" || head != headSHA {
+						t.Errorf("CompareCommits() base = %s, head = %s, want main and %s", base, head, headSHA)
+					}
+					return &github.CommitsComparison{BehindBy: intPtr(tt.behindBy), AheadBy: intPtr(tt.aheadBy)}, nil, nil
+				},
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{{Context: stringPtr("build"), State: stringPtr(successState)}},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequest(1),
+				WithSelfJob("self-job"),
+				WithMaxCommitsBehind(tt.maxBehind),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			var st *status
+			if tt.wantErr {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() error = %v, want failure", err)
+				}
+				st = fe.status
+			} else {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				st = got.(*status)
+				if !st.IsSuccess() {
+					t.Errorf("Validate() IsSuccess() = false, want true")
+				}
+			}
+			if !containsJob(st.notes, tt.wantNote) {
+				t.Errorf("Validate() notes = %v, want %q", st.notes, tt.wantNote)
+			}
+		})
+	}
+}
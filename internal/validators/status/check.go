@@ -0,0 +1,31 @@
+package status
+
+import (
+	"context"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+// Check returns the result of the gate for the ref at this point in time, with a single pass of listing the jobs and
+// deciding on them, for callers such as dashboards which read the result rather than waiting for it. As with
+// Validate, a failed gate is returned as an error carrying the status of the jobs.
+//
+// The options requiring the result to be sustained across the polls are disregarded, as there is no later poll to
+// sustain it, and thus the all-green result is reported as successful at once.
+func Check(ctx context.Context, c github.Client, opts ...Option) (validators.Status, error) {
+	sv := &statusValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	if err := sv.validateFields(); err != nil {
+		return nil, err
+	}
+	sv.reverifyAfter = 0
+	sv.stablePolls = 0
+	sv.skipUnchangedPolls = false
+
+	return sv.Validate(ctx)
+}
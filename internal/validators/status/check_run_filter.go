@@ -0,0 +1,23 @@
+package status
+
+import "fmt"
+
+func (sv *statusValidator) validateCheckRunStatusFilter() error {
+	switch sv.checkRunStatusFilter {
+	case "", checkRunQueuedStatus, checkRunInProgressStatus, checkRunCompletedStatus:
+		return nil
+	default:
+		return fmt.Errorf("invalid check run status filter: %q, must be either %q, %q or %q",
+			sv.checkRunStatusFilter, checkRunQueuedStatus, checkRunInProgressStatus, checkRunCompletedStatus)
+	}
+}
+
+// checkRunStatusFilterOption returns the status filter forwarded with the requests listing the check runs, or nil
+// when all the check runs are listed.
+func (sv *statusValidator) checkRunStatusFilterOption() *string {
+	if len(sv.checkRunStatusFilter) == 0 {
+		return nil
+	}
+	filter := sv.checkRunStatusFilter
+	return &filter
+}
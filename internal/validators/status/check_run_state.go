@@ -0,0 +1,132 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+)
+
+// The states which a check run can be mapped to. A check run mapped to the skipped state is disregarded as if it
+// were not reported.
+const (
+	CheckRunMappedSuccess = "success"
+	CheckRunMappedFailure = "failure"
+	CheckRunMappedPending = "pending"
+	CheckRunMappedSkipped = "skipped"
+)
+
+// CheckRunState is the pair of the status and the conclusion of a check run. The conclusion is empty unless
+// the status is completed.
+type CheckRunState struct {
+	Status     string
+	Conclusion string
+}
+
+func (s CheckRunState) String() string {
+	if len(s.Conclusion) == 0 {
+		return s.Status
+	}
+	return s.Status + ":" + s.Conclusion
+}
+
+// DefaultCheckRunStates returns the default mapping of the check run states to the states of the jobs. The failing
+// conclusions may still be tolerated for the jobs or the apps they are set for.
+func DefaultCheckRunStates() map[CheckRunState]string {
+	return map[CheckRunState]string{
+		{Status: checkRunQueuedStatus}:                                                  CheckRunMappedPending,
+		{Status: checkRunInProgressStatus}:                                              CheckRunMappedPending,
+		{Status: checkRunCompletedStatus, Conclusion: checkRunSuccessConclusion}:        CheckRunMappedSuccess,
+		{Status: checkRunCompletedStatus, Conclusion: checkRunNeutralConclusion}:        CheckRunMappedSuccess,
+		{Status: checkRunCompletedStatus, Conclusion: checkRunSkipConclusion}:           CheckRunMappedSkipped,
+		{Status: checkRunCompletedStatus, Conclusion: checkRunFailureConclusion}:        CheckRunMappedFailure,
+		{Status: checkRunCompletedStatus, Conclusion: checkRunCancelledConclusion}:      CheckRunMappedFailure,
+		{Status: checkRunCompletedStatus, Conclusion: checkRunTimedOutConclusion}:       CheckRunMappedFailure,
+		{Status: checkRunCompletedStatus, Conclusion: checkRunActionRequiredConclusion}: CheckRunMappedFailure,
+		{Status: checkRunCompletedStatus, Conclusion: checkRunStaleConclusion}:          CheckRunMappedFailure,
+	}
+}
+
+var defaultCheckRunStates = DefaultCheckRunStates()
+
+var knownCheckRunMappedStates = map[string]struct{}{
+	CheckRunMappedSuccess: {},
+	CheckRunMappedFailure: {},
+	CheckRunMappedPending: {},
+	CheckRunMappedSkipped: {},
+}
+
+// validateCheckRunStates validates the entries of the mapping in the order of the states, so that the error is stable.
+func validateCheckRunStates(states map[CheckRunState]string) error {
+	keys := make([]CheckRunState, 0, len(states))
+	for s := range states {
+		keys = append(keys, s)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	for _, s := range keys {
+		if _, ok := knownCheckRunStatuses[s.Status]; !ok {
+			return fmt.Errorf("unknown check run status %q is mapped", s.Status)
+		}
+		if s.Status == checkRunCompletedStatus {
+			if _, ok := knownCheckRunConclusions[s.Conclusion]; !ok {
+				return fmt.Errorf("unknown check run conclusion %q is mapped", s.Conclusion)
+			}
+		} else if len(s.Conclusion) != 0 {
+			return fmt.Errorf("check run state %s is mapped, but only completed check runs have conclusions", s)
+		}
+		if _, ok := knownCheckRunMappedStates[states[s]]; !ok {
+			return fmt.Errorf("check run state %s is mapped to unknown state %q", s, states[s])
+		}
+	}
+	return nil
+}
+
+// checkRunState returns the state which the check run state is mapped to. The state which is not in the mapping is
+// regarded as pending while the check run is not completed, and as failure once completed.
+func (sv *statusValidator) checkRunState(s CheckRunState) string {
+	states := sv.checkRunStates
+	if states == nil {
+		states = defaultCheckRunStates
+	}
+	if mapped, ok := states[s]; ok {
+		return mapped
+	}
+	if s.Status != checkRunCompletedStatus {
+		return CheckRunMappedPending
+	}
+	return CheckRunMappedFailure
+}
+
+// setCheckRunState sets the state of the job from the status and the conclusion of its check run. It returns false
+// when the check run is mapped to the skipped state, and thus must be disregarded.
+func (sv *statusValidator) setCheckRunState(ghaStatus *ghaStatus, runStatus, conclusion string) bool {
+	state := CheckRunState{Status: runStatus}
+	if runStatus != checkRunCompletedStatus {
+		if _, ok := knownCheckRunStatuses[runStatus]; !ok {
+			ghaStatus.Unknown = fmt.Sprintf("status %q", runStatus)
+		}
+	} else {
+		if _, ok := knownCheckRunConclusions[conclusion]; !ok {
+			ghaStatus.Unknown = fmt.Sprintf("conclusion %q", conclusion)
+		}
+		state.Conclusion = sv.appConclusion(ghaStatus.App, conclusion)
+	}
+
+	switch sv.checkRunState(state) {
+	case CheckRunMappedSuccess:
+		ghaStatus.State = successState
+	case CheckRunMappedPending:
+		ghaStatus.State = pendingState
+	case CheckRunMappedSkipped:
+		return false
+	default:
+		if state.Status == checkRunCompletedStatus && sv.isToleratedConclusion(ghaStatus.Job, conclusion) {
+			ghaStatus.State = successState
+			ghaStatus.Tolerated = conclusion
+			break
+		}
+		ghaStatus.State = errorState
+	}
+	return true
+}
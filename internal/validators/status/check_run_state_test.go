@@ -0,0 +1,155 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_validateCheckRunStates(t *testing.T) {
+	tests := map[string]struct {
+		states  map[CheckRunState]string
+		wantErr bool
+	}{
+		"returns nil for the defaults": {
+			states: DefaultCheckRunStates(),
+		},
+		"returns nil for nil": {
+			states: nil,
+		},
+		"returns error when the status is unknown": {
+			states:  map[CheckRunState]string{{Status: "waiting"}: CheckRunMappedPending},
+			wantErr: true,
+		},
+		"returns error when the conclusion is unknown": {
+			states:  map[CheckRunState]string{{Status: checkRunCompletedStatus, Conclusion: "unknown"}: CheckRunMappedFailure},
+			wantErr: true,
+		},
+		"returns error when the conclusion of a completed run is empty": {
+			states:  map[CheckRunState]string{{Status: checkRunCompletedStatus}: CheckRunMappedFailure},
+			wantErr: true,
+		},
+		"returns error when the run which is not completed has a conclusion": {
+			states:  map[CheckRunState]string{{Status: checkRunQueuedStatus, Conclusion: checkRunSuccessConclusion}: CheckRunMappedSuccess},
+			wantErr: true,
+		},
+		"returns error when the mapped state is unknown": {
+			states:  map[CheckRunState]string{{Status: checkRunCompletedStatus, Conclusion: checkRunNeutralConclusion}: "ok"},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := validateCheckRunStates(tt.states); (err != nil) != tt.wantErr {
+				t.Errorf("validateCheckRunStates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_checkRunStates(t *testing.T) {
+	runs := []*github.CheckRun{
+		{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion)},
+		{Name: stringPtr("scan"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunNeutralConclusion)},
+		{Name: stringPtr("docs"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSkipConclusion)},
+		{Name: stringPtr("e2e"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunCancelledConclusion)},
+	}
+
+	tests := map[string]struct {
+		states       map[CheckRunState]string
+		wantErr      bool
+		wantComplete []string
+		wantErrJobs  []string
+		wantTotal    []string
+	}{
+		"maps the states by the defaults": {
+			wantErr:      true,
+			wantComplete: []string{"build", "scan"},
+			wantErrJobs:  []string{"e2e"},
+			wantTotal:    []string{"build", "e2e", "scan"},
+		},
+		"maps the states by the overridden entries": {
+			states: map[CheckRunState]string{
+				{Status: checkRunCompletedStatus, Conclusion: checkRunNeutralConclusion}:   CheckRunMappedFailure,
+				{Status: checkRunCompletedStatus, Conclusion: checkRunSkipConclusion}:      CheckRunMappedSuccess,
+				{Status: checkRunCompletedStatus, Conclusion: checkRunCancelledConclusion}: CheckRunMappedSkipped,
+			},
+			wantErr:      true,
+			wantComplete: []string{"build", "docs"},
+			wantErrJobs:  []string{"scan"},
+			wantTotal:    []string{"build", "docs", "scan"},
+		},
+		"keeps waiting for the state mapped to pending": {
+			states: map[CheckRunState]string{
+				{Status: checkRunCompletedStatus, Conclusion: checkRunCancelledConclusion}: CheckRunMappedPending,
+			},
+			wantComplete: []string{"build", "scan"},
+			wantErrJobs:  []string{},
+			wantTotal:    []string{"build", "e2e", "scan"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := CreateValidator(&mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: runs}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithCheckRunStates(tt.states),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			var st *status
+			if tt.wantErr {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("statusValidator.Validate() error = %v, want failure", err)
+				}
+				st = fe.status
+			} else {
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() unexpected error: %v", err)
+				}
+				st = got.(*status)
+				if st.IsSuccess() {
+					t.Errorf("statusValidator.Validate() IsSuccess() = true, want false")
+				}
+			}
+			if !reflect.DeepEqual(st.completeJobs, tt.wantComplete) {
+				t.Errorf("statusValidator.Validate() completeJobs = %v, want %v", st.completeJobs, tt.wantComplete)
+			}
+			if !reflect.DeepEqual(st.errJobs, tt.wantErrJobs) {
+				t.Errorf("statusValidator.Validate() errJobs = %v, want %v", st.errJobs, tt.wantErrJobs)
+			}
+			if !reflect.DeepEqual(st.totalJobs, tt.wantTotal) {
+				t.Errorf("statusValidator.Validate() totalJobs = %v, want %v", st.totalJobs, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestWithCheckRunStates(t *testing.T) {
+	sv := &statusValidator{}
+	WithCheckRunStates(map[CheckRunState]string{
+		{Status: checkRunCompletedStatus, Conclusion: checkRunNeutralConclusion}: CheckRunMappedFailure,
+	})(sv)
+
+	want := DefaultCheckRunStates()
+	want[CheckRunState{Status: checkRunCompletedStatus, Conclusion: checkRunNeutralConclusion}] = CheckRunMappedFailure
+	if !reflect.DeepEqual(sv.checkRunStates, want) {
+		t.Errorf("WithCheckRunStates() = %v, want %v", sv.checkRunStates, want)
+	}
+}
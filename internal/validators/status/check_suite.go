@@ -0,0 +1,52 @@
+package status
+
+import (
+	"context"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// latestSuiteRuns drops the check runs of the check suites of GitHub Actions which are superseded by a later suite of
+// the same workflow, as the runs of the old suite may linger once the whole suite is re-run. The suites are ordered
+// by their creation, and the suite of the greater ID wins the tie. The check runs of the other apps are kept.
+func (sv *statusValidator) latestSuiteRuns(ctx context.Context, runs []*github.CheckRun) ([]*github.CheckRun, error) {
+	if !sv.latestCheckSuites {
+		return runs, nil
+	}
+
+	suites := make(map[int64]suiteWorkflow)
+	latest := make(map[int64]int64)
+	for _, run := range runs {
+		suiteID := run.GetCheckSuite().GetID()
+		if run.GetApp().GetSlug() != workflowJobApp || suiteID == 0 || run.GetID() == 0 {
+			continue
+		}
+		if _, ok := suites[suiteID]; ok {
+			continue
+		}
+		workflow, err := sv.suiteWorkflowOf(ctx, suiteID, run.GetID(), run.GetName())
+		if err != nil {
+			return nil, err
+		}
+		suites[suiteID] = workflow
+		if workflow.workflowID == 0 {
+			continue
+		}
+
+		current, ok := latest[workflow.workflowID]
+		if !ok || workflow.createdAt.After(suites[current].createdAt) ||
+			(workflow.createdAt.Equal(suites[current].createdAt) && suiteID > current) {
+			latest[workflow.workflowID] = suiteID
+		}
+	}
+
+	kept := make([]*github.CheckRun, 0, len(runs))
+	for _, run := range runs {
+		suiteID := run.GetCheckSuite().GetID()
+		if workflow, ok := suites[suiteID]; ok && workflow.workflowID != 0 && latest[workflow.workflowID] != suiteID {
+			continue
+		}
+		kept = append(kept, run)
+	}
+	return kept, nil
+}
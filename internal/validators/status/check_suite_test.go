@@ -0,0 +1,142 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_latestCheckSuites(t *testing.T) {
+	rerunAt := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	actions := &github.App{Slug: stringPtr(workflowJobApp)}
+	run := func(id, suite int64, name, conclusion string, startedAt time.Time) *github.CheckRun {
+		return &github.CheckRun{
+			ID:         int64Ptr(id),
+			Name:       stringPtr(name),
+			App:        actions,
+			CheckSuite: &github.CheckSuite{ID: int64Ptr(suite)},
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(conclusion),
+			StartedAt:  &github.Timestamp{Time: startedAt},
+		}
+	}
+	// Suite 1 is the old suite of CI, which is re-run as suite 2 without the job it has removed meanwhile.
+	checkRuns := []*github.CheckRun{
+		run(11, 1, "build", checkRunFailureConclusion, rerunAt.Add(-time.Hour)),
+		run(12, 1, "legacy", checkRunFailureConclusion, rerunAt.Add(-time.Hour)),
+		run(21, 2, "build", checkRunSuccessConclusion, rerunAt),
+		run(31, 3, "lint", checkRunSuccessConclusion, rerunAt.Add(-time.Hour)),
+		{
+			ID:         int64Ptr(41),
+			Name:       stringPtr("external"),
+			App:        &github.App{Slug: stringPtr("external-ci")},
+			CheckSuite: &github.CheckSuite{ID: int64Ptr(4)},
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(checkRunSuccessConclusion),
+		},
+	}
+	workflowRuns := map[int64]*github.WorkflowRun{
+		101: {ID: int64Ptr(101), Name: stringPtr("CI"), WorkflowID: int64Ptr(7), CreatedAt: &github.Timestamp{Time: rerunAt.Add(-time.Hour)}},
+		201: {ID: int64Ptr(201), Name: stringPtr("CI"), WorkflowID: int64Ptr(7), CreatedAt: &github.Timestamp{Time: rerunAt}},
+		301: {ID: int64Ptr(301), Name: stringPtr("Lint"), WorkflowID: int64Ptr(8), CreatedAt: &github.Timestamp{Time: rerunAt.Add(-time.Hour)}},
+	}
+
+	tests := map[string]struct {
+		latestOnly      bool
+		wantFailure     bool
+		wantTotalJobs   int
+		wantJobRequests int
+	}{
+		"validates only the runs of the latest suite of each workflow": {
+			latestOnly:      true,
+			wantTotalJobs:   3,
+			wantJobRequests: 3,
+		},
+		"fails on the lingering runs of the old suite by default": {
+			wantFailure:     true,
+			wantJobRequests: 0,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var jobRequests int
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: checkRuns}, nil, nil
+				},
+				GetWorkflowJobByIDFunc: func(ctx context.Context, owner, repo string, jobID int64) (*github.WorkflowJob, *github.Response, error) {
+					jobRequests++
+					// The workflow jobs of the suites are numbered after their workflow runs, e.g. 21 of 201.
+					return &github.WorkflowJob{ID: int64Ptr(jobID), RunID: int64Ptr(jobID/10*100 + 1)}, nil, nil
+				},
+				GetWorkflowRunByIDFunc: func(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+					return workflowRuns[runID], nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithLatestCheckSuitesOnly(tt.latestOnly),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			// The workflow of each suite is looked up only once across the polls.
+			for i := 0; i < 2; i++ {
+				got, err := v.Validate(context.Background())
+				if tt.wantFailure {
+					var fe *failureError
+					if !errors.As(err, &fe) {
+						t.Fatalf("Validate() error = %v, want failure", err)
+					}
+					if !containsJob(fe.status.errJobs, "legacy") {
+						t.Errorf("Validate() failed jobs = %v, want legacy", fe.status.errJobs)
+					}
+					continue
+				}
+				if err != nil || !got.IsSuccess() {
+					t.Fatalf("Validate() = %v, %v, want success", got, err)
+				}
+				if st := got.(*status); len(st.totalJobs) != tt.wantTotalJobs || containsJob(st.totalJobs, "legacy") {
+					t.Errorf("Validate() total jobs = %v, want build, lint and external", st.totalJobs)
+				}
+			}
+			if jobRequests != tt.wantJobRequests {
+				t.Errorf("GetWorkflowJobByID() called %d times, want %d", jobRequests, tt.wantJobRequests)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_latestSuiteRuns_tie(t *testing.T) {
+	createdAt := &github.Timestamp{Time: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)}
+	sv := &statusValidator{
+		latestCheckSuites: true,
+		suiteWorkflows: map[int64]suiteWorkflow{
+			1: {name: "CI", workflowID: 7, createdAt: createdAt.Time},
+			2: {name: "CI", workflowID: 7, createdAt: createdAt.Time},
+		},
+	}
+	actions := &github.App{Slug: stringPtr(workflowJobApp)}
+	runs := []*github.CheckRun{
+		{ID: int64Ptr(21), Name: stringPtr("build"), App: actions, CheckSuite: &github.CheckSuite{ID: int64Ptr(2)}},
+		{ID: int64Ptr(11), Name: stringPtr("build"), App: actions, CheckSuite: &github.CheckSuite{ID: int64Ptr(1)}},
+	}
+
+	got, err := sv.latestSuiteRuns(context.Background(), runs)
+	if err != nil {
+		t.Fatalf("latestSuiteRuns() error = %v", err)
+	}
+	if len(got) != 1 || got[0].GetID() != 21 {
+		t.Errorf("latestSuiteRuns() = %v, want the run of the suite of the greater ID", got)
+	}
+}
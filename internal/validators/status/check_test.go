@@ -0,0 +1,92 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func TestCheck(t *testing.T) {
+	tests := map[string]struct {
+		state       string
+		opts        []Option
+		wantSuccess bool
+		wantFailure bool
+	}{
+		"reports the pending jobs": {
+			state: pendingState,
+		},
+		"reports the successful jobs": {
+			state:       successState,
+			wantSuccess: true,
+		},
+		"reports the failed jobs as failure": {
+			state:       errorState,
+			wantFailure: true,
+		},
+		"reports success at once regardless of the options sustaining the result": {
+			state: successState,
+			opts: []Option{
+				WithStableConsecutivePolls(3),
+				WithPostSuccessReverify(time.Minute),
+				WithSkipUnchangedPolls(true),
+			},
+			wantSuccess: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requested int
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					requested++
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("self-job"), State: stringPtr(pendingState)},
+							{Context: stringPtr("build"), State: stringPtr(tt.state)},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			opts := append([]Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+			}, tt.opts...)
+
+			got, err := Check(context.Background(), c, opts...)
+			if requested != 1 {
+				t.Errorf("Check() requested the statuses %d times, want 1", requested)
+			}
+			if tt.wantFailure {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Check() error = %v, want failure", err)
+				}
+				if !containsJob(fe.status.errJobs, "build") {
+					t.Errorf("Check() failed jobs = %v, want build", fe.status.errJobs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("Check() IsSuccess() = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+		})
+	}
+}
+
+func TestCheck_invalidOptions(t *testing.T) {
+	if _, err := Check(context.Background(), &mock.Client{}, WithGitHubRef("main")); err == nil {
+		t.Error("Check() expected error")
+	}
+}
@@ -0,0 +1,67 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// combinedState is the overall state of the commit statuses aggregated by GitHub, along with the commit statuses
+// which are not successful and thus account for it.
+type combinedState struct {
+	state     string
+	unsettled map[string]string
+}
+
+func newCombinedState(state string, statuses []*github.RepoStatus) *combinedState {
+	cs := &combinedState{
+		state:     state,
+		unsettled: make(map[string]string),
+	}
+	for _, s := range statuses {
+		if s.GetState() != successState {
+			cs.unsettled[s.GetContext()] = s.GetState()
+		}
+	}
+	return cs
+}
+
+// combinedStateMismatch returns the note when the overall state of the commit statuses is not successful while all
+// the jobs are green, which indicates that the states of the individual commit statuses have been misread. The commit
+// statuses which are disregarded by the validation, such as the ignored jobs and the self job, may leave the overall
+// state pending or failed, and thus the overall state is only distrusted when they do not account for it.
+func (sv *statusValidator) combinedStateMismatch(cs *combinedState) (string, Decision) {
+	if cs == nil || len(cs.state) == 0 || cs.state == successState {
+		return "", DecisionSuccess
+	}
+
+	var contexts []string
+	var accounted bool
+	for context, state := range cs.unsettled {
+		job := sv.canonicalJob(context)
+		if sv.isSelfJob(job) || containsJob(sv.ignoredJobs, job) ||
+			(len(sv.onlyJobs) != 0 && !containsJob(sv.onlyJobs, job)) ||
+			(state == pendingState && containsJob(sv.nonBlockingPendingJobs, job)) {
+			// The overall state is failure when any commit status is in either failure or error state.
+			accounted = accounted || state == cs.state || (cs.state == "failure" && state == errorState)
+			continue
+		}
+		contexts = append(contexts, fmt.Sprintf("%s (%s)", context, state))
+	}
+	if len(contexts) == 0 && accounted {
+		return "", DecisionSuccess
+	}
+	sort.Strings(contexts)
+
+	cause := "which no commit status accounts for"
+	if len(contexts) != 0 {
+		cause = "disagreeing on " + strings.Join(contexts, ", ")
+	}
+	note := fmt.Sprintf("Combined state of the commit statuses is %s while all the jobs are green, %s", cs.state, cause)
+	if cs.state == pendingState {
+		return note, DecisionPending
+	}
+	return note, DecisionFailure
+}
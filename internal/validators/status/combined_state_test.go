@@ -0,0 +1,115 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_combinedState(t *testing.T) {
+	tests := map[string]struct {
+		state       string
+		statuses    []*github.RepoStatus
+		disabled    bool
+		wantSuccess bool
+		wantPending bool
+		wantNote    string
+	}{
+		"succeeds when the combined state agrees with the jobs": {
+			state: successState,
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			wantSuccess: true,
+		},
+		"succeeds when the combined state is left pending by the self job and the ignored jobs": {
+			state: pendingState,
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("self-job"), State: stringPtr(pendingState)},
+				{Context: stringPtr("flaky"), State: stringPtr(errorState)},
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			wantSuccess: true,
+		},
+		"succeeds when the combined state has failed on an ignored job": {
+			state: "failure",
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("flaky"), State: stringPtr(errorState)},
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			wantSuccess: true,
+		},
+		"waits while the combined state is pending on the green jobs": {
+			state: pendingState,
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+				{Context: stringPtr("lint"), State: stringPtr(successState)},
+			},
+			wantPending: true,
+			wantNote:    "Combined state of the commit statuses is pending while all the jobs are green, which no commit status accounts for",
+		},
+		"fails when the combined state has failed while the jobs are green": {
+			state: "failure",
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("self-job"), State: stringPtr(pendingState)},
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			wantNote: "Combined state of the commit statuses is failure while all the jobs are green, which no commit status accounts for",
+		},
+		"disregards the combined state when the check is disabled": {
+			state: "failure",
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			disabled:    true,
+			wantSuccess: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{State: stringPtr(tt.state), Statuses: tt.statuses}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithIgnoredJobs("flaky"),
+				WithCombinedStateCheck(!tt.disabled),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if tt.wantSuccess || tt.wantPending {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				if got.IsSuccess() != tt.wantSuccess {
+					t.Errorf("Validate() IsSuccess() = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+				}
+				if notes := got.(*status).notes; len(tt.wantNote) != 0 && !containsJob(notes, tt.wantNote) {
+					t.Errorf("Validate() notes = %v, want %q", notes, tt.wantNote)
+				}
+				return
+			}
+
+			var fe *failureError
+			if !errors.As(err, &fe) {
+				t.Fatalf("Validate() error = %v, want failure", err)
+			}
+			if !containsJob(fe.status.notes, tt.wantNote) {
+				t.Errorf("Validate() notes = %v, want %q", fe.status.notes, tt.wantNote)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// awaitCommitAge holds off the success until the validated commit is at least as old as the minimum age, so that
+// the checks of a freshly pushed commit have registered before the gate passes. The age is measured from the date of
+// the committer, which is fetched only once for each commit.
+func (sv *statusValidator) awaitCommitAge(ctx context.Context, st *status) error {
+	if sv.minCommitAge <= 0 {
+		return nil
+	}
+
+	ref := sv.targetRef()
+	if sv.commitDateRef != ref {
+		// A single file is enough, as only the commit itself is needed.
+		commit, _, err := sv.client.GetCommit(ctx, sv.owner, sv.repo, ref, &github.ListOptions{PerPage: 1})
+		if err != nil {
+			return fmt.Errorf("failed to get commit %s: %w", ref, err)
+		}
+		sv.commitDate = commit.GetCommit().GetCommitter().GetDate()
+		sv.commitDateRef = ref
+	}
+
+	if age := sv.now().Sub(sv.commitDate); age < sv.minCommitAge {
+		st.succeeded = false
+		if age < 0 {
+			age = 0
+		}
+		st.notes = append(st.notes, fmt.Sprintf("All jobs are green, but commit %s is only %s old, waiting %s more before declaring success",
+			ref, age.Round(time.Second), (sv.minCommitAge-age).Round(time.Second)))
+	}
+	return nil
+}
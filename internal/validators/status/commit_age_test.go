@@ -0,0 +1,105 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_minCommitAge(t *testing.T) {
+	committed := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	polls := []struct {
+		now         time.Time
+		lintState   string
+		wantSuccess bool
+		wantNote    string
+	}{
+		{now: committed.Add(10 * time.Second), lintState: pendingState},
+		{
+			now:       committed.Add(30 * time.Second),
+			lintState: successState,
+			wantNote:  "All jobs are green, but commit main is only 30s old, waiting 1m30s more before declaring success",
+		},
+		{now: committed.Add(2 * time.Minute), lintState: successState, wantSuccess: true},
+	}
+
+	var poll, requests int
+	c := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{
+					{Context: stringPtr("lint"), State: stringPtr(polls[poll].lintState)},
+				},
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{}, nil, nil
+		},
+		GetCommitFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+			requests++
+			return &github.RepositoryCommit{
+				Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &committed}},
+			}, nil, nil
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+		WithMinCommitAge(2*time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+	v.(*statusValidator).clock = func() time.Time { return polls[poll].now }
+
+	for poll = range polls {
+		got, err := v.Validate(context.Background())
+		if err != nil {
+			t.Fatalf("poll %d: Validate() error = %v", poll, err)
+		}
+		if got.IsSuccess() != polls[poll].wantSuccess {
+			t.Errorf("poll %d: Validate() success = %v, want %v", poll, got.IsSuccess(), polls[poll].wantSuccess)
+		}
+		if note := polls[poll].wantNote; len(note) != 0 && !containsJob(got.(*status).notes, note) {
+			t.Errorf("poll %d: Validate() notes = %v, want %q", poll, got.(*status).notes, note)
+		}
+	}
+
+	// The commit is only fetched once the jobs are green, and only once for the commit.
+	if requests != 1 {
+		t.Errorf("GetCommit() called %d times, want 1", requests)
+	}
+}
+
+func Test_statusValidator_Validate_minCommitAgeError(t *testing.T) {
+	c := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{{Context: stringPtr("lint"), State: stringPtr(successState)}},
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{}, nil, nil
+		},
+		GetCommitFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+			return nil, nil, errors.New("err")
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+		WithMinCommitAge(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+	if _, err := v.Validate(context.Background()); err == nil {
+		t.Error("Validate() error = nil, want the error of the request")
+	}
+}
@@ -0,0 +1,52 @@
+package status
+
+import "fmt"
+
+// Decision is the overall result of the validation.
+type Decision int
+
+const (
+	// DecisionPending means the validation should be retried later, as some jobs are yet to be completed.
+	DecisionPending Decision = iota
+	// DecisionSuccess means all the required jobs are successful.
+	DecisionSuccess
+	// DecisionFailure means the validation has failed and should not be retried.
+	DecisionFailure
+)
+
+// StatusDecider decides the overall result from the job statuses, along with the reason of the decision.
+// The given statuses exclude the ignored jobs and the self job.
+//
+// Custom deciders can be injected with WithDecider to encode rules which are not supported by the options,
+// such as requirements on a group of jobs.
+type StatusDecider interface {
+	Decide(statuses []JobStatus) (Decision, string)
+}
+
+// DefaultDecider is the built-in StatusDecider. It fails when any job is in error or failure state,
+// and succeeds only when all the jobs are successful.
+type DefaultDecider struct{}
+
+func (DefaultDecider) Decide(statuses []JobStatus) (Decision, string) {
+	var failed, pending int
+	for _, st := range statuses {
+		switch st.State {
+		case successState:
+		case errorState, failureState:
+			failed++
+		default:
+			pending++
+		}
+	}
+
+	switch {
+	case failed != 0:
+		return DecisionFailure, fmt.Sprintf("%d job(s) failed", failed)
+	case pending != 0:
+		return DecisionPending, fmt.Sprintf("%d job(s) are yet to be completed", pending)
+	default:
+		return DecisionSuccess, "all jobs are successful"
+	}
+}
+
+var _ StatusDecider = DefaultDecider{}
@@ -0,0 +1,58 @@
+package status
+
+import "testing"
+
+type deciderFunc func(statuses []JobStatus) (Decision, string)
+
+func (f deciderFunc) Decide(statuses []JobStatus) (Decision, string) {
+	return f(statuses)
+}
+
+func TestDefaultDecider_Decide(t *testing.T) {
+	tests := map[string]struct {
+		statuses   []JobStatus
+		want       Decision
+		wantReason string
+	}{
+		"returns success when all jobs are successful": {
+			statuses: []JobStatus{
+				{Job: "job-01", State: successState},
+				{Job: "job-02", State: successState},
+			},
+			want:       DecisionSuccess,
+			wantReason: "all jobs are successful",
+		},
+		"returns success when there is no job": {
+			want:       DecisionSuccess,
+			wantReason: "all jobs are successful",
+		},
+		"returns pending when some jobs are not completed": {
+			statuses: []JobStatus{
+				{Job: "job-01", State: successState},
+				{Job: "job-02", State: pendingState},
+			},
+			want:       DecisionPending,
+			wantReason: "1 job(s) are yet to be completed",
+		},
+		"returns failure when some jobs failed even if others are pending": {
+			statuses: []JobStatus{
+				{Job: "job-01", State: errorState},
+				{Job: "job-02", State: pendingState},
+				{Job: "job-03", State: failureState},
+			},
+			want:       DecisionFailure,
+			wantReason: "2 job(s) failed",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, reason := DefaultDecider{}.Decide(tt.statuses)
+			if got != tt.want {
+				t.Errorf("DefaultDecider.Decide() = %v, want %v", got, tt.want)
+			}
+			if reason != tt.wantReason {
+				t.Errorf("DefaultDecider.Decide() reason = %s, want %s", reason, tt.wantReason)
+			}
+		})
+	}
+}
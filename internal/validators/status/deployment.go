@@ -0,0 +1,119 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// NOTE: https://docs.github.com/en/rest/reference/deployments#deployment-statuses
+const (
+	deploymentSuccessState    = "success"
+	deploymentFailureState    = "failure"
+	deploymentErrorState      = "error"
+	deploymentInactiveState   = "inactive"
+	deploymentPendingState    = "pending"
+	deploymentQueuedState     = "queued"
+	deploymentInProgressState = "in_progress"
+)
+
+// deploymentJobPrefix is prepended to the environment names, so that the deployments are not mistaken for the jobs.
+const deploymentJobPrefix = "deployment: "
+
+func deploymentJobName(environment string) string {
+	return deploymentJobPrefix + environment
+}
+
+// listDeploymentStatuses returns the status of the latest deployment of the ref to each of the required environments.
+// The environment which the ref is not deployed to yet is regarded as pending, as the deployment is most likely yet
+// to be created by the release workflow.
+func (sv *statusValidator) listDeploymentStatuses(ctx context.Context, ref string) ([]*ghaStatus, fetchInfo, error) {
+	info := fetchInfo{unchanged: true}
+	if len(sv.requiredEnvironments) == 0 {
+		return nil, info, nil
+	}
+
+	opts := &github.DeploymentsListOptions{ListOptions: github.ListOptions{PerPage: 1}}
+	if commitSHAPattern.MatchString(ref) {
+		opts.SHA = ref
+	} else {
+		opts.Ref = ref
+	}
+
+	ghaStatuses := make([]*ghaStatus, 0, len(sv.requiredEnvironments))
+	for _, environment := range sv.requiredEnvironments {
+		opts.Environment = environment
+		// The deployments are returned from the newest one.
+		deployments, resp, err := sv.client.ListDeployments(ctx, sv.owner, sv.repo, opts)
+		if err != nil {
+			return nil, fetchInfo{}, fmt.Errorf("failed to list deployments to %s: %w", environment, err)
+		}
+		info.unchanged = info.unchanged && github.IsNotModified(resp)
+
+		ghaStatus := &ghaStatus{
+			Job:    deploymentJobName(environment),
+			State:  pendingState,
+			Source: JobSourceDeployment,
+		}
+		ghaStatuses = append(ghaStatuses, ghaStatus)
+		if len(deployments) == 0 {
+			continue
+		}
+		ghaStatus.StartedAt = deployments[0].GetCreatedAt().Time
+
+		statuses, resp, err := sv.client.ListDeploymentStatuses(ctx, sv.owner, sv.repo, deployments[0].GetID(), &github.ListOptions{PerPage: 1})
+		if err != nil {
+			return nil, fetchInfo{}, fmt.Errorf("failed to list deployment statuses of %s: %w", environment, err)
+		}
+		info.unchanged = info.unchanged && github.IsNotModified(resp)
+		if len(statuses) == 0 {
+			continue
+		}
+		ghaStatus.DetailsURL = deploymentDetailsURL(statuses[0])
+
+		switch state := statuses[0].GetState(); state {
+		case deploymentSuccessState:
+			ghaStatus.State = successState
+			ghaStatus.CompletedAt = statuses[0].GetCreatedAt().Time
+		case deploymentFailureState, deploymentErrorState:
+			ghaStatus.State = errorState
+			ghaStatus.CompletedAt = statuses[0].GetCreatedAt().Time
+		case deploymentInactiveState:
+			// The deployment has been superseded by another one, and thus the ref is no longer deployed.
+			ghaStatus.State = errorState
+		case deploymentPendingState, deploymentQueuedState, deploymentInProgressState:
+		default:
+			ghaStatus.Unknown = fmt.Sprintf("deployment state %q", state)
+		}
+	}
+	return ghaStatuses, info, nil
+}
+
+func deploymentDetailsURL(s *github.DeploymentStatus) string {
+	switch {
+	case len(s.GetLogURL()) != 0:
+		return s.GetLogURL()
+	case len(s.GetTargetURL()) != 0:
+		return s.GetTargetURL()
+	default:
+		return s.GetEnvironmentURL()
+	}
+}
+
+// summarizeEnvironments returns the state of each of the required environments, e.g. "production: success".
+func summarizeEnvironments(jobStatuses []JobStatus) []string {
+	var summaries []string
+	for _, js := range jobStatuses {
+		if js.Source != JobSourceDeployment {
+			continue
+		}
+		state := js.State
+		if js.StartedAt.IsZero() {
+			state = "not deployed yet"
+		}
+		summaries = append(summaries, fmt.Sprintf("%s: %s", strings.TrimPrefix(js.Job, deploymentJobPrefix), state))
+	}
+	return summaries
+}
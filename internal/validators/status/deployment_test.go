@@ -0,0 +1,172 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_requiredEnvironments(t *testing.T) {
+	createdAt := &github.Timestamp{Time: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := map[string]struct {
+		deployments      map[string][]*github.Deployment
+		statuses         map[int64][]*github.DeploymentStatus
+		wantErr          bool
+		wantSucceeded    bool
+		wantEnvironments []string
+	}{
+		"succeeds when the ref is successfully deployed to all the environments": {
+			deployments: map[string][]*github.Deployment{
+				"staging":    {{ID: int64Ptr(1), CreatedAt: createdAt}},
+				"production": {{ID: int64Ptr(2), CreatedAt: createdAt}},
+			},
+			statuses: map[int64][]*github.DeploymentStatus{
+				1: {{State: stringPtr("success"), CreatedAt: createdAt}},
+				2: {{State: stringPtr("success"), CreatedAt: createdAt}},
+			},
+			wantSucceeded:    true,
+			wantEnvironments: []string{"production: success", "staging: success"},
+		},
+		"waits while the ref is not deployed to an environment yet": {
+			deployments: map[string][]*github.Deployment{
+				"staging": {{ID: int64Ptr(1), CreatedAt: createdAt}},
+			},
+			statuses: map[int64][]*github.DeploymentStatus{
+				1: {{State: stringPtr("success"), CreatedAt: createdAt}},
+			},
+			wantSucceeded:    false,
+			wantEnvironments: []string{"production: not deployed yet", "staging: success"},
+		},
+		"waits while a deployment is in progress": {
+			deployments: map[string][]*github.Deployment{
+				"staging":    {{ID: int64Ptr(1), CreatedAt: createdAt}},
+				"production": {{ID: int64Ptr(2), CreatedAt: createdAt}},
+			},
+			statuses: map[int64][]*github.DeploymentStatus{
+				1: {{State: stringPtr("success"), CreatedAt: createdAt}},
+				2: {{State: stringPtr("in_progress"), CreatedAt: createdAt}},
+			},
+			wantSucceeded:    false,
+			wantEnvironments: []string{"production: pending", "staging: success"},
+		},
+		"fails when a deployment has failed": {
+			deployments: map[string][]*github.Deployment{
+				"staging":    {{ID: int64Ptr(1), CreatedAt: createdAt}},
+				"production": {{ID: int64Ptr(2), CreatedAt: createdAt}},
+			},
+			statuses: map[int64][]*github.DeploymentStatus{
+				1: {{State: stringPtr("success"), CreatedAt: createdAt}},
+				2: {{State: stringPtr("failure"), CreatedAt: createdAt}},
+			},
+			wantErr:          true,
+			wantEnvironments: []string{"production: error", "staging: success"},
+		},
+		"fails when the deployment has been superseded": {
+			deployments: map[string][]*github.Deployment{
+				"staging":    {{ID: int64Ptr(1), CreatedAt: createdAt}},
+				"production": {{ID: int64Ptr(2), CreatedAt: createdAt}},
+			},
+			statuses: map[int64][]*github.DeploymentStatus{
+				1: {{State: stringPtr("success"), CreatedAt: createdAt}},
+				2: {{State: stringPtr("inactive"), CreatedAt: createdAt}},
+			},
+			wantErr:          true,
+			wantEnvironments: []string{"production: error", "staging: success"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("job-01"), State: stringPtr(successState)},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+				ListDeploymentsFunc: func(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, *github.Response, error) {
+					if opts.Ref != "main" || opts.PerPage != 1 {
+						t.Errorf("ListDeployments() opts = %+v, want the latest deployment of main", opts)
+					}
+					return tt.deployments[opts.Environment], nil, nil
+				},
+				ListDeploymentStatusesFunc: func(ctx context.Context, owner, repo string, deployment int64, opts *github.ListOptions) ([]*github.DeploymentStatus, *github.Response, error) {
+					return tt.statuses[deployment], nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithRequiredEnvironments("production,staging"),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			var st *status
+			if tt.wantErr {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() error = %v, want failure", err)
+				}
+				st = fe.status
+			} else {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				st = got.(*status)
+				if st.IsSuccess() != tt.wantSucceeded {
+					t.Errorf("Validate() succeeded = %v, want %v", st.IsSuccess(), tt.wantSucceeded)
+				}
+			}
+			if !reflect.DeepEqual(st.environments, tt.wantEnvironments) {
+				t.Errorf("Validate() environments = %v, want %v", st.environments, tt.wantEnvironments)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_listDeploymentStatuses(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	c := &mock.Client{
+		ListDeploymentsFunc: func(ctx context.Context, owner, repo string, opts *github.DeploymentsListOptions) ([]*github.Deployment, *github.Response, error) {
+			if opts.SHA != sha || len(opts.Ref) != 0 {
+				t.Errorf("ListDeployments() opts = %+v, want the deployments of %s", opts, sha)
+			}
+			return []*github.Deployment{{ID: int64Ptr(1), CreatedAt: &github.Timestamp{Time: time.Now()}}}, nil, nil
+		},
+		ListDeploymentStatusesFunc: func(ctx context.Context, owner, repo string, deployment int64, opts *github.ListOptions) ([]*github.DeploymentStatus, *github.Response, error) {
+			return []*github.DeploymentStatus{
+				{State: stringPtr("success"), TargetURL: stringPtr("https://example.com/target"), LogURL: stringPtr("https://example.com/log")},
+			}, nil, nil
+		},
+	}
+	sv := &statusValidator{
+		owner:                "test-owner",
+		repo:                 "test-repo",
+		client:               c,
+		requiredEnvironments: []string{"production"},
+	}
+
+	got, _, err := sv.listDeploymentStatuses(context.Background(), sha)
+	if err != nil {
+		t.Fatalf("listDeploymentStatuses() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("listDeploymentStatuses() = %v, want 1 status", got)
+	}
+	if got[0].Job != "deployment: production" || got[0].Source != JobSourceDeployment || got[0].DetailsURL != "https://example.com/log" {
+		t.Errorf("listDeploymentStatuses() = %+v, want the log URL of production", got[0])
+	}
+}
@@ -0,0 +1,44 @@
+package status
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// compileRequiredDescriptions compiles the patterns which the descriptions of the commit statuses must match, in the
+// order of the contexts so that the error is stable.
+func (sv *statusValidator) compileRequiredDescriptions() error {
+	if len(sv.requiredDescriptionPatterns) == 0 {
+		return nil
+	}
+	contexts := make([]string, 0, len(sv.requiredDescriptionPatterns))
+	for context := range sv.requiredDescriptionPatterns {
+		contexts = append(contexts, context)
+	}
+	sort.Strings(contexts)
+
+	sv.requiredDescriptions = make(map[string]*regexp.Regexp, len(contexts))
+	for _, context := range contexts {
+		re, err := regexp.Compile(sv.requiredDescriptionPatterns[context])
+		if err != nil {
+			return fmt.Errorf("invalid required description of %s: %w", context, err)
+		}
+		sv.requiredDescriptions[sv.canonicalJob(context)] = re
+	}
+	return nil
+}
+
+// descriptionMismatchNote returns the note when the commit status has succeeded, but its description does not match
+// the required pattern, e.g. a license scan which passed with violations. Check runs have no descriptions, and the
+// commit statuses which have not succeeded are left to their states.
+func (sv *statusValidator) descriptionMismatchNote(s *ghaStatus) string {
+	re, ok := sv.requiredDescriptions[s.Job]
+	if !ok || s.Source != JobSourceCommitStatus || s.State != successState {
+		return ""
+	}
+	if re.MatchString(s.Description) {
+		return ""
+	}
+	return fmt.Sprintf("Commit status %s has succeeded with description %q, which does not match %q", s.Job, s.Description, re)
+}
@@ -0,0 +1,81 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_requiredDescriptions(t *testing.T) {
+	tests := map[string]struct {
+		state         string
+		description   string
+		wantErr       bool
+		wantSucceeded bool
+		wantNote      string
+	}{
+		"succeeds when the description matches": {
+			state:         successState,
+			description:   "Passed with 0 violations",
+			wantSucceeded: true,
+		},
+		"returns error when the description does not match": {
+			state:       successState,
+			description: "Passed with 3 violations",
+			wantErr:     true,
+			wantNote:    `Commit status license-scan has succeeded with description "Passed with 3 violations", which does not match "^Passed with 0 violations$"`,
+		},
+		"returns error when the description is empty": {
+			state:    successState,
+			wantErr:  true,
+			wantNote: `Commit status license-scan has succeeded with description "", which does not match "^Passed with 0 violations$"`,
+		},
+		"keeps waiting while the status is pending regardless of the description": {
+			state:         pendingState,
+			description:   "Scanning",
+			wantSucceeded: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := CreateValidator(&mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("license-scan"), State: stringPtr(tt.state), Description: stringPtr(tt.description)},
+							{Context: stringPtr("lint"), State: stringPtr(successState), Description: stringPtr("Passed with 3 warnings")},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithRequiredDescriptions(map[string]string{"license-scan": "^Passed with 0 violations$"}),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.wantNote) {
+					t.Errorf("statusValidator.Validate() error does not contain %q:\n%s", tt.wantNote, err.Error())
+				}
+				return
+			}
+			if got.IsSuccess() != tt.wantSucceeded {
+				t.Errorf("statusValidator.Validate() IsSuccess() = %v, want %v", got.IsSuccess(), tt.wantSucceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package status
+
+import "fmt"
+
+// ErrorStatePolicy is the behavior for the commit statuses in error state, which GitHub distinguishes from failure
+// state as an error usually indicates an infrastructure problem rather than a genuine failure.
+type ErrorStatePolicy string
+
+const (
+	// ErrorStateFail fails the validation as with the failure state. This is the default.
+	ErrorStateFail ErrorStatePolicy = ""
+	// ErrorStateRetry regards the jobs in error state as pending, and keeps waiting for them to be retried.
+	ErrorStateRetry ErrorStatePolicy = "retry"
+)
+
+func (p ErrorStatePolicy) validate() error {
+	switch p {
+	case ErrorStateFail, ErrorStateRetry:
+		return nil
+	default:
+		return fmt.Errorf("invalid error state policy: %q, must be %q", p, ErrorStateRetry)
+	}
+}
+
+// retryErrorState reports whether the job in error state is waited to be retried, along with the note to report.
+// Check runs are not affected, as their failing conclusions do not tell infrastructure problems from genuine failures.
+// When the retries are limited, the job fails once it remains in error state for more polls than the limit.
+func (sv *statusValidator) retryErrorState(s *ghaStatus) (bool, string) {
+	if sv.onErrorState != ErrorStateRetry || s.Source != JobSourceCommitStatus || s.State != errorState {
+		return false, ""
+	}
+	if sv.maxErrorStateRetries == 0 {
+		return true, fmt.Sprintf("%s is in error state, waiting for it to be retried", s.Job)
+	}
+
+	if sv.errorStatePolls == nil {
+		sv.errorStatePolls = make(map[string]int)
+	}
+	sv.errorStatePolls[s.Job]++
+	polls := sv.errorStatePolls[s.Job]
+	if polls > sv.maxErrorStateRetries {
+		return false, fmt.Sprintf("%s has remained in error state for %d polls, exceeding the %d tolerated", s.Job, polls, sv.maxErrorStateRetries)
+	}
+	return true, fmt.Sprintf("%s is in error state, waiting for it to be retried (%d/%d)", s.Job, polls, sv.maxErrorStateRetries)
+}
+
+// pruneErrorStatePolls forgets the polls counted for the jobs which are no longer in error state, so that the jobs
+// failing again after being retried are tolerated again.
+func (sv *statusValidator) pruneErrorStatePolls(ghaStatuses []*ghaStatus) {
+	for job := range sv.errorStatePolls {
+		var inError bool
+		for _, s := range ghaStatuses {
+			if s.Job == job && s.Source == JobSourceCommitStatus && s.State == errorState {
+				inError = true
+				break
+			}
+		}
+		if !inError {
+			delete(sv.errorStatePolls, job)
+		}
+	}
+}
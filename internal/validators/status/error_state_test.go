@@ -0,0 +1,102 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_errorStateRetries(t *testing.T) {
+	tests := map[string]struct {
+		retries   int
+		states    []string
+		wantFails []bool
+		wantNotes []string
+	}{
+		"fails once the job remains in error state for more polls than tolerated": {
+			retries:   2,
+			states:    []string{errorState, errorState, errorState},
+			wantFails: []bool{false, false, true},
+			wantNotes: []string{
+				"job-01 is in error state, waiting for it to be retried (1/2)",
+				"job-01 is in error state, waiting for it to be retried (2/2)",
+				"job-01 has remained in error state for 3 polls, exceeding the 2 tolerated",
+			},
+		},
+		"tolerates the job again once it has been retried": {
+			retries:   1,
+			states:    []string{errorState, pendingState, errorState},
+			wantFails: []bool{false, false, false},
+			wantNotes: []string{
+				"job-01 is in error state, waiting for it to be retried (1/1)",
+				"",
+				"job-01 is in error state, waiting for it to be retried (1/1)",
+			},
+		},
+		"waits for the job in error state until the timeout without the limit": {
+			retries:   0,
+			states:    []string{errorState, errorState, errorState},
+			wantFails: []bool{false, false, false},
+			wantNotes: []string{
+				"job-01 is in error state, waiting for it to be retried",
+				"job-01 is in error state, waiting for it to be retried",
+				"job-01 is in error state, waiting for it to be retried",
+			},
+		},
+		"fails immediately when the job is in failure state": {
+			retries:   2,
+			states:    []string{failureState},
+			wantFails: []bool{true},
+			wantNotes: []string{""},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var poll int
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("job-01"), State: stringPtr(tt.states[poll])},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("self-job"),
+				WithErrorStatePolicy(ErrorStateRetry),
+				WithErrorStateRetries(tt.retries),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			for poll = range tt.states {
+				st, err := v.Validate(context.Background())
+				if (err != nil) != tt.wantFails[poll] {
+					t.Fatalf("poll %d: statusValidator.Validate() error = %v, want failure %v", poll+1, err, tt.wantFails[poll])
+				}
+				var detail string
+				if err != nil {
+					detail = err.Error()
+				} else {
+					detail = st.Detail()
+				}
+				if want := tt.wantNotes[poll]; len(want) != 0 && !strings.Contains(detail, want) {
+					t.Errorf("poll %d: detail does not contain %q:\n%s", poll+1, want, detail)
+				}
+				if len(tt.wantNotes[poll]) == 0 && strings.Contains(detail, "error state") {
+					t.Errorf("poll %d: detail unexpectedly mentions the error state:\n%s", poll+1, detail)
+				}
+			}
+		})
+	}
+}
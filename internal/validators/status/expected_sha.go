@@ -0,0 +1,29 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// verifyExpectedSHA returns the error when the commit statuses or any check run of the validated ref are reported
+// for another commit than the expected one, so that the jobs of an unrelated commit are never taken for the jobs of
+// the head of the pull request. The commit statuses are only verified when any of them is reported, as GitHub
+// returns the commit of the ref even without any commit status.
+func (sv *statusValidator) verifyExpectedSHA(ref, statusesSHA string, runs []*github.CheckRun) error {
+	if len(sv.expectedSHA) == 0 {
+		return nil
+	}
+	if len(statusesSHA) != 0 && statusesSHA != sv.expectedSHA {
+		return fmt.Errorf("%w: commit statuses of ref %s are reported for %s, expected %s", ErrUnexpectedSHA, ref, statusesSHA, sv.expectedSHA)
+	}
+	for _, run := range runs {
+		if headSHA := run.GetHeadSHA(); headSHA != sv.expectedSHA {
+			if len(headSHA) == 0 {
+				headSHA = "none"
+			}
+			return fmt.Errorf("%w: check run %s of ref %s is reported for %s, expected %s", ErrUnexpectedSHA, run.GetName(), ref, headSHA, sv.expectedSHA)
+		}
+	}
+	return nil
+}
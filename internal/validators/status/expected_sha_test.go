@@ -0,0 +1,108 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_expectedSHA(t *testing.T) {
+	const (
+		headSHA  = "0123456789abcdef0123456789abcdef01234567"
+		otherSHA = "89abcdef0123456789abcdef0123456789abcdef"
+	)
+
+	tests := map[string]struct {
+		expectedSHA string
+		statusesSHA string
+		runSHA      string
+		noStatuses  bool
+		wantErr     string
+	}{
+		"succeeds when the jobs are reported for the expected sha": {
+			expectedSHA: headSHA,
+			statusesSHA: headSHA,
+			runSHA:      headSHA,
+		},
+		"fails when the commit statuses are reported for another sha": {
+			expectedSHA: headSHA,
+			statusesSHA: otherSHA,
+			runSHA:      headSHA,
+			wantErr:     "commit statuses of ref main are reported for " + otherSHA,
+		},
+		"fails when a check run is reported for another sha": {
+			expectedSHA: headSHA,
+			statusesSHA: headSHA,
+			runSHA:      otherSHA,
+			wantErr:     "check run test of ref main is reported for " + otherSHA,
+		},
+		"fails when a check run has no head sha": {
+			expectedSHA: headSHA,
+			statusesSHA: headSHA,
+			wantErr:     "check run test of ref main is reported for none",
+		},
+		"disregards the sha of the ref without any commit status": {
+			expectedSHA: headSHA,
+			statusesSHA: otherSHA,
+			runSHA:      headSHA,
+			noStatuses:  true,
+		},
+		"does not verify the shas without the expected sha": {
+			statusesSHA: otherSHA,
+			runSHA:      otherSHA,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					combined := &github.CombinedStatus{SHA: stringPtr(tt.statusesSHA)}
+					if !tt.noStatuses {
+						combined.Statuses = []*github.RepoStatus{
+							{Context: stringPtr("build"), State: stringPtr(successState)},
+						}
+					}
+					return combined, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					run := &github.CheckRun{
+						Name:       stringPtr("test"),
+						Status:     stringPtr("completed"),
+						Conclusion: stringPtr("success"),
+					}
+					if len(tt.runSHA) != 0 {
+						run.HeadSHA = stringPtr(tt.runSHA)
+					}
+					return &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{run}}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithExpectedSHA(tt.expectedSHA),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if len(tt.wantErr) != 0 {
+				if !errors.Is(err, ErrUnexpectedSHA) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if !got.IsSuccess() {
+				t.Errorf("Validate() IsSuccess() = false, want true")
+			}
+		})
+	}
+}
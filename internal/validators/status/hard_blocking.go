@@ -0,0 +1,10 @@
+package status
+
+// isHardBlockingFailure reports whether the job is a hard-blocking job which has failed. The state reported by the
+// job itself is used, so that the failure is not retried or tolerated as it may be for the other jobs.
+func (sv *statusValidator) isHardBlockingFailure(ghaStatus *ghaStatus) bool {
+	if !containsJob(sv.hardBlockingJobs, ghaStatus.Job) {
+		return false
+	}
+	return ghaStatus.State == errorState || ghaStatus.State == failureState
+}
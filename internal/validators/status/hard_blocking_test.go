@@ -0,0 +1,149 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+// settledDecider decides only once all the jobs are settled, so that a failure waits for the other jobs.
+type settledDecider struct{}
+
+func (settledDecider) Decide(statuses []JobStatus) (Decision, string) {
+	decision := DecisionSuccess
+	for _, st := range statuses {
+		switch st.State {
+		case successState:
+		case errorState, failureState:
+			if decision == DecisionSuccess {
+				decision = DecisionFailure
+			}
+		default:
+			return DecisionPending, "waiting for all the jobs to be settled"
+		}
+	}
+	return decision, ""
+}
+
+func Test_statusValidator_Validate_hardBlockingJobs(t *testing.T) {
+	tests := map[string]struct {
+		statuses    []*github.RepoStatus
+		opts        []Option
+		wantErr     bool
+		wantErrJobs []string
+		wantNote    string
+	}{
+		"keeps waiting when a job which is not hard-blocking fails while the others are pending": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("lint"), State: stringPtr(failureState)},
+				{Context: stringPtr("scan"), State: stringPtr(pendingState)},
+			},
+			opts:        []Option{WithDecider(settledDecider{})},
+			wantErrJobs: []string{"lint"},
+		},
+		"aborts when a hard-blocking job fails while the others are pending": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("lint"), State: stringPtr(pendingState)},
+				{Context: stringPtr("scan"), State: stringPtr(failureState)},
+			},
+			opts:        []Option{WithDecider(settledDecider{})},
+			wantErr:     true,
+			wantErrJobs: []string{"scan"},
+			wantNote:    "Hard-blocking job(s) failed, aborting: scan",
+		},
+		"keeps waiting when a job which is not hard-blocking is retried in error state": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("lint"), State: stringPtr(errorState)},
+				{Context: stringPtr("scan"), State: stringPtr(successState)},
+			},
+			opts:        []Option{WithErrorStatePolicy(ErrorStateRetry)},
+			wantErrJobs: []string{},
+			wantNote:    "lint is in error state, waiting for it to be retried",
+		},
+		"aborts without retrying when a hard-blocking job is in error state": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("lint"), State: stringPtr(pendingState)},
+				{Context: stringPtr("scan"), State: stringPtr(errorState)},
+			},
+			opts:        []Option{WithErrorStatePolicy(ErrorStateRetry)},
+			wantErr:     true,
+			wantErrJobs: []string{"scan"},
+			wantNote:    "Hard-blocking job(s) failed, aborting: scan",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{Statuses: tt.statuses}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			opts := append([]Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithHardBlockingJobs("scan,"),
+			}, tt.opts...)
+			v, err := CreateValidator(c, opts...)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			var st *status
+			if tt.wantErr {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() error = %v, want failure", err)
+				}
+				st = fe.status
+			} else {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want to keep waiting", err)
+				}
+				st = got.(*status)
+				if st.IsSuccess() {
+					t.Errorf("Validate() IsSuccess() = true, want false")
+				}
+			}
+			if !reflect.DeepEqual(st.errJobs, tt.wantErrJobs) {
+				t.Errorf("Validate() errJobs = %v, want %v", st.errJobs, tt.wantErrJobs)
+			}
+			if len(tt.wantNote) != 0 && !containsJob(st.notes, tt.wantNote) {
+				t.Errorf("Validate() notes = %v, want %q", st.notes, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestWithHardBlockingJobs(t *testing.T) {
+	tests := map[string]struct {
+		names string
+		want  []string
+	}{
+		"returns nil when names is empty": {
+			names: "",
+			want:  nil,
+		},
+		"trims the names and drops the empty ones": {
+			names: " scan, , e2e,",
+			want:  []string{"scan", "e2e"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{}
+			WithHardBlockingJobs(tt.names)(sv)
+			if !reflect.DeepEqual(sv.hardBlockingJobs, tt.want) {
+				t.Errorf("WithHardBlockingJobs() = %v, want %v", sv.hardBlockingJobs, tt.want)
+			}
+		})
+	}
+}
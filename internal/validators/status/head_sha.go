@@ -0,0 +1,41 @@
+package status
+
+import "fmt"
+
+// HeadSHAMismatchPolicy is the behavior for the check runs attached to another head SHA than the validated commit,
+// which the API may rarely return while it is eventually consistent.
+type HeadSHAMismatchPolicy string
+
+const (
+	// HeadSHAMismatchAllow validates the check runs regardless of their head SHAs. This is the default.
+	HeadSHAMismatchAllow HeadSHAMismatchPolicy = ""
+	// HeadSHAMismatchIgnore disregards the mismatched check runs as if they were not reported.
+	HeadSHAMismatchIgnore HeadSHAMismatchPolicy = "ignore"
+	// HeadSHAMismatchFail fails the jobs of the mismatched check runs.
+	HeadSHAMismatchFail HeadSHAMismatchPolicy = "fail"
+)
+
+func (p HeadSHAMismatchPolicy) validate() error {
+	switch p {
+	case HeadSHAMismatchAllow, HeadSHAMismatchIgnore, HeadSHAMismatchFail:
+		return nil
+	default:
+		return fmt.Errorf("invalid head sha mismatch policy: %q, must be either %q or %q", p, HeadSHAMismatchIgnore, HeadSHAMismatchFail)
+	}
+}
+
+// mismatchedHeadSHA returns the head SHA of the check run when it is not the commit of the ref, and thus the run is
+// most likely of a stale head. A run without the head SHA cannot be verified, and is regarded as mismatched as well.
+// The runs are only compared when the ref is a commit SHA, as a branch name may legitimately move.
+func (sv *statusValidator) mismatchedHeadSHA(ref, headSHA string) (string, bool) {
+	if sv.onHeadSHAMismatch == HeadSHAMismatchAllow || !commitSHAPattern.MatchString(ref) {
+		return "", false
+	}
+	if headSHA == ref {
+		return "", false
+	}
+	if len(headSHA) == 0 {
+		return "none", true
+	}
+	return headSHA, true
+}
@@ -0,0 +1,113 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_headSHAMismatch(t *testing.T) {
+	const (
+		sha      = "0123456789abcdef0123456789abcdef01234567"
+		staleSHA = "89abcdef0123456789abcdef0123456789abcdef"
+	)
+
+	tests := map[string]struct {
+		policy      HeadSHAMismatchPolicy
+		ref         string
+		headSHA     string
+		wantSuccess bool
+		wantFailure bool
+		wantPending bool
+		wantNote    string
+	}{
+		"counts the mismatched run by default": {
+			policy:      HeadSHAMismatchAllow,
+			ref:         sha,
+			headSHA:     staleSHA,
+			wantSuccess: true,
+		},
+		"disregards the mismatched run with the ignore policy": {
+			policy:      HeadSHAMismatchIgnore,
+			ref:         sha,
+			headSHA:     staleSHA,
+			wantPending: true,
+		},
+		"fails the mismatched run with the fail policy": {
+			policy:      HeadSHAMismatchFail,
+			ref:         sha,
+			headSHA:     staleSHA,
+			wantFailure: true,
+			wantNote:    "Check run build is attached to head SHA " + staleSHA + ", not the validated commit " + sha,
+		},
+		"fails the run without head sha with the fail policy": {
+			policy:      HeadSHAMismatchFail,
+			ref:         sha,
+			headSHA:     "",
+			wantFailure: true,
+			wantNote:    "Check run build is attached to head SHA none, not the validated commit " + sha,
+		},
+		"succeeds when the head sha matches with the fail policy": {
+			policy:      HeadSHAMismatchFail,
+			ref:         sha,
+			headSHA:     sha,
+			wantSuccess: true,
+		},
+		"does not compare when ref is not a commit sha": {
+			policy:      HeadSHAMismatchFail,
+			ref:         "main",
+			headSHA:     staleSHA,
+			wantSuccess: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), HeadSHA: stringPtr(tt.headSHA)},
+						},
+					}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef(tt.ref),
+				WithSelfJob("self-job"),
+				WithRequiredJobs("build"),
+				WithHeadSHAMismatchPolicy(tt.policy),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if tt.wantFailure {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() error = %v, want failure", err)
+				}
+				if !containsJob(fe.status.notes, tt.wantNote) {
+					t.Errorf("Validate() notes = %v, want %q", fe.status.notes, tt.wantNote)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("Validate() IsSuccess() = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+			if tt.wantPending && !containsJob(got.(*status).missingRequiredJobs, "build") {
+				t.Errorf("Validate() missing required jobs = %v, want the disregarded run", got.(*status).missingRequiredJobs)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+var (
+	ErrUnauthorized = errors.New("github token is invalid or expired")
+	ErrForbidden    = errors.New("github token does not have enough permission")
+
+	ErrInsufficientScope = errors.New("github token does not have the scope to post commit statuses")
+)
+
+// statusWriteScopes are the OAuth scopes allowing to create commit statuses.
+var statusWriteScopes = []string{"repo", "repo:status", "public_repo"}
+
+// statusWritePermissions are the permissions on the repository allowing to create commit statuses.
+var statusWritePermissions = []string{"push", "maintain", "admin"}
+
+// CheckAccess makes a minimal API call for the ref to verify the token and connectivity,
+// without evaluating whether the jobs are successful.
+func CheckAccess(ctx context.Context, c github.Client, opts ...Option) error {
+	_, _, err := checkAccess(ctx, c, opts...)
+	return err
+}
+
+// CheckPermissions verifies that the token can read the statuses of the ref before polling, and also post commit
+// statuses when write is set, so that a missing permission fails fast rather than at the end of the wait. The write
+// scope is verified for the tokens reporting their OAuth scopes, such as classic personal access tokens, and the
+// permissions on the repository are verified for the other tokens of users, such as fine-grained personal access
+// tokens. The tokens of GitHub Apps including GITHUB_TOKEN report neither, and thus they are not verified to write.
+func CheckPermissions(ctx context.Context, c github.Client, write bool, opts ...Option) error {
+	sv, resp, err := checkAccess(ctx, c, opts...)
+	if err != nil || !write {
+		return err
+	}
+
+	var header []string
+	var ok bool
+	if resp != nil && resp.Response != nil {
+		header, ok = resp.Header["X-Oauth-Scopes"]
+	}
+	if !ok {
+		return sv.checkWritePermission(ctx)
+	}
+	for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+		if containsJob(statusWriteScopes, strings.TrimSpace(scope)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w, any of %s is required but the token has %q", ErrInsufficientScope, strings.Join(statusWriteScopes, ", "), strings.Join(header, ","))
+}
+
+// checkWritePermission verifies the permissions of the token on the repository, which are only reported for the
+// tokens of users. Either of them allows to post commit statuses.
+func (sv *statusValidator) checkWritePermission(ctx context.Context) error {
+	repository, _, err := sv.client.GetRepository(ctx, sv.owner, sv.repo)
+	if err != nil {
+		return fmt.Errorf("failed to get permissions on %s/%s: %w", sv.owner, sv.repo, err)
+	}
+	permissions := repository.GetPermissions()
+	if len(permissions) == 0 {
+		return nil
+	}
+	for _, permission := range statusWritePermissions {
+		if permissions[permission] {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w to post commit statuses on %s/%s, any of %s is required", ErrForbidden, sv.owner, sv.repo, strings.Join(statusWritePermissions, ", "))
+}
+
+// checkAccess makes a minimal API call for the ref, and returns the response so that its headers can be inspected.
+// The head of the pull request is resolved first when the ref is not set.
+func checkAccess(ctx context.Context, c github.Client, opts ...Option) (*statusValidator, *github.Response, error) {
+	sv := &statusValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	if errs := sv.validateTargetFields(); len(errs) != 0 {
+		return nil, nil, errs
+	}
+	if len(sv.ref) == 0 {
+		if _, err := sv.resolvePullRequestHead(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	_, resp, err := sv.client.GetCombinedStatus(ctx, sv.owner, sv.repo, sv.targetRef(), &github.ListOptions{PerPage: 1})
+	if err == nil {
+		return sv, resp, nil
+	}
+	if resp == nil {
+		return nil, nil, fmt.Errorf("failed to connect to github: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return nil, nil, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	case http.StatusForbidden:
+		return nil, nil, fmt.Errorf("%w to read statuses of %s/%s: %v", ErrForbidden, sv.owner, sv.repo, err)
+	case http.StatusNotFound:
+		return nil, nil, sv.wrapRefError(sv.targetRef(), resp, err)
+	default:
+		return nil, nil, fmt.Errorf("failed to get combined status of %s/%s@%s: %w", sv.owner, sv.repo, sv.ref, err)
+	}
+}
@@ -0,0 +1,207 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func TestCheckAccess(t *testing.T) {
+	errResponse := func(code int) func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+		return func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return nil, &github.Response{Response: &http.Response{StatusCode: code}}, errors.New("err")
+		}
+	}
+	opts := []Option{
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("sha"),
+	}
+	tests := map[string]struct {
+		c         github.Client
+		opts      []Option
+		wantErr   bool
+		wantErrIs error
+	}{
+		"returns nil when the api call succeeds": {
+			c: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+			},
+			opts: opts,
+		},
+		"returns ErrUnauthorized when the token is invalid": {
+			c:         &mock.Client{GetCombinedStatusFunc: errResponse(http.StatusUnauthorized)},
+			opts:      opts,
+			wantErr:   true,
+			wantErrIs: ErrUnauthorized,
+		},
+		"returns ErrForbidden when the token lacks permission": {
+			c:         &mock.Client{GetCombinedStatusFunc: errResponse(http.StatusForbidden)},
+			opts:      opts,
+			wantErr:   true,
+			wantErrIs: ErrForbidden,
+		},
+		"returns ErrRefNotFound when the ref does not exist": {
+			c:         &mock.Client{GetCombinedStatusFunc: errResponse(http.StatusNotFound)},
+			opts:      opts,
+			wantErr:   true,
+			wantErrIs: ErrRefNotFound,
+		},
+		"returns error when the ref is empty": {
+			c:       &mock.Client{},
+			opts:    []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := CheckAccess(context.Background(), tt.c, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("CheckAccess() error = %v, want %v", err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+func TestCheckPermissions(t *testing.T) {
+	scopesResponse := func(scopes ...string) func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+		return func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			header := http.Header{}
+			for _, scope := range scopes {
+				header.Add("X-OAuth-Scopes", scope)
+			}
+			return &github.CombinedStatus{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK, Header: header}}, nil
+		}
+	}
+	permissionsResponse := func(permissions map[string]bool) func(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+		return func(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+			return &github.Repository{Permissions: permissions}, nil, nil
+		}
+	}
+	opts := []Option{
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("sha"),
+	}
+	tests := map[string]struct {
+		c         github.Client
+		write     bool
+		opts      []Option
+		wantErr   bool
+		wantErrIs error
+	}{
+		"returns nil when the token can read without writing": {
+			c:    &mock.Client{GetCombinedStatusFunc: scopesResponse("read:org")},
+			opts: opts,
+		},
+		"returns nil when the token has the repo scope": {
+			c:     &mock.Client{GetCombinedStatusFunc: scopesResponse("read:org, repo")},
+			write: true,
+			opts:  opts,
+		},
+		"returns nil when the token has the repo:status scope": {
+			c:     &mock.Client{GetCombinedStatusFunc: scopesResponse("repo:status")},
+			write: true,
+			opts:  opts,
+		},
+		"returns nil when the token does not report the scopes and can push": {
+			c: &mock.Client{
+				GetCombinedStatusFunc: scopesResponse(),
+				GetRepositoryFunc:     permissionsResponse(map[string]bool{"pull": true, "push": true}),
+			},
+			write: true,
+			opts:  opts,
+		},
+		"returns ErrForbidden when the token does not report the scopes and can only pull": {
+			c: &mock.Client{
+				GetCombinedStatusFunc: scopesResponse(),
+				GetRepositoryFunc:     permissionsResponse(map[string]bool{"pull": true}),
+			},
+			write:     true,
+			opts:      opts,
+			wantErr:   true,
+			wantErrIs: ErrForbidden,
+		},
+		"returns nil when the token reports neither the scopes nor the permissions": {
+			c: &mock.Client{
+				GetCombinedStatusFunc: scopesResponse(),
+				GetRepositoryFunc:     permissionsResponse(nil),
+			},
+			write: true,
+			opts:  opts,
+		},
+		"returns error when the permissions cannot be got": {
+			c: &mock.Client{
+				GetCombinedStatusFunc: scopesResponse(),
+				GetRepositoryFunc: func(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			write:   true,
+			opts:    opts,
+			wantErr: true,
+		},
+		"returns ErrInsufficientScope when the token lacks the scope to write": {
+			c:         &mock.Client{GetCombinedStatusFunc: scopesResponse("read:org")},
+			write:     true,
+			opts:      opts,
+			wantErr:   true,
+			wantErrIs: ErrInsufficientScope,
+		},
+		"returns ErrInsufficientScope when the token has no scope": {
+			c:         &mock.Client{GetCombinedStatusFunc: scopesResponse("")},
+			write:     true,
+			opts:      opts,
+			wantErr:   true,
+			wantErrIs: ErrInsufficientScope,
+		},
+		"returns ErrForbidden when the token cannot read": {
+			c: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}, errors.New("err")
+				},
+			},
+			write:     true,
+			opts:      opts,
+			wantErr:   true,
+			wantErrIs: ErrForbidden,
+		},
+		"resolves the head of the pull request when the ref is empty": {
+			c: &mock.Client{
+				GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+					return &github.PullRequest{Head: &github.PullRequestBranch{SHA: stringPtr("head-sha")}}, nil, nil
+				},
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					if ref != "head-sha" {
+						return nil, nil, errors.New("unexpected ref " + ref)
+					}
+					return &github.CombinedStatus{}, nil, nil
+				},
+				GetRepositoryFunc: permissionsResponse(map[string]bool{"push": true}),
+			},
+			write: true,
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithPullRequest(1),
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := CheckPermissions(context.Background(), tt.c, tt.write, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("CheckPermissions() error = %v, want %v", err, tt.wantErrIs)
+			}
+		})
+	}
+}
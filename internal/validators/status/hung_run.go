@@ -0,0 +1,15 @@
+package status
+
+import "time"
+
+// hungRunFor returns the duration for which the check run has been in progress beyond the hung threshold. A queued
+// run is merely waiting for a runner, so only the runs which have been picked up by a runner are regarded as hung.
+func (sv *statusValidator) hungRunFor(s *ghaStatus, runStatus string) time.Duration {
+	if sv.hungRunThreshold <= 0 || runStatus != checkRunInProgressStatus || s.StartedAt.IsZero() {
+		return 0
+	}
+	if elapsed := sv.now().Sub(s.StartedAt); elapsed > sv.hungRunThreshold {
+		return elapsed
+	}
+	return 0
+}
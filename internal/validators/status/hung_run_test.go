@@ -0,0 +1,91 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_hungRunThreshold(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	since := func(name, status string, d time.Duration) *github.CheckRun {
+		return &github.CheckRun{
+			Name:      stringPtr(name),
+			Status:    stringPtr(status),
+			StartedAt: &github.Timestamp{Time: now.Add(-d)},
+		}
+	}
+
+	tests := map[string]struct {
+		checkRuns []*github.CheckRun
+		wantErr   bool
+		wantNote  string
+	}{
+		"keeps waiting while the runs in progress are within the threshold": {
+			checkRuns: []*github.CheckRun{
+				since("build", checkRunInProgressStatus, 50*time.Minute),
+				since("lint", checkRunQueuedStatus, 2*time.Hour),
+			},
+			wantErr: false,
+		},
+		"returns error when a run has been in progress beyond the threshold": {
+			checkRuns: []*github.CheckRun{
+				since("build", checkRunInProgressStatus, 50*time.Minute),
+				since("e2e", checkRunInProgressStatus, 3*time.Hour),
+			},
+			wantErr:  true,
+			wantNote: "e2e has been in progress for 3h0m0s, exceeding the hung threshold of 1h0m0s",
+		},
+		"keeps waiting when a run has been queued beyond the threshold": {
+			checkRuns: []*github.CheckRun{
+				since("e2e", checkRunQueuedStatus, 3*time.Hour),
+			},
+			wantErr: false,
+		},
+		"keeps waiting when the start time of a run in progress is unknown": {
+			checkRuns: []*github.CheckRun{
+				{Name: stringPtr("e2e"), Status: stringPtr(checkRunInProgressStatus)},
+			},
+			wantErr: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := CreateValidator(&mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithHungRunThreshold(time.Hour),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+			v.(*statusValidator).clock = func() time.Time { return now }
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.wantNote) {
+					t.Errorf("statusValidator.Validate() error does not contain %q:\n%s", tt.wantNote, err.Error())
+				}
+				return
+			}
+			if got.IsSuccess() {
+				t.Errorf("statusValidator.Validate() IsSuccess() = true, want false")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+)
+
+// jobCountLimit is the maximum number of the jobs which a pattern may match, such as the shards of a test suite.
+type jobCountLimit struct {
+	pattern jobPattern
+	max     int
+}
+
+// compileJobCountLimits parses the patterns of the job count limits in their order, so that the error and the notes
+// are stable.
+func (sv *statusValidator) compileJobCountLimits() error {
+	if len(sv.jobCountLimitPatterns) == 0 {
+		return nil
+	}
+	patterns := make([]string, 0, len(sv.jobCountLimitPatterns))
+	for pattern := range sv.jobCountLimitPatterns {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	sv.jobCountLimits = make([]jobCountLimit, 0, len(patterns))
+	for _, pattern := range patterns {
+		p, err := parseJobPattern(pattern)
+		if err != nil {
+			return err
+		}
+		max := sv.jobCountLimitPatterns[pattern]
+		if max <= 0 {
+			return fmt.Errorf("maximum number of jobs matching %s must be positive: %d", pattern, max)
+		}
+		sv.jobCountLimits = append(sv.jobCountLimits, jobCountLimit{pattern: p, max: max})
+	}
+	return nil
+}
+
+// exceededJobCountNotes returns the notes for the patterns which more jobs match than allowed, which most likely
+// indicates a runaway matrix of shards rather than a real change of the jobs.
+func (sv *statusValidator) exceededJobCountNotes(jobStatuses []JobStatus) []string {
+	var notes []string
+	for _, l := range sv.jobCountLimits {
+		var n int
+		for _, js := range jobStatuses {
+			if l.pattern.match(js.Job) {
+				n++
+			}
+		}
+		if n > l.max {
+			notes = append(notes, fmt.Sprintf("%d jobs match %s, more than the allowed %d", n, l.pattern.pattern, l.max))
+		}
+	}
+	return notes
+}
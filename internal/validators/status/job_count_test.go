@@ -0,0 +1,88 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_maxMatchingJobs(t *testing.T) {
+	shards := func(states ...string) []*github.RepoStatus {
+		statuses := []*github.RepoStatus{
+			{Context: stringPtr("self-job"), State: stringPtr(pendingState)},
+			{Context: stringPtr("build"), State: stringPtr(successState)},
+		}
+		for i, state := range states {
+			statuses = append(statuses, &github.RepoStatus{Context: stringPtr(fmt.Sprintf("test-shard-%d", i+1)), State: stringPtr(state)})
+		}
+		return statuses
+	}
+
+	tests := map[string]struct {
+		statuses    []*github.RepoStatus
+		wantSuccess bool
+		wantPending bool
+		wantNote    string
+	}{
+		"succeeds when all the shards within the cap have succeeded": {
+			statuses:    shards(successState, successState, successState),
+			wantSuccess: true,
+		},
+		"waits for the pending shards within the cap": {
+			statuses:    shards(successState, pendingState, successState),
+			wantPending: true,
+		},
+		"fails on a failed shard within the cap": {
+			statuses: shards(successState, errorState, pendingState),
+		},
+		"fails without waiting when more shards than allowed are reported": {
+			statuses: shards(successState, pendingState, successState, pendingState),
+			wantNote: "4 jobs match test-shard-*, more than the allowed 3",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{Statuses: tt.statuses}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithRequiredJobs("test-shard-*"),
+				WithMaxMatchingJobs(map[string]int{"test-shard-*": 3}),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if tt.wantSuccess || tt.wantPending {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				if got.IsSuccess() != tt.wantSuccess {
+					t.Errorf("Validate() IsSuccess() = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+				}
+				return
+			}
+
+			var fe *failureError
+			if !errors.As(err, &fe) {
+				t.Fatalf("Validate() error = %v, want failure", err)
+			}
+			if len(tt.wantNote) != 0 && !containsJob(fe.status.notes, tt.wantNote) {
+				t.Errorf("Validate() notes = %v, want %q", fe.status.notes, tt.wantNote)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package status
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JobListSource is one of the places which the ignored or required jobs are given by, such as a flag, an environment
+// variable, the required checks file or the branch protection.
+type JobListSource struct {
+	Name string
+	Jobs []string
+}
+
+// ResolvedJobLists are the ignored and required jobs merged from all the sources.
+type ResolvedJobLists struct {
+	Ignored  []string
+	Required []string
+
+	// Overridden are the ignored jobs which are validated anyway, as they are required by a source.
+	Overridden []string
+
+	ignoredFrom  map[string][]string
+	requiredFrom map[string][]string
+}
+
+// ResolveJobLists merges the ignored jobs and the required job patterns given by the sources, which are ordered by
+// their precedence. The ignored jobs are the union of all the sources, and so are the required patterns. The jobs are
+// kept in the order in which they first appear, and duplicates are dropped.
+//
+// A job which is both ignored and required is validated, because a required job must never be bypassed by an ignore
+// list of another source. It is reported as overridden rather than silently dropped.
+func ResolveJobLists(ignored, required []JobListSource) (*ResolvedJobLists, error) {
+	l := &ResolvedJobLists{
+		Ignored:      []string{},
+		Required:     []string{},
+		Overridden:   []string{},
+		ignoredFrom:  make(map[string][]string),
+		requiredFrom: make(map[string][]string),
+	}
+
+	patterns := make([]jobPattern, 0)
+	for _, src := range required {
+		for _, job := range src.Jobs {
+			if job = strings.TrimSpace(job); len(job) == 0 {
+				continue
+			}
+			if _, ok := l.requiredFrom[job]; !ok {
+				p, err := parseJobPattern(job)
+				if err != nil {
+					return nil, fmt.Errorf("invalid required job from %s: %w", src.Name, err)
+				}
+				patterns = append(patterns, p)
+				l.Required = append(l.Required, job)
+			}
+			l.requiredFrom[job] = appendSource(l.requiredFrom[job], src.Name)
+		}
+	}
+
+	for _, src := range ignored {
+		for _, job := range src.Jobs {
+			if job = strings.TrimSpace(job); len(job) == 0 {
+				continue
+			}
+			if _, ok := l.ignoredFrom[job]; !ok {
+				if matchesAnyPattern(patterns, job) {
+					l.Overridden = append(l.Overridden, job)
+				} else {
+					l.Ignored = append(l.Ignored, job)
+				}
+			}
+			l.ignoredFrom[job] = appendSource(l.ignoredFrom[job], src.Name)
+		}
+	}
+	return l, nil
+}
+
+// LoadIgnoredJobs reads the ignored jobs file, which has a job name per line, so that the ignored jobs can be declared
+// in the repository along with the required checks file. Blank lines and lines starting with "#" are skipped.
+func LoadIgnoredJobs(r io.Reader) ([]string, error) {
+	var jobs []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		job := strings.TrimSpace(scanner.Text())
+		if len(job) == 0 || strings.HasPrefix(job, "#") {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ignored jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func appendSource(sources []string, name string) []string {
+	if containsJob(sources, name) {
+		return sources
+	}
+	return append(sources, name)
+}
+
+func matchesAnyPattern(patterns []jobPattern, job string) bool {
+	for _, p := range patterns {
+		if p.match(job) {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary returns the lines describing the resolved lists along with the sources of each job, e.g.
+// "Ignored jobs: docs (flag, env)", so that which list takes effect is visible at startup.
+func (l *ResolvedJobLists) Summary() []string {
+	lines := []string{
+		"Ignored jobs: " + l.describe(l.Ignored, l.ignoredFrom),
+		"Required jobs: " + l.describe(l.Required, l.requiredFrom),
+	}
+	if len(l.Overridden) != 0 {
+		lines = append(lines, "Ignored but required jobs, which are validated: "+l.describe(l.Overridden, l.ignoredFrom))
+	}
+	return lines
+}
+
+// IgnoredBy returns the names of the sources which have ignored the job, in the order of their precedence.
+func (l *ResolvedJobLists) IgnoredBy(job string) []string {
+	return append([]string{}, l.ignoredFrom[job]...)
+}
+
+// RequiredBy returns the names of the sources which have required the job pattern, in the order of their precedence.
+func (l *ResolvedJobLists) RequiredBy(pattern string) []string {
+	return append([]string{}, l.requiredFrom[pattern]...)
+}
+
+func (l *ResolvedJobLists) describe(jobs []string, from map[string][]string) string {
+	if len(jobs) == 0 {
+		return "none"
+	}
+	described := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		described = append(described, fmt.Sprintf("%s (%s)", job, strings.Join(from[job], ", ")))
+	}
+	return strings.Join(described, ", ")
+}
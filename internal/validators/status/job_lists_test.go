@@ -0,0 +1,105 @@
+package status
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveJobLists(t *testing.T) {
+	tests := map[string]struct {
+		ignored        []JobListSource
+		required       []JobListSource
+		wantIgnored    []string
+		wantRequired   []string
+		wantOverridden []string
+		wantSummary    []string
+		wantErr        bool
+	}{
+		"returns empty lists without any jobs": {
+			ignored:        []JobListSource{{Name: "flag", Jobs: []string{""}}},
+			required:       []JobListSource{{Name: "flag"}},
+			wantIgnored:    []string{},
+			wantRequired:   []string{},
+			wantOverridden: []string{},
+			wantSummary:    []string{"Ignored jobs: none", "Required jobs: none"},
+		},
+		"merges the jobs of all the sources in the order of precedence": {
+			ignored: []JobListSource{
+				{Name: "flag", Jobs: []string{"docs", " lint "}},
+				{Name: "env", Jobs: []string{"lint", "stale"}},
+			},
+			required: []JobListSource{
+				{Name: "flag", Jobs: []string{"build"}},
+				{Name: "file", Jobs: []string{"e2e-*", "build"}},
+				{Name: "protection of main", Jobs: []string{"build", "test"}},
+			},
+			wantIgnored:    []string{"docs", "lint", "stale"},
+			wantRequired:   []string{"build", "e2e-*", "test"},
+			wantOverridden: []string{},
+			wantSummary: []string{
+				"Ignored jobs: docs (flag), lint (flag, env), stale (env)",
+				"Required jobs: build (flag, file, protection of main), e2e-* (file), test (protection of main)",
+			},
+		},
+		"validates the ignored jobs which are required by another source": {
+			ignored: []JobListSource{
+				{Name: "flag", Jobs: []string{"docs", "e2e-linux"}},
+				{Name: "env", Jobs: []string{"test"}},
+			},
+			required: []JobListSource{
+				{Name: "file", Jobs: []string{"e2e-*"}},
+				{Name: "protection of main", Jobs: []string{"test"}},
+			},
+			wantIgnored:    []string{"docs"},
+			wantRequired:   []string{"e2e-*", "test"},
+			wantOverridden: []string{"e2e-linux", "test"},
+			wantSummary: []string{
+				"Ignored jobs: docs (flag)",
+				"Required jobs: e2e-* (file), test (protection of main)",
+				"Ignored but required jobs, which are validated: e2e-linux (flag), test (env)",
+			},
+		},
+		"returns error when a required pattern is invalid": {
+			required: []JobListSource{{Name: "env", Jobs: []string{"e2e-["}}},
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveJobLists(tt.ignored, tt.required)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveJobLists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Ignored, tt.wantIgnored) {
+				t.Errorf("ResolveJobLists() Ignored = %v, want %v", got.Ignored, tt.wantIgnored)
+			}
+			if !reflect.DeepEqual(got.Required, tt.wantRequired) {
+				t.Errorf("ResolveJobLists() Required = %v, want %v", got.Required, tt.wantRequired)
+			}
+			if !reflect.DeepEqual(got.Overridden, tt.wantOverridden) {
+				t.Errorf("ResolveJobLists() Overridden = %v, want %v", got.Overridden, tt.wantOverridden)
+			}
+			if summary := got.Summary(); !reflect.DeepEqual(summary, tt.wantSummary) {
+				t.Errorf("ResolvedJobLists.Summary() = %q, want %q", summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestLoadIgnoredJobs(t *testing.T) {
+	got, err := LoadIgnoredJobs(strings.NewReader(`# Ignored jobs
+docs
+
+  bot/flaky
+`))
+	if err != nil {
+		t.Fatalf("LoadIgnoredJobs() error = %v", err)
+	}
+	if want := []string{"docs", "bot/flaky"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadIgnoredJobs() = %v, want %v", got, want)
+	}
+}
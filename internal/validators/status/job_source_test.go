@@ -0,0 +1,105 @@
+package status
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_jobSources(t *testing.T) {
+	successRun := func(name string) *github.CheckRun {
+		return &github.CheckRun{Name: stringPtr(name), Status: stringPtr("completed"), Conclusion: stringPtr("success")}
+	}
+	pendingRun := func(name string) *github.CheckRun {
+		return &github.CheckRun{Name: stringPtr(name), Status: stringPtr("in_progress")}
+	}
+
+	tests := map[string]struct {
+		statuses     []*github.RepoStatus
+		runs         []*github.CheckRun
+		wantTotal    []string
+		wantComplete []string
+		wantSuccess  bool
+	}{
+		"succeeds without any job from either source": {
+			wantTotal:    []string{},
+			wantComplete: []string{},
+			wantSuccess:  true,
+		},
+		"counts the check runs alone when no commit status is reported": {
+			runs:         []*github.CheckRun{successRun("build"), pendingRun("test")},
+			wantTotal:    []string{"build", "test"},
+			wantComplete: []string{"build"},
+		},
+		"counts the commit statuses alone when no check run is reported": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("ci/external"), State: stringPtr(successState)},
+			},
+			wantTotal:    []string{"ci/external"},
+			wantComplete: []string{"ci/external"},
+			wantSuccess:  true,
+		},
+		"counts both sources together": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("ci/external"), State: stringPtr(successState)},
+			},
+			runs:         []*github.CheckRun{successRun("build"), successRun("test")},
+			wantTotal:    []string{"build", "ci/external", "test"},
+			wantComplete: []string{"build", "ci/external", "test"},
+			wantSuccess:  true,
+		},
+		"counts a job reported by both sources only once": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+			},
+			runs:         []*github.CheckRun{successRun("build")},
+			wantTotal:    []string{"build"},
+			wantComplete: []string{"build"},
+			wantSuccess:  true,
+		},
+		"does not count the self job as the only check run": {
+			runs:         []*github.CheckRun{pendingRun("self-job")},
+			wantTotal:    []string{},
+			wantComplete: []string{},
+			wantSuccess:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{Statuses: tt.statuses}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: tt.runs}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			st := got.(*status)
+			if !reflect.DeepEqual(st.totalJobs, tt.wantTotal) {
+				t.Errorf("Validate() total jobs = %v, want %v", st.totalJobs, tt.wantTotal)
+			}
+			if !reflect.DeepEqual(st.completeJobs, tt.wantComplete) {
+				t.Errorf("Validate() complete jobs = %v, want %v", st.completeJobs, tt.wantComplete)
+			}
+			if st.IsSuccess() != tt.wantSuccess {
+				t.Errorf("Validate() IsSuccess() = %v, want %v", st.IsSuccess(), tt.wantSuccess)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package status
+
+import (
+	"context"
+	"time"
+)
+
+// JobSource is the kind of the GitHub API object which a job status is collected from.
+type JobSource string
+
+const (
+	JobSourceCommitStatus JobSource = "commit_status"
+	JobSourceCheckRun     JobSource = "check_run"
+	JobSourceDeployment   JobSource = "deployment"
+)
+
+// JobStatus is the state of a job collected from the GitHub API.
+type JobStatus struct {
+	Job   string
+	State string
+
+	Source JobSource
+
+	// DetailsURL is the details URL of a check run, or the target URL of a commit status.
+	DetailsURL string
+
+	// The following fields are only populated for check runs, except that the times are also populated for deployments.
+	CheckSuiteID int64
+	App          string
+	StartedAt    time.Time
+	CompletedAt  time.Time
+
+	// Unknown describes the state value which could not be mapped, e.g. `conclusion "foo"`.
+	Unknown string
+
+	// Tolerated is the failing conclusion which is tolerated for the job, and thus mapped to success.
+	Tolerated string
+
+	// Attempts is the number of the check runs of the same name, which are the reruns of the job. It is only
+	// populated for check runs which have been rerun, and the latest attempt is always the one used.
+	Attempts int
+
+	// origin is the collected status which the status is handed to the transformer for.
+	origin *ghaStatus
+}
+
+// JobStatusLister lists the raw job statuses instead of the summarized result, for callers building their own
+// reports. The validator created by CreateValidator implements it.
+type JobStatusLister interface {
+	ListJobStatuses(ctx context.Context) ([]JobStatus, error)
+}
+
+var _ JobStatusLister = (*statusValidator)(nil)
+
+// ListJobStatuses returns the latest statuses of all the jobs for the target ref, including the ignored jobs
+// and the self job.
+func (sv *statusValidator) ListJobStatuses(ctx context.Context) ([]JobStatus, error) {
+	if _, err := sv.resolvePullRequestHead(ctx); err != nil {
+		return nil, err
+	}
+	if err := sv.resolveCommitSHA(ctx); err != nil {
+		return nil, err
+	}
+
+	ghaStatuses, err := sv.listGhaStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobStatuses := make([]JobStatus, 0, len(ghaStatuses))
+	for _, ghaStatus := range ghaStatuses {
+		jobStatuses = append(jobStatuses, ghaStatus.jobStatus())
+	}
+	return jobStatuses, nil
+}
+
+func (s *ghaStatus) jobStatus() JobStatus {
+	return JobStatus{
+		Job:          s.Job,
+		State:        s.State,
+		Source:       s.Source,
+		CheckSuiteID: s.CheckSuiteID,
+		App:          s.App,
+		DetailsURL:   s.DetailsURL,
+		StartedAt:    s.StartedAt,
+		CompletedAt:  s.CompletedAt,
+		Unknown:      s.Unknown,
+		Tolerated:    s.Tolerated,
+		Attempts:     s.Attempts,
+	}
+}
@@ -0,0 +1,86 @@
+package status
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_ListJobStatuses(t *testing.T) {
+	startedAt := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	sv := &statusValidator{
+		owner:       "test-owner",
+		repo:        "test-repo",
+		ref:         "main",
+		selfJobName: "self-job",
+		ignoredJobs: []string{"job-02"},
+		client: &mock.Client{
+			GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+				return &github.CombinedStatus{
+					Statuses: []*github.RepoStatus{
+						{
+							Context: stringPtr("job-01"),
+							State:   stringPtr(successState),
+						},
+						{
+							Context: stringPtr("self-job"),
+							State:   stringPtr(pendingState),
+						},
+					},
+				}, nil, nil
+			},
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{
+					CheckRuns: []*github.CheckRun{
+						{
+							Name:        stringPtr("job-02"),
+							Status:      stringPtr(checkRunCompletedStatus),
+							Conclusion:  stringPtr(checkRunFailureConclusion),
+							CheckSuite:  &github.CheckSuite{ID: int64Ptr(1)},
+							App:         &github.App{Slug: stringPtr("github-actions")},
+							DetailsURL:  stringPtr("https://example.com/job-02"),
+							StartedAt:   &github.Timestamp{Time: startedAt},
+							CompletedAt: &github.Timestamp{Time: startedAt.Add(time.Minute)},
+						},
+					},
+				}, nil, nil
+			},
+		},
+	}
+
+	want := []JobStatus{
+		{
+			Job:    "job-01",
+			State:  successState,
+			Source: JobSourceCommitStatus,
+		},
+		{
+			Job:    "self-job",
+			State:  pendingState,
+			Source: JobSourceCommitStatus,
+		},
+		{
+			Job:          "job-02",
+			State:        errorState,
+			Source:       JobSourceCheckRun,
+			CheckSuiteID: 1,
+			App:          "github-actions",
+			DetailsURL:   "https://example.com/job-02",
+			StartedAt:    startedAt,
+			CompletedAt:  startedAt.Add(time.Minute),
+		},
+	}
+
+	var lister JobStatusLister = sv
+	got, err := lister.ListJobStatuses(context.Background())
+	if err != nil {
+		t.Fatalf("statusValidator.ListJobStatuses() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("statusValidator.ListJobStatuses() = %v, want %v", got, want)
+	}
+}
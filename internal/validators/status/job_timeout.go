@@ -0,0 +1,33 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// validateJobTimeouts validates the per-job timeouts, which must be positive.
+func (sv *statusValidator) validateJobTimeouts() error {
+	jobs := make([]string, 0, len(sv.jobTimeouts))
+	for job := range sv.jobTimeouts {
+		jobs = append(jobs, job)
+	}
+	sort.Strings(jobs)
+	for _, job := range jobs {
+		if sv.jobTimeouts[job] <= 0 {
+			return fmt.Errorf("timeout of job %s must be positive: %s", job, sv.jobTimeouts[job])
+		}
+	}
+	return nil
+}
+
+// exceededJobTimeout returns the elapsed time of the job when it is still running beyond its own timeout.
+// Jobs without a start time, such as the ones reported by commit statuses, never exceed their timeouts.
+func (sv *statusValidator) exceededJobTimeout(s *ghaStatus) (time.Duration, bool) {
+	timeout, ok := sv.jobTimeouts[s.Job]
+	if !ok || s.State != pendingState || s.StartedAt.IsZero() {
+		return 0, false
+	}
+	elapsed := sv.now().Sub(s.StartedAt)
+	return elapsed, elapsed > timeout
+}
@@ -0,0 +1,96 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_jobTimeouts(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	runningSince := func(name string, d time.Duration) *github.CheckRun {
+		return &github.CheckRun{
+			Name:      stringPtr(name),
+			Status:    stringPtr("in_progress"),
+			StartedAt: &github.Timestamp{Time: now.Add(-d)},
+		}
+	}
+	timeouts := map[string]time.Duration{
+		"e2e":  30 * time.Minute,
+		"lint": 5 * time.Minute,
+	}
+
+	tests := map[string]struct {
+		checkRuns []*github.CheckRun
+		wantErr   bool
+		wantNote  string
+	}{
+		"keeps waiting when all jobs are within their own timeouts": {
+			checkRuns: []*github.CheckRun{
+				runningSince("e2e", 20*time.Minute),
+				runningSince("lint", 3*time.Minute),
+			},
+			wantErr: false,
+		},
+		"returns error when a quick job exceeds its own timeout while a slow job is within its timeout": {
+			checkRuns: []*github.CheckRun{
+				runningSince("e2e", 20*time.Minute),
+				runningSince("lint", 10*time.Minute),
+			},
+			wantErr:  true,
+			wantNote: "lint has been running for 10m0s, exceeding its timeout of 5m0s",
+		},
+		"keeps waiting when a job without its own timeout runs long": {
+			checkRuns: []*github.CheckRun{
+				runningSince("build", 2*time.Hour),
+				runningSince("lint", 3*time.Minute),
+			},
+			wantErr: false,
+		},
+		"keeps waiting when the start time of a job is unknown": {
+			checkRuns: []*github.CheckRun{
+				{Name: stringPtr("lint"), Status: stringPtr("queued")},
+			},
+			wantErr: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := CreateValidator(&mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: tt.checkRuns}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithJobTimeouts(timeouts),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+			v.(*statusValidator).clock = func() time.Time { return now }
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.wantNote) {
+					t.Errorf("statusValidator.Validate() error does not contain %q:\n%s", tt.wantNote, err.Error())
+				}
+				return
+			}
+			if got.IsSuccess() {
+				t.Errorf("statusValidator.Validate() IsSuccess() = true, want false")
+			}
+		})
+	}
+}
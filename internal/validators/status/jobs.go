@@ -0,0 +1,39 @@
+package status
+
+import (
+	"context"
+	"sort"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/multierror"
+)
+
+// ListJobs returns the distinct jobs reported for the ref sorted by name, along with their latest states and sources.
+// It is meant for discovering the exact job names to build the ignored and required job lists. Only the check runs of
+// the status are listed when it is set, either "queued", "in_progress" or "completed", which reduces the payload when
+// e.g. only the jobs still running are of interest. The commit statuses are listed regardless.
+func ListJobs(ctx context.Context, c github.Client, checkRunStatus string, opts ...Option) ([]JobStatus, error) {
+	sv := &statusValidator{
+		client:               c,
+		checkRunStatusFilter: checkRunStatus,
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	errs := sv.validateTargetFields()
+	if err := sv.validateCheckRunStatusFilter(); err != nil {
+		errs = append(errs, multierror.Categorize(multierror.CategoryConfig, err))
+	}
+	if len(errs) != 0 {
+		return nil, errs
+	}
+
+	jobStatuses, err := sv.ListJobStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(jobStatuses, func(i, j int) bool {
+		return jobStatuses[i].Job < jobStatuses[j].Job
+	})
+	return jobStatuses, nil
+}
@@ -0,0 +1,122 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func TestListJobs(t *testing.T) {
+	opts := []Option{
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("sha"),
+	}
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		want    []JobStatus
+		wantErr bool
+	}{
+		"returns the distinct jobs sorted by name": {
+			c: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("lint"), State: stringPtr(successState)},
+							{Context: stringPtr("lint"), State: stringPtr(errorState)},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{Name: stringPtr("build"), Status: stringPtr("in_progress")},
+						},
+					}, nil, nil
+				},
+			},
+			opts: opts,
+			want: []JobStatus{
+				{Job: "build", State: pendingState, Source: JobSourceCheckRun},
+				{Job: "lint", State: successState, Source: JobSourceCommitStatus},
+			},
+		},
+		"returns error when the statuses cannot be fetched": {
+			c: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			opts:    opts,
+			wantErr: true,
+		},
+		"returns error when the ref is empty": {
+			c:       &mock.Client{},
+			opts:    []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ListJobs(context.Background(), tt.c, "", tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListJobs_checkRunStatusFilter(t *testing.T) {
+	tests := map[string]struct {
+		filter     string
+		wantFilter *string
+		wantErr    bool
+	}{
+		"forwards the status filter": {
+			filter:     "in_progress",
+			wantFilter: stringPtr("in_progress"),
+		},
+		"lists all the check runs without the status filter": {
+			filter:     "",
+			wantFilter: nil,
+		},
+		"returns error when the status filter is invalid": {
+			filter:  "pending",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []*string
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					got = append(got, opts.Status)
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			_, err := ListJobs(context.Background(), c, tt.filter,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != 1 || !reflect.DeepEqual(got[0], tt.wantFilter) {
+				t.Errorf("ListCheckRunsForRef() status filters = %v, want %v", got, tt.wantFilter)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package status
 
-import "strings"
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
 
 type Option func(s *statusValidator)
 
@@ -12,6 +17,457 @@ func WithSelfJob(name string) Option {
 	}
 }
 
+// WithIgnoreSelfCheckSuite makes the validator ignore all the check runs in the same check suite as the gatekeeper.
+func WithIgnoreSelfCheckSuite(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.ignoreSelfSuite = enabled
+	}
+}
+
+// WithSelfWorkflowRunID sets the workflow run ID of the gatekeeper, which is used to detect its own check suite
+// even when the check run name does not match with the self job name.
+func WithSelfWorkflowRunID(id string) Option {
+	return func(s *statusValidator) {
+		if len(id) != 0 {
+			s.selfRunID = id
+		}
+	}
+}
+
+// WithStrictStates makes the validator fail when any job is in a state which the validator does not recognise,
+// rather than guessing whether it should be regarded as success or failure.
+func WithStrictStates(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.strictStates = enabled
+	}
+}
+
+// WithTimingReport reports the time spent by each job, such as how long it has been queued and run, so that the jobs
+// on the critical path can be found.
+func WithTimingReport(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.timingReport = enabled
+	}
+}
+
+// WithFailingOnlyReport makes the status detail list only the failed and incomplete jobs.
+func WithFailingOnlyReport(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.failingOnly = enabled
+	}
+}
+
+// WithSummaryTemplate overrides the summary at the top of the detail with the given text/template,
+// which is executed with SummaryData. DefaultSummaryTemplate is used when it is empty.
+func WithSummaryTemplate(text string) Option {
+	return func(s *statusValidator) {
+		if len(text) != 0 {
+			s.summaryTemplateText = text
+		}
+	}
+}
+
+// WithPostSuccessReverify makes the validator re-validate after the given duration once all jobs are green,
+// and only succeed when the result is sustained.
+func WithPostSuccessReverify(d time.Duration) Option {
+	return func(s *statusValidator) {
+		s.reverifyAfter = d
+	}
+}
+
+// WithMinCommitAge makes the validator succeed only once the validated commit is at least as old as the given
+// duration, so that all the checks of a freshly pushed commit have registered. The validation keeps polling meanwhile.
+func WithMinCommitAge(d time.Duration) Option {
+	return func(s *statusValidator) {
+		s.minCommitAge = d
+	}
+}
+
+// WithStableConsecutivePolls makes the validator succeed only when the all-green result is identical for the given
+// number of consecutive polls, which defends against late-arriving jobs and flapping states.
+func WithStableConsecutivePolls(n int) Option {
+	return func(s *statusValidator) {
+		s.stablePolls = n
+	}
+}
+
+// WithJobSetShrinkPolicy sets the behavior when the number of jobs decreases between polls, which usually means
+// a force-push has replaced the checks.
+func WithJobSetShrinkPolicy(p JobSetShrinkPolicy) Option {
+	return func(s *statusValidator) {
+		s.onJobSetShrink = p
+	}
+}
+
+// WithFailOnRegression fails the validation as soon as a job which has succeeded in a previous poll no longer does,
+// e.g. as it has been rerun, instead of waiting for it to complete again. The regressions are reported regardless.
+func WithFailOnRegression(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.failOnRegression = enabled
+	}
+}
+
+// WithExpectedSHA makes the validation fail when the commit statuses or any check run of the validated ref are
+// reported for another commit than the given one, such as the head of the pull request known to the caller. The SHA
+// must be a full commit SHA.
+func WithExpectedSHA(sha string) Option {
+	return func(s *statusValidator) {
+		if len(sha) != 0 {
+			s.expectedSHA = sha
+		}
+	}
+}
+
+// WithHeadSHAMismatchPolicy sets the behavior for the check runs attached to another head SHA than the validated
+// commit, so that the checks of a stale head are not counted. The runs are only compared when the ref is resolved
+// to a commit SHA.
+func WithHeadSHAMismatchPolicy(p HeadSHAMismatchPolicy) Option {
+	return func(s *statusValidator) {
+		s.onHeadSHAMismatch = p
+	}
+}
+
+// WithErrorStatePolicy sets the behavior for the commit statuses in error state, so that infrastructure problems can
+// be retried while genuine failures in failure state still fail the validation.
+func WithErrorStatePolicy(p ErrorStatePolicy) Option {
+	return func(s *statusValidator) {
+		s.onErrorState = p
+	}
+}
+
+// WithErrorStateRetries limits the polls for which a job in error state is waited to be retried with ErrorStateRetry.
+// The job fails once it remains in error state for more polls, while 0 means it is waited until the timeout.
+func WithErrorStateRetries(n int) Option {
+	return func(s *statusValidator) {
+		s.maxErrorStateRetries = n
+	}
+}
+
+// WithSkipUnchangedPolls reuses the previous result without recomputing while the jobs are pending and the responses
+// are unchanged. It requires the client to use the ETag cache, so that unchanged responses can be detected.
+func WithSkipUnchangedPolls(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.skipUnchangedPolls = enabled
+	}
+}
+
+// WithPageConcurrency sets the maximum number of pages fetched at once from the GitHub API.
+func WithPageConcurrency(n int) Option {
+	return func(s *statusValidator) {
+		if n > 0 {
+			s.pageConcurrency = n
+		}
+	}
+}
+
+// WithRefCacheTTL sets the duration for which the statuses of the jobs for a ref are reused, so that the same ref is
+// not fetched twice, e.g. when the base branch is at one of the intermediate commits. It should be shorter than the
+// poll interval, so that every poll still sees the latest statuses.
+func WithRefCacheTTL(d time.Duration) Option {
+	return func(s *statusValidator) {
+		s.refCacheTTL = d
+	}
+}
+
+// WithPartialResults makes the validator proceed with the pages fetched before a page failed to be fetched,
+// rather than failing. The validation never succeeds on the partial results, so that it is retried on the next poll.
+func WithPartialResults(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.allowPartialResults = enabled
+	}
+}
+
+// WithRequireSelfJob makes the validator fail when the self job is not found once all the other jobs are green,
+// which almost always indicates the self job name is misconfigured.
+func WithRequireSelfJob(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.requireSelfJob = enabled
+	}
+}
+
+// WithMatrixSelfJobMatch makes the validator also disregard the jobs of the matrix of the self job, which are named
+// after the self job name with the matrix suffix, e.g. "merge-gatekeeper (ubuntu-latest)". Only the job of the exact
+// self job name is disregarded by default, so that a different job whose name happens to have the suffix is still
+// validated.
+func WithMatrixSelfJobMatch(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.matrixSelfJob = enabled
+	}
+}
+
+// WithReportSelfFailure makes the validator report the self job when it is in error or failure state, e.g. when a
+// step before the gatekeeper in the same job has failed. The self job is still disregarded, and thus never blocks.
+func WithReportSelfFailure(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.reportSelfFailure = enabled
+	}
+}
+
+// WithRequireCompletedRuns requires check runs to be completed before their jobs are regarded as successful,
+// even when a commit status of the same name reports success. Jobs reported only by commit statuses are unaffected.
+func WithRequireCompletedRuns(b bool) Option {
+	return func(s *statusValidator) {
+		s.requireCompletedRuns = b
+	}
+}
+
+// WithIntermediateSHAs sets the commits of a push range other than the head. The head must still be green,
+// while the states of these commits are only reported unless WithFailOnIntermediate is set.
+func WithIntermediateSHAs(shas string) Option {
+	return func(s *statusValidator) {
+		if len(shas) == 0 {
+			return
+		}
+		s.intermediateSHAs = splitJobNames(shas)
+	}
+}
+
+// WithFailOnIntermediate fails the validation when any job of the intermediate commits has failed.
+func WithFailOnIntermediate(b bool) Option {
+	return func(s *statusValidator) {
+		s.failOnIntermediate = b
+	}
+}
+
+// WithBaseBranchCheck fails the validation when the head of the base branch of the pull request has failed jobs,
+// so that the pull request is not merged into a broken base. The failures of the base are reported separately.
+func WithBaseBranchCheck(b bool) Option {
+	return func(s *statusValidator) {
+		s.checkBase = b
+	}
+}
+
+// WithMaxCommitsBehind fails the validation when the head of the pull request is behind its base branch by more than
+// the given number of commits, so that it is rebased before it is merged. A negative number disables the check.
+func WithMaxCommitsBehind(n int) Option {
+	return func(s *statusValidator) {
+		if n < 0 {
+			return
+		}
+		s.checkBehind = true
+		s.maxCommitsBehind = n
+	}
+}
+
+// WithToleratedConclusions sets the check run conclusions which are tolerated only for the named jobs, e.g.
+// "e2e:timed_out,e2e:cancelled". A job concluding with a tolerated conclusion does not block, while any other
+// failing conclusion of the same job still does.
+func WithToleratedConclusions(rules string) Option {
+	return func(s *statusValidator) {
+		for _, rule := range splitJobNames(rules) {
+			// The conclusion is split at the last colon, as job names may contain colons.
+			var tc toleratedConclusion
+			if i := strings.LastIndex(rule, ":"); i >= 0 {
+				tc.job = strings.TrimSpace(rule[:i])
+				tc.conclusion = strings.TrimSpace(rule[i+1:])
+			} else {
+				tc.job = rule
+			}
+			s.toleratedConclusions = append(s.toleratedConclusions, tc)
+		}
+	}
+}
+
+// WithAppConclusions sets the check run conclusions which are regarded as success only for the named apps, e.g.
+// "some-app:neutral". The same conclusion of the check runs posted by any other app blocks, so that neutral from
+// a third-party app passes while neutral from our own CI, meaning inconclusive, does not.
+func WithAppConclusions(rules string) Option {
+	return func(s *statusValidator) {
+		for _, rule := range splitJobNames(rules) {
+			// The conclusion is split at the last colon, as with the tolerated conclusions.
+			var ac appConclusion
+			if i := strings.LastIndex(rule, ":"); i >= 0 {
+				ac.app = strings.TrimSpace(rule[:i])
+				ac.conclusion = strings.TrimSpace(rule[i+1:])
+			} else {
+				ac.app = rule
+			}
+			s.appConclusions = append(s.appConclusions, ac)
+		}
+	}
+}
+
+// WithCheckRunStates maps the check run states to the states of the jobs, overriding the entries of
+// DefaultCheckRunStates, e.g. {Status: "completed", Conclusion: "neutral"} to CheckRunMappedFailure. The mappings are
+// appended to the ones already set.
+func WithCheckRunStates(states map[CheckRunState]string) Option {
+	return func(s *statusValidator) {
+		if len(states) == 0 {
+			return
+		}
+		if s.checkRunStates == nil {
+			s.checkRunStates = DefaultCheckRunStates()
+		}
+		for state, mapped := range states {
+			s.checkRunStates[state] = mapped
+		}
+	}
+}
+
+// WithJobTimeouts sets the durations for which the named jobs are allowed to run since they started. A job running
+// beyond its own timeout fails the validation before the global timeout, so that a stuck quick job fails fast.
+func WithJobTimeouts(timeouts map[string]time.Duration) Option {
+	return func(s *statusValidator) {
+		if len(timeouts) == 0 {
+			return
+		}
+		s.jobTimeouts = make(map[string]time.Duration, len(timeouts))
+		for job, timeout := range timeouts {
+			s.jobTimeouts[job] = timeout
+		}
+	}
+}
+
+// WithHungRunThreshold sets the duration beyond which a check run in progress is regarded as hung and fails the
+// validation. Unlike the job timeouts, it applies to all the check runs, but not to the ones still queued.
+func WithHungRunThreshold(d time.Duration) Option {
+	return func(s *statusValidator) {
+		s.hungRunThreshold = d
+	}
+}
+
+// WithRequiredDescriptions sets the regular expressions which the descriptions of the named commit statuses must match,
+// e.g. "license-scan" to "0 violations". A commit status which has succeeded with any other description fails.
+func WithRequiredDescriptions(patterns map[string]string) Option {
+	return func(s *statusValidator) {
+		if len(patterns) == 0 {
+			return
+		}
+		s.requiredDescriptionPatterns = make(map[string]string, len(patterns))
+		for context, pattern := range patterns {
+			s.requiredDescriptionPatterns[context] = pattern
+		}
+	}
+}
+
+// WithAnnotationCheckedJobs sets the jobs whose check runs fail when they have emitted error annotations, even when
+// they have succeeded. The annotations are fetched only for these jobs, as it takes a request for each check run.
+func WithAnnotationCheckedJobs(jobs ...string) Option {
+	return func(s *statusValidator) {
+		for _, job := range jobs {
+			if job = strings.TrimSpace(job); len(job) != 0 {
+				s.annotationJobs = append(s.annotationJobs, job)
+			}
+		}
+	}
+}
+
+// WithAllowedWorkflows sets the names of the only workflows whose check runs are validated, e.g. "CI". The check runs
+// of any other GitHub Actions workflow are ignored, which takes a few requests for each check suite to look them up.
+func WithAllowedWorkflows(names string) Option {
+	return func(s *statusValidator) {
+		if len(names) == 0 {
+			return
+		}
+		s.allowedWorkflows = splitJobNames(names)
+	}
+}
+
+// WithLatestCheckSuitesOnly makes the validator only validate the check runs of the latest check suite of each
+// GitHub Actions workflow, so that the runs lingering in an old suite do not pollute the result once the whole suite
+// is re-run. It takes a few requests for each check suite to look up its workflow.
+func WithLatestCheckSuitesOnly(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.latestCheckSuites = enabled
+	}
+}
+
+// WithAllowedTargetHosts sets the hosts which the target URLs of the commit statuses must point to, such as
+// "ci.example.com" or "*.example.com". A commit status targeting any other host is flagged in the notes.
+func WithAllowedTargetHosts(hosts string) Option {
+	return func(s *statusValidator) {
+		if len(hosts) == 0 {
+			return
+		}
+		s.allowedTargetHosts = splitJobNames(hosts)
+	}
+}
+
+// WithFailOnUntrustedTarget regards a commit status targeting a host which is not allowed as failed.
+func WithFailOnUntrustedTarget(b bool) Option {
+	return func(s *statusValidator) {
+		s.failOnUntrustedTarget = b
+	}
+}
+
+// WithToleranceWindow restricts the tolerated conclusions to the daily window, e.g. "06:00-10:00" in UTC or
+// "06:00-10:00 Asia/Tokyo", so that known flakiness is tolerated only while it happens. Outside the window,
+// the tolerated conclusions fail as usual.
+func WithToleranceWindow(window string) Option {
+	return func(s *statusValidator) {
+		if len(window) != 0 {
+			s.toleranceWindowText = window
+		}
+	}
+}
+
+// WithServerVersionDetection detects the version of GitHub Enterprise Server, so that only the commit statuses are
+// validated on the versions which lack the check runs API.
+func WithServerVersionDetection(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.detectVersion = enabled
+	}
+}
+
+// WithWorkflowRunID validates the jobs of the workflow run instead of all the jobs for the ref, so that the gate is
+// scoped to a single workflow. The overall conclusion of the run is reported along with the jobs.
+func WithWorkflowRunID(id int64) Option {
+	return func(s *statusValidator) {
+		s.workflowRunID = id
+	}
+}
+
+// WithMinDistinctApps requires successful check runs posted by at least the given number of distinct apps, such as
+// GitHub Actions and an external CI, so that the validation fails once all jobs are green without redundant coverage.
+func WithMinDistinctApps(n int) Option {
+	return func(s *statusValidator) {
+		s.minDistinctApps = n
+	}
+}
+
+// WithCombinedStateCheck requires the overall state of the commit statuses aggregated by GitHub to be successful once
+// all the jobs are green, as a guard against misreading the states of the individual commit statuses. The commit
+// statuses disregarded by the validation, such as the ignored jobs and the self job, do not count as a disagreement.
+// The validation keeps waiting while the overall state is pending, and fails when it has failed.
+func WithCombinedStateCheck(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.checkCombinedState = enabled
+	}
+}
+
+// WithStatusTransformer sets the transformer of the statuses collected on each poll, which is invoked before the
+// result is computed. For example, the following transformer drops the jobs of a bot and renames a legacy job:
+//
+//	status.WithStatusTransformer(func(statuses []status.JobStatus) []status.JobStatus {
+//		transformed := make([]status.JobStatus, 0, len(statuses))
+//		for _, s := range statuses {
+//			switch {
+//			case strings.HasPrefix(s.Job, "bot/"):
+//				continue
+//			case s.Job == "ci/legacy":
+//				s.Job = "build"
+//			}
+//			transformed = append(transformed, s)
+//		}
+//		return transformed
+//	})
+func WithStatusTransformer(t StatusTransformer) Option {
+	return func(s *statusValidator) {
+		if t != nil {
+			s.transformer = t
+		}
+	}
+}
+
+// WithDecider replaces the built-in logic deciding the overall result with the given decider.
+func WithDecider(d StatusDecider) Option {
+	return func(s *statusValidator) {
+		if d != nil {
+			s.decider = d
+		}
+	}
+}
+
 func WithGitHubOwnerAndRepo(owner, repo string) Option {
 	return func(s *statusValidator) {
 		if len(owner) != 0 {
@@ -31,6 +487,38 @@ func WithGitHubRef(ref string) Option {
 	}
 }
 
+// WithPullRequest sets the pull request number, whose head commit is validated.
+func WithPullRequest(number int) Option {
+	return func(s *statusValidator) {
+		if number > 0 {
+			s.prNumber = number
+		}
+	}
+}
+
+// WithFollowPullRequestHead re-resolves the head commit of the pull request on every validation,
+// so that a new commit pushed while waiting is followed.
+func WithFollowPullRequestHead(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.followHead = enabled
+	}
+}
+
+// WithMergeRef validates the test merge commit of the pull request computed by GitHub instead of its head, and fails
+// when the pull request has merge conflicts regardless of the jobs.
+func WithMergeRef(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.mergeRef = enabled
+	}
+}
+
+// WithRefResolution resolves the ref to its commit SHA before the first validation.
+func WithRefResolution(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.resolveRef = enabled
+	}
+}
+
 func WithIgnoredJobs(names string) Option {
 	return func(s *statusValidator) {
 		// TODO: Add more input validation, such as "," should not be a valid input.
@@ -38,15 +526,170 @@ func WithIgnoredJobs(names string) Option {
 			return // TODO: Return some clearer error
 		}
 
-		jobs := []string{}
-		ss := strings.Split(names, ",")
-		for _, s := range ss {
-			jobName := strings.TrimSpace(s)
-			if len(jobName) == 0 {
-				continue // TODO: Provide more clue to users
+		s.ignoredJobs = splitJobNames(names)
+	}
+}
+
+// WithOnlyJobs sets the jobs to validate, so that all the other jobs are disregarded. When only a few jobs are set,
+// their check runs are filtered on the server to reduce the requests.
+func WithOnlyJobs(names string) Option {
+	return func(s *statusValidator) {
+		if len(names) == 0 {
+			return
+		}
+		s.onlyJobs = splitJobNames(names)
+	}
+}
+
+// WithJobAliases maps the old names of the renamed jobs to their new names, e.g. {"test": "unit-test"}. The old names
+// in the required, ignored, self and other job lists are regarded as the new names, and so are the jobs still reported
+// with the old names, so that the lists keep working while migrating.
+func WithJobAliases(aliases map[string]string) Option {
+	return func(s *statusValidator) {
+		if len(aliases) == 0 {
+			return
+		}
+		s.jobAliases = make(map[string]string, len(aliases))
+		for old, renamed := range aliases {
+			s.jobAliases[old] = renamed
+		}
+	}
+}
+
+// WithDraftSkippedJobs sets the jobs which intentionally don't run while the pull request is a draft.
+// They are not required until the pull request is marked as ready for review.
+func WithDraftSkippedJobs(names string) Option {
+	return func(s *statusValidator) {
+		if len(names) == 0 {
+			return
+		}
+		s.draftSkippedJobs = splitJobNames(names)
+	}
+}
+
+// WithRequiredJobs sets the patterns of the jobs which must be reported and succeed, so that the validation does not
+// succeed before they are queued. Glob patterns such as "e2e-*" and regular expressions enclosed in slashes such as
+// "/^e2e-.*$/" are supported. The patterns are appended to the ones already set.
+func WithRequiredJobs(patterns ...string) Option {
+	return func(s *statusValidator) {
+		for _, pattern := range patterns {
+			if pattern = strings.TrimSpace(pattern); len(pattern) != 0 {
+				s.requiredJobPatterns = append(s.requiredJobPatterns, pattern)
+			}
+		}
+	}
+}
+
+// WithMaxMatchingJobs sets the maximum numbers of the jobs which the patterns may match, e.g. "test-shard-*" to 50,
+// as a sanity cap on the dynamically named jobs required by the patterns. The validation fails as soon as more jobs
+// than allowed match a pattern.
+func WithMaxMatchingJobs(limits map[string]int) Option {
+	return func(s *statusValidator) {
+		if len(limits) == 0 {
+			return
+		}
+		s.jobCountLimitPatterns = make(map[string]int, len(limits))
+		for pattern, max := range limits {
+			s.jobCountLimitPatterns[pattern] = max
+		}
+	}
+}
+
+// WithStages sets the steps of the pipeline in their order, such as "build", "test" and "deploy", which group the
+// jobs in the summary. A stage is either a name, matching the jobs whose names start with it, or a name followed by
+// a job pattern such as "e2e=/^(e2e|smoke)-.*$/". It only changes how the jobs are reported.
+func WithStages(stages ...string) Option {
+	return func(s *statusValidator) {
+		for _, stage := range stages {
+			if stage = strings.TrimSpace(stage); len(stage) != 0 {
+				s.stageTexts = append(s.stageTexts, stage)
 			}
-			jobs = append(jobs, jobName)
 		}
-		s.ignoredJobs = jobs
+	}
+}
+
+// WithRequiredMissingAsPending sets whether the required jobs which are not reported yet are regarded as pending,
+// which is the default as they are most likely still being created. When disabled, the validation fails as soon as
+// any required job is missing, without waiting for it to be reported.
+func WithRequiredMissingAsPending(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.failOnMissingRequired = !enabled
+	}
+}
+
+// WithNonBlockingPendingJobs sets the jobs which are ignored while they are pending, as some integrations
+// never update their pending statuses. They are still validated once they reach success or error.
+func WithNonBlockingPendingJobs(names string) Option {
+	return func(s *statusValidator) {
+		if len(names) == 0 {
+			return
+		}
+		s.nonBlockingPendingJobs = splitJobNames(names)
+	}
+}
+
+// WithPassUnlessFailing passes the validation as long as no job is failing and no required job is pending, for the
+// repositories whose jobs are all optional and only need to keep red changes from being merged. The pending jobs
+// which are not required are ignored, while they still fail the validation once they fail. By default, all the jobs
+// must succeed.
+func WithPassUnlessFailing(enabled bool) Option {
+	return func(s *statusValidator) {
+		s.passUnlessFailing = enabled
+	}
+}
+
+// WithHardBlockingJobs sets the critical jobs, such as a security scan, which abort the validation as soon as any of
+// them fails. The failure is final even when the other jobs are still pending, or when the failures would otherwise
+// be retried or waited for, e.g. by the error state policy or the decider.
+func WithHardBlockingJobs(names string) Option {
+	return func(s *statusValidator) {
+		if len(names) == 0 {
+			return
+		}
+		s.hardBlockingJobs = splitJobNames(names)
+	}
+}
+
+// WithConditionalJobs sets the jobs which only run for some changes. Unlike the ignored jobs, they are ignored only
+// while they are not reported, and validated as any other job once they are reported. Each of them may be a glob or a
+// regular expression as the required jobs are, which is reported when any job matching it is.
+func WithConditionalJobs(names string) Option {
+	return func(s *statusValidator) {
+		if len(names) == 0 {
+			return
+		}
+		s.conditionalJobs = splitJobNames(names)
+	}
+}
+
+// WithRequiredEnvironments sets the environments which the ref must be successfully deployed to, e.g. "production".
+// The latest deployment to each environment is validated as a job, which is pending until the ref is deployed.
+func WithRequiredEnvironments(names string) Option {
+	return func(s *statusValidator) {
+		if len(names) == 0 {
+			return
+		}
+		s.requiredEnvironments = splitJobNames(names)
+	}
+}
+
+func splitJobNames(names string) []string {
+	jobs := []string{}
+	ss := strings.Split(names, ",")
+	for _, s := range ss {
+		jobName := strings.TrimSpace(s)
+		if len(jobName) == 0 {
+			continue // TODO: Provide more clue to users
+		}
+		jobs = append(jobs, jobName)
+	}
+	return jobs
+}
+
+// WithTracerProvider starts a span with the tracer of the provider for each poll, which is the parent of the spans of
+// the API calls made by a client traced with the same provider. Nothing is traced by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(s *statusValidator) {
+		s.tracerProvider = tp
 	}
 }
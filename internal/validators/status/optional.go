@@ -0,0 +1,19 @@
+package status
+
+// isOptionalPending reports whether the job is pending while nothing requires it, which does not block the merge
+// when only the failures block it. The jobs are required by the required job patterns, by being listed as the only
+// jobs to validate, or as the deployments to the required environments.
+func (sv *statusValidator) isOptionalPending(ghaStatus *ghaStatus) bool {
+	if !sv.passUnlessFailing || ghaStatus.State != pendingState {
+		return false
+	}
+	if len(sv.onlyJobs) != 0 || ghaStatus.Source == JobSourceDeployment {
+		return false
+	}
+	for _, p := range sv.requiredJobs {
+		if p.match(ghaStatus.Job) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_passUnlessFailing(t *testing.T) {
+	tests := map[string]struct {
+		statuses     []*github.RepoStatus
+		opts         []Option
+		wantSuccess  bool
+		wantPending  bool
+		wantOptional []string
+	}{
+		"passes with the optional jobs pending": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+				{Context: stringPtr("lint"), State: stringPtr(pendingState)},
+			},
+			wantSuccess:  true,
+			wantOptional: []string{"lint"},
+		},
+		"waits for the optional jobs by default": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(successState)},
+				{Context: stringPtr("lint"), State: stringPtr(pendingState)},
+			},
+			opts:        []Option{WithPassUnlessFailing(false)},
+			wantPending: true,
+		},
+		"passes when no job is reported": {
+			wantSuccess: true,
+		},
+		"fails on a failed optional job": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(errorState)},
+				{Context: stringPtr("lint"), State: stringPtr(pendingState)},
+			},
+		},
+		"waits for the pending required jobs": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(pendingState)},
+				{Context: stringPtr("lint"), State: stringPtr(pendingState)},
+			},
+			opts:        []Option{WithRequiredJobs("build")},
+			wantPending: true,
+		},
+		"waits for the required jobs which are not reported yet": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("lint"), State: stringPtr(pendingState)},
+			},
+			opts:        []Option{WithRequiredJobs("build")},
+			wantPending: true,
+		},
+		"waits for the pending jobs listed as the only jobs": {
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("build"), State: stringPtr(pendingState)},
+			},
+			opts:        []Option{WithOnlyJobs("build")},
+			wantPending: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{Statuses: tt.statuses}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			opts := append([]Option{
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithPassUnlessFailing(true),
+			}, tt.opts...)
+			v, err := CreateValidator(c, opts...)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if !tt.wantSuccess && !tt.wantPending {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() error = %v, want failure", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("Validate() IsSuccess() = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+			st := got.(*status)
+			for _, job := range tt.wantOptional {
+				if !containsJob(st.optionalJobs, job) {
+					t.Errorf("Validate() optional jobs = %v, want %s", st.optionalJobs, job)
+				}
+				if containsJob(st.ignoredJobs, job) {
+					t.Errorf("Validate() ignored jobs = %v, want %s not ignored", st.ignoredJobs, job)
+				}
+			}
+		})
+	}
+}
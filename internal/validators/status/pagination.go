@@ -0,0 +1,103 @@
+package status
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultPageConcurrency = 4
+
+// pageFetcher fetches the given page, and reports whether it is the last page.
+type pageFetcher func(ctx context.Context, page int) (last bool, err error)
+
+// fetchInfo describes how the pages have been fetched.
+type fetchInfo struct {
+	// unchanged reports whether all the pages are unchanged since the previous fetch.
+	unchanged bool
+
+	// partialErr is the error of the page which failed after the preceding pages were fetched,
+	// when the validator proceeds with the partial results.
+	partialErr error
+
+	// combined is the overall state of the commit statuses, or nil when they have not been fetched.
+	combined *combinedState
+
+	// sha is the commit which the commit statuses are reported for, or empty when none of them has been fetched.
+	sha string
+}
+
+func (i fetchInfo) merge(other fetchInfo) fetchInfo {
+	merged := fetchInfo{
+		unchanged:  i.unchanged && other.unchanged,
+		partialErr: i.partialErr,
+		combined:   i.combined,
+		sha:        i.sha,
+	}
+	if merged.partialErr == nil {
+		merged.partialErr = other.partialErr
+	}
+	if merged.combined == nil {
+		merged.combined = other.combined
+	}
+	if len(merged.sha) == 0 {
+		merged.sha = other.sha
+	}
+	return merged
+}
+
+// partialPagesError is returned when a page fails after the preceding pages were fetched.
+type partialPagesError struct {
+	err error
+}
+
+func (e *partialPagesError) Error() string {
+	return e.err.Error()
+}
+
+func (e *partialPagesError) Unwrap() error {
+	return e.err
+}
+
+// fetchPages fetches pages from the first one until the last page is found. The first page is fetched alone, as
+// most refs fit in a single page. The rest are fetched in batches with up to concurrency pages in flight at once,
+// so a few pages after the last one may be requested, but they must be discarded by the caller.
+// It returns the number of pages up to and including the last page. When a page after the first one fails,
+// it returns the number of pages fetched before it along with *partialPagesError.
+func fetchPages(ctx context.Context, concurrency int, fetch pageFetcher) (int, error) {
+	if concurrency <= 0 {
+		concurrency = defaultPageConcurrency
+	}
+
+	last, err := fetch(ctx, 1)
+	if err != nil {
+		return 0, err
+	}
+	if last {
+		return 1, nil
+	}
+
+	for first := 2; ; first += concurrency {
+		lasts := make([]bool, concurrency)
+		errs := make([]error, concurrency)
+
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				lasts[i], errs[i] = fetch(ctx, first+i)
+			}(i)
+		}
+		wg.Wait()
+
+		// Results are checked in the page order, so that the pages after the last one do not affect the result.
+		for i := 0; i < concurrency; i++ {
+			if errs[i] != nil {
+				return first + i - 1, &partialPagesError{err: errs[i]}
+			}
+			if lasts[i] {
+				return first + i, nil
+			}
+		}
+	}
+}
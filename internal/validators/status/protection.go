@@ -0,0 +1,69 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+// RequiredChecksDiff is the difference between the checks required by the branch protection and the jobs reported
+// on a ref.
+type RequiredChecksDiff struct {
+	// Required are the checks required by the branch protection.
+	Required []string
+	// Reported are the jobs reported on the ref.
+	Reported []string
+	// Missing are the required checks which are not reported on the ref.
+	Missing []string
+	// Extra are the jobs reported on the ref which are not required.
+	Extra []string
+}
+
+// DiffRequiredChecks compares the checks required by the protection of the branch with the jobs reported for the ref,
+// all sorted by name. It is meant for planning the branch protection, and is not part of the validation.
+func DiffRequiredChecks(ctx context.Context, c github.Client, branch string, opts ...Option) (*RequiredChecksDiff, error) {
+	sv := &statusValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	if errs := sv.validateTargetFields(); len(errs) != 0 {
+		return nil, errs
+	}
+
+	checks, _, err := c.GetRequiredStatusChecks(ctx, sv.owner, sv.repo, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required status checks of branch %s: %w", branch, err)
+	}
+	jobStatuses, err := sv.ListJobStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &RequiredChecksDiff{
+		Required: append([]string{}, checks.Contexts...),
+		Reported: make([]string, 0, len(jobStatuses)),
+		Missing:  []string{},
+		Extra:    []string{},
+	}
+	for _, js := range jobStatuses {
+		diff.Reported = append(diff.Reported, js.Job)
+	}
+	sort.Strings(diff.Required)
+	sort.Strings(diff.Reported)
+
+	for _, required := range diff.Required {
+		if !containsJob(diff.Reported, required) {
+			diff.Missing = append(diff.Missing, required)
+		}
+	}
+	for _, reported := range diff.Reported {
+		if !containsJob(diff.Required, reported) {
+			diff.Extra = append(diff.Extra, reported)
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,101 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func TestDiffRequiredChecks(t *testing.T) {
+	opts := []Option{
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("sha"),
+	}
+	listStatuses := func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+		return &github.CombinedStatus{
+			Statuses: []*github.RepoStatus{
+				{Context: stringPtr("lint"), State: stringPtr(successState)},
+			},
+		}, nil, nil
+	}
+	listCheckRuns := func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+		return &github.ListCheckRunsResults{
+			CheckRuns: []*github.CheckRun{
+				{Name: stringPtr("build"), Status: stringPtr(checkRunInProgressStatus)},
+				{Name: stringPtr("docs"), Status: stringPtr(checkRunQueuedStatus)},
+			},
+		}, nil, nil
+	}
+
+	tests := map[string]struct {
+		c       github.Client
+		opts    []Option
+		want    *RequiredChecksDiff
+		wantErr bool
+	}{
+		"returns the missing and extra checks sorted by name": {
+			c: &mock.Client{
+				GetRequiredStatusChecksFunc: func(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+					if branch != "main" {
+						t.Errorf("branch = %s, want main", branch)
+					}
+					return &github.RequiredStatusChecks{Contexts: []string{"lint", "e2e", "build"}}, nil, nil
+				},
+				GetCombinedStatusFunc:   listStatuses,
+				ListCheckRunsForRefFunc: listCheckRuns,
+			},
+			opts: opts,
+			want: &RequiredChecksDiff{
+				Required: []string{"build", "e2e", "lint"},
+				Reported: []string{"build", "docs", "lint"},
+				Missing:  []string{"e2e"},
+				Extra:    []string{"docs"},
+			},
+		},
+		"returns all the reported jobs as extra when no check is required": {
+			c: &mock.Client{
+				GetRequiredStatusChecksFunc: func(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+					return &github.RequiredStatusChecks{Contexts: []string{}}, nil, nil
+				},
+				GetCombinedStatusFunc:   listStatuses,
+				ListCheckRunsForRefFunc: listCheckRuns,
+			},
+			opts: opts,
+			want: &RequiredChecksDiff{
+				Required: []string{},
+				Reported: []string{"build", "docs", "lint"},
+				Missing:  []string{},
+				Extra:    []string{"build", "docs", "lint"},
+			},
+		},
+		"returns error when the required status checks cannot be fetched": {
+			c: &mock.Client{
+				GetRequiredStatusChecksFunc: func(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			},
+			opts:    opts,
+			wantErr: true,
+		},
+		"returns error when the ref is empty": {
+			c:       &mock.Client{},
+			opts:    []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := DiffRequiredChecks(context.Background(), tt.c, "main", tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DiffRequiredChecks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffRequiredChecks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
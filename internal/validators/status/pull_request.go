@@ -0,0 +1,92 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEmptyPullRequestHead = errors.New("head sha of pull request is empty")
+	ErrMergeConflict        = errors.New("pull request has merge conflicts")
+)
+
+// mergeableStateDirty is the mergeable state of the pull request which has merge conflicts.
+const mergeableStateDirty = "dirty"
+
+// resolvePullRequestHead resolves the head SHA of the pull request. By default the head is resolved only once,
+// but it is re-resolved on every poll when following the head, so that a commit pushed while waiting is validated
+// instead of the stale one. It returns a message describing the change when the head has moved.
+//
+// The pull request is also fetched on every poll when there are draft skipped jobs, as it can be marked as
+// ready for review while waiting, and until its base branch is known when the base branch is checked or compared.
+//
+// When validating the merge ref, the test merge commit computed by GitHub is followed instead of the head, as it
+// changes whenever either the head or the base moves. The head is validated until the merge commit is computed.
+func (sv *statusValidator) resolvePullRequestHead(ctx context.Context) (string, error) {
+	resolveHead := len(sv.sha) == 0 || sv.followHead || sv.mergeRef
+	resolveBase := (sv.checkBase || sv.checkBehind) && len(sv.baseBranch) == 0
+	if sv.prNumber == 0 || (!resolveHead && !resolveBase && len(sv.draftSkippedJobs) == 0) {
+		return "", nil
+	}
+
+	pr, _, err := sv.client.GetPullRequest(ctx, sv.owner, sv.repo, sv.prNumber)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pull request #%d: %w", sv.prNumber, err)
+	}
+	sv.draft = pr.GetDraft()
+	sv.mergeableState = pr.GetMergeableState()
+	sv.baseBranch = pr.GetBase().GetRef()
+	sv.prHead = pr.GetHead().GetSHA()
+	if !resolveHead {
+		return "", nil
+	}
+
+	head := pr.GetHead().GetSHA()
+	if len(head) == 0 {
+		return "", fmt.Errorf("%w pull request: #%d", ErrEmptyPullRequestHead, sv.prNumber)
+	}
+	target := "Head"
+	if merge := pr.GetMergeCommitSHA(); sv.mergeRef && len(merge) != 0 {
+		head, target = merge, "Merge commit"
+	}
+
+	prev := sv.sha
+	sv.sha = head
+	if len(prev) == 0 || prev == head {
+		return "", nil
+	}
+
+	// The jobs of the previous head are no longer relevant, and the time is counted from the new head.
+	sv.resetPollState()
+	sv.startedAt = time.Time{}
+	return fmt.Sprintf("%s of pull request #%d has changed from %s to %s", target, sv.prNumber, prev, head), nil
+}
+
+// checkMergeable fails when the pull request has merge conflicts, regardless of the jobs, as they are not revealed
+// by the jobs. It returns the note describing the mergeable state for the report.
+func (sv *statusValidator) checkMergeable() (string, error) {
+	if !sv.mergeRef {
+		return "", nil
+	}
+	if sv.mergeableState == mergeableStateDirty {
+		return "", fmt.Errorf("%w: #%d", ErrMergeConflict, sv.prNumber)
+	}
+	state := sv.mergeableState
+	if len(state) == 0 {
+		state = "not computed yet"
+	}
+	return fmt.Sprintf("Mergeable state of pull request #%d: %s", sv.prNumber, state), nil
+}
+
+// draftNote describes the draft state of the pull request for the report.
+func (sv *statusValidator) draftNote() string {
+	if sv.prNumber == 0 || len(sv.draftSkippedJobs) == 0 {
+		return ""
+	}
+	if sv.draft {
+		return fmt.Sprintf("Pull request #%d is a draft, so the draft skipped jobs are not required", sv.prNumber)
+	}
+	return fmt.Sprintf("Pull request #%d is ready for review", sv.prNumber)
+}
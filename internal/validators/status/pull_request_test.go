@@ -0,0 +1,244 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_resolvePullRequestHead(t *testing.T) {
+	tests := map[string]struct {
+		followHead  bool
+		heads       []string
+		wantRefs    []string
+		wantChanged []bool
+	}{
+		"resolves the head only once without following": {
+			heads:       []string{"sha-01", "sha-02"},
+			wantRefs:    []string{"sha-01", "sha-01"},
+			wantChanged: []bool{false, false},
+		},
+		"follows the new head when following": {
+			followHead:  true,
+			heads:       []string{"sha-01", "sha-01", "sha-02"},
+			wantRefs:    []string{"sha-01", "sha-01", "sha-02"},
+			wantChanged: []bool{false, false, true},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var poll int
+			var gotRef string
+			now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+			sv := &statusValidator{
+				clock:       func() time.Time { return now },
+				owner:       "test-owner",
+				repo:        "test-repo",
+				prNumber:    1,
+				followHead:  tt.followHead,
+				selfJobName: "self-job",
+				client: &mock.Client{
+					GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+						return &github.PullRequest{
+							Head: &github.PullRequestBranch{SHA: stringPtr(tt.heads[poll])},
+						}, nil, nil
+					},
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						gotRef = ref
+						return &github.CombinedStatus{}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{}, nil, nil
+					},
+				},
+			}
+			for poll = range tt.heads {
+				now = now.Add(time.Minute)
+				sv.greenSince = time.Now()
+				got, err := sv.Validate(context.Background())
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() #%d unexpected error: %v", poll, err)
+				}
+				if gotRef != tt.wantRefs[poll] {
+					t.Errorf("statusValidator.Validate() #%d ref = %s, want %s", poll, gotRef, tt.wantRefs[poll])
+				}
+				changed := strings.Contains(got.Detail(), "has changed")
+				if changed != tt.wantChanged[poll] {
+					t.Errorf("statusValidator.Validate() #%d head changed = %v, want %v", poll, changed, tt.wantChanged[poll])
+				}
+				if reported := got.(HeadChangeReporter).HeadChanged(); reported != tt.wantChanged[poll] {
+					t.Errorf("statusValidator.Validate() #%d HeadChanged() = %v, want %v", poll, reported, tt.wantChanged[poll])
+				}
+				if changed && !sv.greenSince.IsZero() {
+					t.Errorf("statusValidator.Validate() #%d did not reset the poll state", poll)
+				}
+				// The time is counted from the new head.
+				if d := got.(*status).duration; changed && d != 0 {
+					t.Errorf("statusValidator.Validate() #%d duration = %s, want 0 for the new head", poll, d)
+				}
+			}
+		})
+	}
+}
+
+func Test_statusValidator_resolvePullRequestHead_emptyHead(t *testing.T) {
+	sv := &statusValidator{
+		prNumber: 1,
+		client: &mock.Client{
+			GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+				return &github.PullRequest{}, nil, nil
+			},
+		},
+	}
+	if _, err := sv.resolvePullRequestHead(context.Background()); err == nil {
+		t.Errorf("statusValidator.resolvePullRequestHead() error = nil, want error")
+	}
+}
+
+func Test_statusValidator_Validate_draftSkippedJobs(t *testing.T) {
+	tests := map[string]struct {
+		drafts        []bool
+		wantSucceeded []bool
+		wantNote      []string
+	}{
+		"does not require the draft skipped jobs while the pull request is a draft": {
+			drafts:        []bool{true},
+			wantSucceeded: []bool{true},
+			wantNote:      []string{"is a draft"},
+		},
+		"requires the draft skipped jobs once the pull request is ready for review": {
+			drafts:        []bool{true, false},
+			wantSucceeded: []bool{true, false},
+			wantNote:      []string{"is a draft", "is ready for review"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var poll int
+			sv := &statusValidator{
+				owner:            "test-owner",
+				repo:             "test-repo",
+				prNumber:         1,
+				selfJobName:      "self-job",
+				draftSkippedJobs: []string{"e2e"},
+				client: &mock.Client{
+					GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+						return &github.PullRequest{
+							Draft: &tt.drafts[poll],
+							Head:  &github.PullRequestBranch{SHA: stringPtr("sha-01")},
+						}, nil, nil
+					},
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						return &github.CombinedStatus{
+							Statuses: []*github.RepoStatus{
+								{
+									Context: stringPtr("unit"),
+									State:   stringPtr(successState),
+								},
+								{
+									Context: stringPtr("e2e"),
+									State:   stringPtr(pendingState),
+								},
+							},
+						}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{}, nil, nil
+					},
+				},
+			}
+			for poll = range tt.drafts {
+				got, err := sv.Validate(context.Background())
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() #%d unexpected error: %v", poll, err)
+				}
+				if got.IsSuccess() != tt.wantSucceeded[poll] {
+					t.Errorf("statusValidator.Validate() #%d succeeded = %v, want %v", poll, got.IsSuccess(), tt.wantSucceeded[poll])
+				}
+				if !strings.Contains(got.Detail(), tt.wantNote[poll]) {
+					t.Errorf("statusValidator.Validate() #%d detail does not contain %q:\n%s", poll, tt.wantNote[poll], got.Detail())
+				}
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_mergeRef(t *testing.T) {
+	tests := map[string]struct {
+		mergeCommitSHA string
+		mergeableState string
+		wantRef        string
+		wantErrIs      error
+		wantNote       string
+	}{
+		"validates the merge commit and reports the mergeable state": {
+			mergeCommitSHA: "merge-sha",
+			mergeableState: "clean",
+			wantRef:        "merge-sha",
+			wantNote:       "Mergeable state of pull request #1: clean",
+		},
+		"validates the head until the merge commit is computed": {
+			wantRef:  "head-sha",
+			wantNote: "Mergeable state of pull request #1: not computed yet",
+		},
+		"returns ErrMergeConflict when the pull request has conflicts": {
+			mergeCommitSHA: "merge-sha",
+			mergeableState: mergeableStateDirty,
+			wantErrIs:      ErrMergeConflict,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotRef string
+			sv := &statusValidator{
+				owner:       "test-owner",
+				repo:        "test-repo",
+				prNumber:    1,
+				mergeRef:    true,
+				selfJobName: "self-job",
+				client: &mock.Client{
+					GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+						pr := &github.PullRequest{
+							Head: &github.PullRequestBranch{SHA: stringPtr("head-sha")},
+						}
+						if len(tt.mergeCommitSHA) != 0 {
+							pr.MergeCommitSHA = stringPtr(tt.mergeCommitSHA)
+						}
+						if len(tt.mergeableState) != 0 {
+							pr.MergeableState = stringPtr(tt.mergeableState)
+						}
+						return pr, nil, nil
+					},
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						gotRef = ref
+						return &github.CombinedStatus{}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("statusValidator.Validate() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("statusValidator.Validate() unexpected error: %v", err)
+			}
+			if gotRef != tt.wantRef {
+				t.Errorf("statusValidator.Validate() ref = %s, want %s", gotRef, tt.wantRef)
+			}
+			if !strings.Contains(got.Detail(), tt.wantNote) {
+				t.Errorf("statusValidator.Validate() detail does not contain %q:\n%s", tt.wantNote, got.Detail())
+			}
+		})
+	}
+}
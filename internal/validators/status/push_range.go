@@ -0,0 +1,51 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// checkIntermediateSHAs validates the intermediate commits of a push range, which would otherwise go unnoticed
+// as only the head is validated. It returns a note for each commit to be included in the report, and whether any
+// job of them has failed. The ignored jobs and the self job are disregarded as they are for the head.
+func (sv *statusValidator) checkIntermediateSHAs(ctx context.Context) ([]string, bool, error) {
+	notes := make([]string, 0, len(sv.intermediateSHAs))
+	var failed bool
+	for _, sha := range sv.intermediateSHAs {
+		if sha == sv.targetRef() {
+			continue
+		}
+
+		ghaStatuses, _, err := sv.listGhaStatusesForRef(ctx, sha)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list statuses of intermediate commit %s: %w", sha, err)
+		}
+
+		var errJobs []string
+		var pending int
+		for _, ghaStatus := range ghaStatuses {
+			if sv.isSelfJob(ghaStatus.Job) || containsJob(sv.ignoredJobs, ghaStatus.Job) {
+				continue
+			}
+			switch ghaStatus.State {
+			case successState:
+			case errorState, failureState:
+				errJobs = append(errJobs, ghaStatus.Job)
+			default:
+				pending++
+			}
+		}
+
+		switch {
+		case len(errJobs) != 0:
+			failed = true
+			notes = append(notes, fmt.Sprintf("Intermediate commit %s has %d failed job(s): %s", sha, len(errJobs), strings.Join(errJobs, ", ")))
+		case pending != 0:
+			notes = append(notes, fmt.Sprintf("Intermediate commit %s has %d incomplete job(s)", sha, pending))
+		default:
+			notes = append(notes, fmt.Sprintf("Intermediate commit %s has no failed job", sha))
+		}
+	}
+	return notes, failed, nil
+}
@@ -0,0 +1,97 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_intermediateSHAs(t *testing.T) {
+	statuses := map[string][]*github.RepoStatus{
+		"head-sha": {
+			{Context: stringPtr("job-01"), State: stringPtr(successState)},
+		},
+		"green-sha": {
+			{Context: stringPtr("job-01"), State: stringPtr(successState)},
+			{Context: stringPtr("self-job"), State: stringPtr(failureState)},
+		},
+		"red-sha": {
+			{Context: stringPtr("job-01"), State: stringPtr(failureState)},
+		},
+	}
+
+	tests := map[string]struct {
+		intermediateSHAs   []string
+		failOnIntermediate bool
+		wantErr            bool
+		wantNotes          []string
+	}{
+		"reports the intermediate commits without failing": {
+			intermediateSHAs: []string{"green-sha", "red-sha"},
+			wantNotes: []string{
+				"Intermediate commit green-sha has no failed job",
+				"Intermediate commit red-sha has 1 failed job(s): job-01",
+			},
+		},
+		"fails when an intermediate commit has failed with fail on intermediate": {
+			intermediateSHAs:   []string{"green-sha", "red-sha"},
+			failOnIntermediate: true,
+			wantErr:            true,
+			wantNotes: []string{
+				"Intermediate commit red-sha has 1 failed job(s): job-01",
+			},
+		},
+		"succeeds when all intermediate commits are green with fail on intermediate": {
+			intermediateSHAs:   []string{"green-sha", "head-sha"},
+			failOnIntermediate: true,
+			wantNotes: []string{
+				"Intermediate commit green-sha has no failed job",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				owner:              "test-owner",
+				repo:               "test-repo",
+				ref:                "head-sha",
+				selfJobName:        "self-job",
+				intermediateSHAs:   tt.intermediateSHAs,
+				failOnIntermediate: tt.failOnIntermediate,
+				client: &mock.Client{
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						return &github.CombinedStatus{Statuses: statuses[ref]}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var detail string
+			if err != nil {
+				detail = err.Error()
+			} else {
+				if !got.IsSuccess() {
+					t.Errorf("statusValidator.Validate() succeeded = false, want true")
+				}
+				detail = got.Detail()
+			}
+			for _, note := range tt.wantNotes {
+				if !strings.Contains(detail, note) {
+					t.Errorf("statusValidator.Validate() detail does not contain %q:\n%s", note, detail)
+				}
+			}
+			if strings.Contains(detail, "Intermediate commit head-sha") {
+				t.Errorf("statusValidator.Validate() detail reports the head as an intermediate commit:\n%s", detail)
+			}
+		})
+	}
+}
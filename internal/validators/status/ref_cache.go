@@ -0,0 +1,46 @@
+package status
+
+import (
+	"context"
+	"time"
+)
+
+// refCacheEntry is the statuses of the jobs for a ref, which are reused while they are fresh.
+type refCacheEntry struct {
+	statuses  []*ghaStatus
+	info      fetchInfo
+	fetchedAt time.Time
+}
+
+func refCacheKey(owner, repo, ref string) string {
+	return owner + "/" + repo + "@" + ref
+}
+
+// listGhaStatusesForRef returns the statuses of the jobs for the ref, along with how they have been fetched. When the
+// ref cache is enabled, the statuses fetched within the TTL are reused, so that the same ref is not fetched twice
+// when it is validated as more than one of the head, the intermediate commits and the base branch. The TTL is kept
+// short, so that the statuses are still refreshed on every poll.
+func (sv *statusValidator) listGhaStatusesForRef(ctx context.Context, ref string) ([]*ghaStatus, fetchInfo, error) {
+	if sv.refCacheTTL <= 0 {
+		return sv.fetchGhaStatusesForRef(ctx, ref)
+	}
+
+	key := refCacheKey(sv.owner, sv.repo, ref)
+	now := sv.now()
+	if e, ok := sv.refCache[key]; ok && now.Sub(e.fetchedAt) < sv.refCacheTTL {
+		return e.statuses, e.info, nil
+	}
+
+	statuses, info, err := sv.fetchGhaStatusesForRef(ctx, ref)
+	if err != nil {
+		return nil, fetchInfo{}, err
+	}
+	// The partial results are not cached, so that the failed pages are fetched again.
+	if info.partialErr == nil {
+		if sv.refCache == nil {
+			sv.refCache = make(map[string]refCacheEntry)
+		}
+		sv.refCache[key] = refCacheEntry{statuses: statuses, info: info, fetchedAt: now}
+	}
+	return statuses, info, nil
+}
@@ -0,0 +1,70 @@
+package status
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_refCache(t *testing.T) {
+	const (
+		head         = "0123456789abcdef0123456789abcdef01234567"
+		intermediate = "89abcdef0123456789abcdef0123456789abcdef"
+	)
+	now := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	fetched := make(map[string]int)
+	c := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			fetched[ref]++
+			return &github.CombinedStatus{}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{
+				CheckRuns: []*github.CheckRun{
+					{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion)},
+				},
+			}, nil, nil
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef(head),
+		WithSelfJob("self-job"),
+		// The same commit is given twice, as when a push range is computed from overlapping pushes.
+		WithIntermediateSHAs(intermediate+","+intermediate),
+		WithRefCacheTTL(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+	sv := v.(*statusValidator)
+	sv.clock = func() time.Time { return now }
+
+	if _, err := v.Validate(context.Background()); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if want := map[string]int{head: 1, intermediate: 1}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("fetched refs = %v, want %v as the duplicate ref hits the cache", fetched, want)
+	}
+
+	// The next poll within the TTL is served from the cache as well.
+	now = now.Add(time.Second)
+	if _, err := v.Validate(context.Background()); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if want := map[string]int{head: 1, intermediate: 1}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("fetched refs = %v, want %v within the TTL", fetched, want)
+	}
+
+	now = now.Add(5 * time.Second)
+	if _, err := v.Validate(context.Background()); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if want := map[string]int{head: 2, intermediate: 2}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("fetched refs = %v, want %v once the TTL has passed", fetched, want)
+	}
+}
@@ -0,0 +1,25 @@
+package status
+
+import "fmt"
+
+// regressionNotes returns the notes for the jobs which have succeeded in a previous poll but no longer do, e.g. as
+// they have been rerun during the wait, along with the names of the jobs. Each regression is reported once, and the
+// job is watched again once it has succeeded again.
+func (sv *statusValidator) regressionNotes(jobStatuses []JobStatus) ([]string, []string) {
+	var notes, regressed []string
+	for _, js := range jobStatuses {
+		_, succeeded := sv.succeededJobs[js.Job]
+		switch {
+		case js.State == successState && !succeeded:
+			if sv.succeededJobs == nil {
+				sv.succeededJobs = make(map[string]struct{}, len(jobStatuses))
+			}
+			sv.succeededJobs[js.Job] = struct{}{}
+		case js.State != successState && succeeded:
+			delete(sv.succeededJobs, js.Job)
+			regressed = append(regressed, js.Job)
+			notes = append(notes, fmt.Sprintf("%s regressed from %s to %s since the previous poll", js.Job, successState, js.State))
+		}
+	}
+	return notes, regressed
+}
@@ -0,0 +1,109 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_regression(t *testing.T) {
+	const note = "test regressed from success to pending since the previous poll"
+
+	tests := map[string]struct {
+		polls            [][2]string
+		failOnRegression bool
+		wantFailedAt     int
+		wantNoteAt       int
+	}{
+		"reports the regressed job and keeps waiting by default": {
+			polls: [][2]string{
+				{successState, pendingState},
+				{successState, pendingState},
+				{pendingState, pendingState},
+				{pendingState, successState},
+			},
+			wantFailedAt: -1,
+			wantNoteAt:   2,
+		},
+		"fails as soon as the job regresses with the option": {
+			polls: [][2]string{
+				{successState, pendingState},
+				{successState, pendingState},
+				{pendingState, pendingState},
+			},
+			failOnRegression: true,
+			wantFailedAt:     2,
+			wantNoteAt:       2,
+		},
+		"does not regard the job which has never succeeded as regressed": {
+			polls: [][2]string{
+				{pendingState, pendingState},
+				{pendingState, successState},
+				{successState, successState},
+			},
+			failOnRegression: true,
+			wantFailedAt:     -1,
+			wantNoteAt:       -1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var poll int
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					states := tt.polls[poll]
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("test"), State: stringPtr(states[0])},
+							{Context: stringPtr("build"), State: stringPtr(states[1])},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithFailOnRegression(tt.failOnRegression),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			for poll = range tt.polls {
+				got, err := v.Validate(context.Background())
+				var notes []string
+				var fe *failureError
+				switch {
+				case errors.As(err, &fe):
+					if poll != tt.wantFailedAt {
+						t.Fatalf("Validate() of poll %d failed, want to fail at poll %d", poll, tt.wantFailedAt)
+					}
+					if !containsJob(fe.status.errJobs, "test") {
+						t.Errorf("Validate() failed jobs = %v, want the regressed job", fe.status.errJobs)
+					}
+					notes = fe.status.notes
+				case err != nil:
+					t.Fatalf("Validate() of poll %d error = %v", poll, err)
+				default:
+					if poll == tt.wantFailedAt {
+						t.Fatalf("Validate() of poll %d did not fail", poll)
+					}
+					notes = got.(*status).notes
+				}
+				if containsJob(notes, note) != (poll == tt.wantNoteAt) {
+					t.Errorf("Validate() notes of poll %d = %v, want the note only at poll %d", poll, notes, tt.wantNoteAt)
+				}
+				if fe != nil {
+					return
+				}
+			}
+		})
+	}
+}
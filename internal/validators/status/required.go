@@ -0,0 +1,79 @@
+package status
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// jobPattern matches job names. A pattern enclosed in slashes such as "/^e2e-.*$/" is a regular expression,
+// and anything else is a glob pattern such as "e2e-*".
+type jobPattern struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func parseJobPattern(pattern string) (jobPattern, error) {
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return jobPattern{}, fmt.Errorf("invalid job pattern %q: %w", pattern, err)
+		}
+		return jobPattern{pattern: pattern, re: re}, nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return jobPattern{}, fmt.Errorf("invalid job pattern %q: %w", pattern, err)
+	}
+	return jobPattern{pattern: pattern}, nil
+}
+
+func (p jobPattern) match(job string) bool {
+	if p.re != nil {
+		return p.re.MatchString(job)
+	}
+	ok, _ := path.Match(p.pattern, job)
+	return ok
+}
+
+// LoadRequiredChecks reads the required checks file, which has a job pattern per line, so that the required checks
+// can be declared in the repository and reviewed in pull requests. Blank lines and lines starting with "#" are
+// skipped. All patterns are validated when loading.
+func LoadRequiredChecks(r io.Reader) ([]string, error) {
+	var patterns []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		pattern := strings.TrimSpace(scanner.Text())
+		if len(pattern) == 0 || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		if _, err := parseJobPattern(pattern); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read required checks: %w", err)
+	}
+	return patterns, nil
+}
+
+// missingRequiredJobs returns the required job patterns which no job matches yet.
+func (sv *statusValidator) missingRequiredJobs(ghaStatuses []*ghaStatus) []string {
+	var missing []string
+	for _, p := range sv.requiredJobs {
+		var found bool
+		for _, ghaStatus := range ghaStatuses {
+			if p.match(ghaStatus.Job) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, p.pattern)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,170 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func TestLoadRequiredChecks(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    []string
+		wantErr bool
+	}{
+		"returns patterns skipping blank lines and comments": {
+			content: `# Required checks
+build
+
+  e2e-*
+/^lint \(.+\)$/
+`,
+			want: []string{"build", "e2e-*", `/^lint \(.+\)$/`},
+		},
+		"returns error when a glob pattern is invalid": {
+			content: "build\ne2e-[\n",
+			wantErr: true,
+		},
+		"returns error when a regular expression is invalid": {
+			content: "/lint (/\n",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := LoadRequiredChecks(strings.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadRequiredChecks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadRequiredChecks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_jobPattern_match(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		job     string
+		want    bool
+	}{
+		"matches the exact name":                {pattern: "build", job: "build", want: true},
+		"matches the glob pattern":              {pattern: "e2e-*", job: "e2e-chrome", want: true},
+		"does not match the glob pattern":       {pattern: "e2e-*", job: "unit", want: false},
+		"matches the regular expression":        {pattern: `/^lint \(.+\)$/`, job: "lint (go)", want: true},
+		"does not match the regular expression": {pattern: `/^lint$/`, job: "lint (go)", want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := parseJobPattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("parseJobPattern() unexpected error: %v", err)
+			}
+			if got := p.match(tt.job); got != tt.want {
+				t.Errorf("jobPattern.match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_requiredJobs(t *testing.T) {
+	tests := map[string]struct {
+		patterns        []string
+		conditionalJobs string
+		wantSucceeded   bool
+		failOnMissing   bool
+		wantErr         bool
+		wantNote        string
+		wantMissing     []string
+	}{
+		"succeeds when all required jobs are reported": {
+			patterns:      []string{"build", "e2e-*"},
+			wantSucceeded: true,
+		},
+		"keeps waiting when a required job is not reported yet": {
+			patterns:      []string{"build", "/^deploy/"},
+			wantSucceeded: false,
+			wantNote:      "Required job /^deploy/ is not reported yet",
+			wantMissing:   []string{"/^deploy/"},
+		},
+		"fails when a required job is not reported yet without regarding it as pending": {
+			patterns:      []string{"build", "/^deploy/"},
+			failOnMissing: true,
+			wantErr:       true,
+			wantNote:      "Required job /^deploy/ is not reported yet",
+			wantMissing:   []string{"/^deploy/"},
+		},
+		"succeeds when all required jobs are reported without regarding missing ones as pending": {
+			patterns:      []string{"build", "e2e-*"},
+			failOnMissing: true,
+			wantSucceeded: true,
+		},
+		"succeeds when a required conditional job is not reported": {
+			patterns:        []string{"build", "docs-lint"},
+			conditionalJobs: "docs-lint",
+			wantSucceeded:   true,
+		},
+		"succeeds when a required job matching a conditional pattern is not reported": {
+			patterns:        []string{"build", "docs-lint"},
+			conditionalJobs: "docs-*",
+			wantSucceeded:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := CreateValidator(&mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("build"), State: stringPtr(successState)},
+							{Context: stringPtr("e2e-chrome"), State: stringPtr(successState)},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithRequiredJobs(tt.patterns...),
+				WithConditionalJobs(tt.conditionalJobs),
+				WithRequiredMissingAsPending(!tt.failOnMissing),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			var st *status
+			if tt.wantErr {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("statusValidator.Validate() error = %v, want failure", err)
+				}
+				st = fe.status
+			} else {
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() unexpected error: %v", err)
+				}
+				st = got.(*status)
+				if st.IsSuccess() != tt.wantSucceeded {
+					t.Errorf("statusValidator.Validate() IsSuccess() = %v, want %v", st.IsSuccess(), tt.wantSucceeded)
+				}
+			}
+			if len(tt.wantNote) != 0 && !strings.Contains(st.Detail(), tt.wantNote) {
+				t.Errorf("statusValidator.Validate() detail does not contain %q:\n%s", tt.wantNote, st.Detail())
+			}
+			if !reflect.DeepEqual(st.MissingRequiredJobs(), tt.wantMissing) {
+				t.Errorf("statusValidator.Validate() MissingRequiredJobs() = %v, want %v", st.MissingRequiredJobs(), tt.wantMissing)
+			}
+		})
+	}
+}
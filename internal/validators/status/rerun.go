@@ -0,0 +1,16 @@
+package status
+
+import "fmt"
+
+// summarizeReruns returns the attempts of the jobs which have been rerun, e.g. "e2e: 3 attempts, the latest is used",
+// so that the flaky jobs are surfaced.
+func summarizeReruns(jobStatuses []JobStatus) []string {
+	var summaries []string
+	for _, js := range jobStatuses {
+		if js.Attempts <= 1 {
+			continue
+		}
+		summaries = append(summaries, fmt.Sprintf("%s: %d attempts, the latest is used", js.Job, js.Attempts))
+	}
+	return summaries
+}
@@ -0,0 +1,111 @@
+package status
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_reruns(t *testing.T) {
+	startedAt := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	c := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{
+				CheckRuns: []*github.CheckRun{
+					{Name: stringPtr("e2e"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailureConclusion), StartedAt: &github.Timestamp{Time: startedAt}},
+					{Name: stringPtr("e2e"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), StartedAt: &github.Timestamp{Time: startedAt.Add(20 * time.Minute)}},
+					{Name: stringPtr("e2e"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailureConclusion), StartedAt: &github.Timestamp{Time: startedAt.Add(10 * time.Minute)}},
+					{Name: stringPtr("lint"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), StartedAt: &github.Timestamp{Time: startedAt}},
+					{Name: stringPtr("unit"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailureConclusion), StartedAt: &github.Timestamp{Time: startedAt}},
+					{Name: stringPtr("unit"), Status: stringPtr(checkRunInProgressStatus), StartedAt: &github.Timestamp{Time: startedAt.Add(5 * time.Minute)}},
+				},
+			}, nil, nil
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+
+	got, err := v.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	st := got.(*status)
+
+	attempts := make(map[string]int)
+	for _, js := range st.JobStatuses() {
+		attempts[js.Job] = js.Attempts
+	}
+	if want := map[string]int{"e2e": 3, "lint": 0, "unit": 2}; !reflect.DeepEqual(attempts, want) {
+		t.Errorf("Validate() attempts = %v, want %v", attempts, want)
+	}
+	if want := []string{"e2e: 3 attempts, the latest is used", "unit: 2 attempts, the latest is used"}; !reflect.DeepEqual(st.reruns, want) {
+		t.Errorf("Validate() reruns = %v, want %v", st.reruns, want)
+	}
+	if !strings.Contains(st.Detail(), "::group::Reruns\n- e2e: 3 attempts, the latest is used\n- unit: 2 attempts, the latest is used\n::endgroup::") {
+		t.Errorf("Detail() = %s, want the reruns", st.Detail())
+	}
+}
+
+func Test_statusValidator_Validate_reruns_jobAliases(t *testing.T) {
+	startedAt := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		runs []*github.CheckRun
+	}{
+		"uses the rerun under the new name after the run under the old name": {
+			runs: []*github.CheckRun{
+				{Name: stringPtr("test"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailureConclusion), StartedAt: &github.Timestamp{Time: startedAt}},
+				{Name: stringPtr("unit-test"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), StartedAt: &github.Timestamp{Time: startedAt.Add(10 * time.Minute)}},
+			},
+		},
+		"uses the rerun under the new name before the run under the old name": {
+			runs: []*github.CheckRun{
+				{Name: stringPtr("unit-test"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), StartedAt: &github.Timestamp{Time: startedAt.Add(10 * time.Minute)}},
+				{Name: stringPtr("test"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunFailureConclusion), StartedAt: &github.Timestamp{Time: startedAt}},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{CheckRuns: tt.runs}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithJobAliases(map[string]string{"test": "unit-test"}),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if err != nil || !got.IsSuccess() {
+				t.Fatalf("Validate() = %v, %v, want success of the latest run", got, err)
+			}
+			st := got.(*status)
+			if want := []string{"unit-test: 2 attempts, the latest is used"}; !reflect.DeepEqual(st.reruns, want) {
+				t.Errorf("Validate() reruns = %v, want %v", st.reruns, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package status
+
+import "fmt"
+
+// selfFailureNote returns the note when the self job has failed. The self job is regarded as success as it cannot
+// wait for itself, which would otherwise mask a failing step before the gatekeeper in the same job.
+func (sv *statusValidator) selfFailureNote(s *ghaStatus) string {
+	if !sv.reportSelfFailure {
+		return ""
+	}
+	switch s.State {
+	case errorState, failureState:
+		return fmt.Sprintf("Self job %s is in %s state, which is not blocking but may hide a failing step of the same job", s.Job, s.State)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,73 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_reportSelfFailure(t *testing.T) {
+	const note = "Self job self-job is in error state, which is not blocking but may hide a failing step of the same job"
+
+	tests := map[string]struct {
+		selfState         string
+		reportSelfFailure bool
+		wantNote          bool
+	}{
+		"reports the self job in error state with the option": {
+			selfState:         errorState,
+			reportSelfFailure: true,
+			wantNote:          true,
+		},
+		"does not report the self job in error state without the option": {
+			selfState:         errorState,
+			reportSelfFailure: false,
+			wantNote:          false,
+		},
+		"does not report the pending self job with the option": {
+			selfState:         pendingState,
+			reportSelfFailure: true,
+			wantNote:          false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("self-job"), State: stringPtr(tt.selfState)},
+							{Context: stringPtr("build"), State: stringPtr(successState)},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithReportSelfFailure(tt.reportSelfFailure),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("Validate() error = %v, want the self job not blocking", err)
+			}
+			st := got.(*status)
+			if !st.IsSuccess() {
+				t.Errorf("Validate() IsSuccess() = false, want true")
+			}
+			if containsJob(st.notes, note) != tt.wantNote {
+				t.Errorf("Validate() notes = %v, want the note %v", st.notes, tt.wantNote)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_isSelfJob(t *testing.T) {
+	tests := map[string]struct {
+		job       string
+		matrix    bool
+		aliases   map[string]string
+		wantMatch bool
+	}{
+		"matches the exact name": {
+			job:       "self-job",
+			wantMatch: true,
+		},
+		"matches the exact name with the matrix match": {
+			job:       "self-job",
+			matrix:    true,
+			wantMatch: true,
+		},
+		"does not match the job of the matrix by default": {
+			job: "self-job (ubuntu-latest, 1.16)",
+		},
+		"matches the job of the matrix with the matrix match": {
+			job:       "self-job (ubuntu-latest, 1.16)",
+			matrix:    true,
+			wantMatch: true,
+		},
+		"does not match the job of a longer name": {
+			job:    "self-job-e2e",
+			matrix: true,
+		},
+		"does not match the job without the closing parenthesis": {
+			job:    "self-job (ubuntu-latest",
+			matrix: true,
+		},
+		"matches the job of the matrix named after the new name of the self job": {
+			job:       "new-self (ubuntu-latest)",
+			matrix:    true,
+			aliases:   map[string]string{"self-job": "new-self"},
+			wantMatch: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:   "self-job",
+				matrixSelfJob: tt.matrix,
+				jobAliases:    tt.aliases,
+			}
+			if got := sv.isSelfJob(tt.job); got != tt.wantMatch {
+				t.Errorf("isSelfJob(%q) = %v, want %v", tt.job, got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_matrixSelfJobMatch(t *testing.T) {
+	tests := map[string]struct {
+		matrix      bool
+		wantSuccess bool
+	}{
+		"waits for the pending job of the self job matrix by default": {
+			matrix:      false,
+			wantSuccess: false,
+		},
+		"disregards the pending job of the self job matrix with the matrix match": {
+			matrix:      true,
+			wantSuccess: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("self-job"), State: stringPtr(pendingState)},
+							{Context: stringPtr("self-job (ubuntu-latest)"), State: stringPtr(pendingState)},
+							{Context: stringPtr("build"), State: stringPtr(successState)},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithMatrixSelfJobMatch(tt.matrix),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got.IsSuccess() != tt.wantSuccess {
+				t.Errorf("Validate() IsSuccess() = %v, want %v", got.IsSuccess(), tt.wantSuccess)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// minCheckRunsVersion is the earliest version of GitHub Enterprise Server providing the check runs API.
+const minCheckRunsVersion = "2.14"
+
+// detectServerVersion detects the version of GitHub Enterprise Server only once, as it does not change while
+// validating. The version is empty for GitHub.com, which provides all the APIs.
+func (sv *statusValidator) detectServerVersion(ctx context.Context) error {
+	if !sv.detectVersion || sv.versionDetected {
+		return nil
+	}
+	v, _, err := sv.client.GetServerVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to detect the server version: %w", err)
+	}
+	sv.serverVersion = v
+	sv.versionDetected = true
+	return nil
+}
+
+// checkRunsSupported reports whether the server provides the check runs API. Only the commit statuses are validated
+// on the older servers, rather than failing on the missing endpoint.
+func (sv *statusValidator) checkRunsSupported() bool {
+	return len(sv.serverVersion) == 0 || versionAtLeast(sv.serverVersion, minCheckRunsVersion)
+}
+
+func (sv *statusValidator) serverVersionNote() string {
+	if sv.checkRunsSupported() {
+		return ""
+	}
+	return fmt.Sprintf("Check runs are not available on GitHub Enterprise Server %s, so only the commit statuses are validated", sv.serverVersion)
+}
+
+// versionAtLeast reports whether the dotted version v, such as "3.1.4", is min or later. The version which cannot be
+// parsed is regarded as the later one, as it is most likely a newer format.
+func versionAtLeast(v, min string) bool {
+	vs, ms := strings.Split(v, "."), strings.Split(min, ".")
+	for i, m := range ms {
+		if i >= len(vs) {
+			return false
+		}
+		n, err := strconv.Atoi(vs[i])
+		if err != nil {
+			return true
+		}
+		want, _ := strconv.Atoi(m)
+		if n != want {
+			return n > want
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_serverVersion(t *testing.T) {
+	tests := map[string]struct {
+		version        string
+		versionErr     error
+		wantCheckRuns  bool
+		wantTotalJobs  []string
+		wantNotes      []string
+		wantErr        bool
+		wantDetections int
+	}{
+		"uses the check runs on GitHub.com": {
+			version:        "",
+			wantCheckRuns:  true,
+			wantTotalJobs:  []string{"job-01", "job-02"},
+			wantDetections: 1,
+		},
+		"uses the check runs on the version providing them": {
+			version:        "3.1.4",
+			wantCheckRuns:  true,
+			wantTotalJobs:  []string{"job-01", "job-02"},
+			wantDetections: 1,
+		},
+		"uses the check runs on the earliest version providing them": {
+			version:        "2.14.0",
+			wantCheckRuns:  true,
+			wantTotalJobs:  []string{"job-01", "job-02"},
+			wantDetections: 1,
+		},
+		"validates only the commit statuses on the version lacking the check runs": {
+			version:       "2.13.9",
+			wantCheckRuns: false,
+			wantTotalJobs: []string{"job-01"},
+			wantNotes: []string{
+				"Check runs are not available on GitHub Enterprise Server 2.13.9, so only the commit statuses are validated",
+			},
+			wantDetections: 1,
+		},
+		"returns error when the version cannot be detected": {
+			versionErr:     errors.New("err"),
+			wantErr:        true,
+			wantDetections: 1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var detections int
+			var checkRuns bool
+			c := &mock.Client{
+				GetServerVersionFunc: func(ctx context.Context) (string, *github.Response, error) {
+					detections++
+					return tt.version, nil, tt.versionErr
+				},
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("job-01"), State: stringPtr(successState)},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					checkRuns = true
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{Name: stringPtr("job-02"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion)},
+						},
+					}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("self-job"),
+				WithServerVersionDetection(true),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			// The version is detected only once across the polls.
+			for i := 0; i < 2; i++ {
+				got, err := v.Validate(context.Background())
+				if tt.wantErr {
+					if err == nil {
+						t.Fatalf("Validate() error = nil, want error")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				st := got.(*status)
+				if !reflect.DeepEqual(st.totalJobs, tt.wantTotalJobs) {
+					t.Errorf("Validate() totalJobs = %v, want %v", st.totalJobs, tt.wantTotalJobs)
+				}
+				if !reflect.DeepEqual(st.notes, tt.wantNotes) {
+					t.Errorf("Validate() notes = %v, want %v", st.notes, tt.wantNotes)
+				}
+			}
+			if checkRuns != tt.wantCheckRuns {
+				t.Errorf("check runs listed = %v, want %v", checkRuns, tt.wantCheckRuns)
+			}
+			if detections != tt.wantDetections {
+				t.Errorf("GetServerVersion() called %d times, want %d", detections, tt.wantDetections)
+			}
+		})
+	}
+}
+
+func Test_versionAtLeast(t *testing.T) {
+	tests := map[string]struct {
+		v    string
+		min  string
+		want bool
+	}{
+		"returns true when the version is the same":            {v: "2.14", min: "2.14", want: true},
+		"returns true when the patch version is later":         {v: "2.14.1", min: "2.14", want: true},
+		"returns true when the major version is later":         {v: "3.0.0", min: "2.14", want: true},
+		"returns true when the minor version is later":         {v: "2.20.0", min: "2.14", want: true},
+		"returns false when the minor version is earlier":      {v: "2.13.9", min: "2.14", want: false},
+		"returns false when the major version is earlier":      {v: "1.20", min: "2.14", want: false},
+		"returns false when the version is shorter":            {v: "2", min: "2.14", want: false},
+		"returns true when the version cannot be parsed":       {v: "next", min: "2.14", want: true},
+		"returns true when the minor version cannot be parsed": {v: "2.x", min: "2.14", want: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := versionAtLeast(tt.v, tt.min); got != tt.want {
+				t.Errorf("versionAtLeast(%q, %q) = %v, want %v", tt.v, tt.min, got, tt.want)
+			}
+		})
+	}
+}
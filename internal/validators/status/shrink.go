@@ -0,0 +1,41 @@
+package status
+
+import "fmt"
+
+// JobSetShrinkPolicy is the behavior when the number of jobs decreases between polls.
+type JobSetShrinkPolicy string
+
+const (
+	// JobSetShrinkIgnore keeps validating the jobs as they are. This is the default.
+	JobSetShrinkIgnore JobSetShrinkPolicy = ""
+	// JobSetShrinkReset discards the state carried over between polls and restarts the validation.
+	JobSetShrinkReset JobSetShrinkPolicy = "reset"
+	// JobSetShrinkFail fails the validation.
+	JobSetShrinkFail JobSetShrinkPolicy = "fail"
+)
+
+func (p JobSetShrinkPolicy) validate() error {
+	switch p {
+	case JobSetShrinkIgnore, JobSetShrinkReset, JobSetShrinkFail:
+		return nil
+	default:
+		return fmt.Errorf("invalid job set shrink policy: %q, must be either %q or %q", p, JobSetShrinkReset, JobSetShrinkFail)
+	}
+}
+
+// checkJobSetShrink detects the number of jobs decreasing from the previous poll, so that a stale set of checks
+// replaced by a concurrent push is not validated. It returns a message describing the reset if any.
+func (sv *statusValidator) checkJobSetShrink(count int) (string, error) {
+	prev := sv.lastJobCount
+	sv.lastJobCount = count
+	if sv.onJobSetShrink == JobSetShrinkIgnore || count >= prev {
+		return "", nil
+	}
+
+	if sv.onJobSetShrink == JobSetShrinkFail {
+		return "", fmt.Errorf("%w: job count decreased from %d to %d", ErrJobSetShrunk, prev, count)
+	}
+	sv.resetPollState()
+	sv.lastJobCount = count
+	return fmt.Sprintf("Job count decreased from %d to %d, restarting the validation", prev, count), nil
+}
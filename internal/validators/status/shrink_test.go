@@ -0,0 +1,91 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+func Test_statusValidator_Validate_jobSetShrink(t *testing.T) {
+	tests := map[string]struct {
+		policy    JobSetShrinkPolicy
+		counts    []int
+		wantErrIs error
+		wantNote  string
+	}{
+		"ignores the shrinking job set by default": {
+			policy: JobSetShrinkIgnore,
+			counts: []int{3, 2},
+		},
+		"restarts the validation when the job set shrinks with reset": {
+			policy:   JobSetShrinkReset,
+			counts:   []int{3, 2},
+			wantNote: "Job count decreased from 3 to 2, restarting the validation",
+		},
+		"fails when the job set shrinks with fail": {
+			policy:    JobSetShrinkFail,
+			counts:    []int{3, 3, 2},
+			wantErrIs: ErrJobSetShrunk,
+		},
+		"does not fail when the job set grows with fail": {
+			policy: JobSetShrinkFail,
+			counts: []int{2, 3},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var poll int
+			sv := &statusValidator{
+				selfJobName:    "self-job",
+				onJobSetShrink: tt.policy,
+				client: &mock.Client{
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						statuses := make([]*github.RepoStatus, 0, tt.counts[poll])
+						for i := 0; i < tt.counts[poll]; i++ {
+							statuses = append(statuses, &github.RepoStatus{
+								Context: stringPtr(fmt.Sprintf("job-%d", i)),
+								State:   stringPtr(pendingState),
+							})
+						}
+						return &github.CombinedStatus{Statuses: statuses}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{}, nil, nil
+					},
+				},
+			}
+
+			var err error
+			var detail string
+			for poll = range tt.counts {
+				var got validators.Status
+				got, err = sv.Validate(context.Background())
+				if err != nil {
+					break
+				}
+				detail = got.Detail()
+			}
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("statusValidator.Validate() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("statusValidator.Validate() unexpected error: %v", err)
+			}
+			if len(tt.wantNote) != 0 && !strings.Contains(detail, tt.wantNote) {
+				t.Errorf("statusValidator.Validate() detail does not contain %q:\n%s", tt.wantNote, detail)
+			}
+			if len(tt.wantNote) == 0 && strings.Contains(detail, "Job count decreased") {
+				t.Errorf("statusValidator.Validate() detail unexpectedly reports the decrease:\n%s", detail)
+			}
+		})
+	}
+}
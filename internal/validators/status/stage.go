@@ -0,0 +1,117 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+)
+
+// otherStageName is the stage of the jobs which belong to none of the configured stages, rendered last.
+const otherStageName = "other"
+
+// stage is a step of the pipeline such as build, test and deploy, which groups the jobs in the summary.
+// A stage without a pattern is inferred from the names of the jobs, and has the jobs whose names start with the
+// name of the stage, such as "build-linux" and "build / macos" of the "build" stage.
+type stage struct {
+	name    string
+	pattern *jobPattern
+}
+
+// parseStage parses a stage such as "build" or "deploy=/^(deploy|release)-.*$/". The stage is split at the first
+// equal sign, as the name of a stage is not expected to contain one while a regular expression may.
+func parseStage(str string) (stage, error) {
+	name, pattern := str, ""
+	if i := strings.Index(str, "="); i >= 0 {
+		name, pattern = strings.TrimSpace(str[:i]), strings.TrimSpace(str[i+1:])
+		if len(pattern) == 0 {
+			return stage{}, fmt.Errorf("invalid stage %q, must be in the form of name or name=pattern", str)
+		}
+	}
+	if len(name) == 0 {
+		return stage{}, fmt.Errorf("invalid stage %q, must be in the form of name or name=pattern", str)
+	}
+	if len(pattern) == 0 {
+		return stage{name: name}, nil
+	}
+	p, err := parseJobPattern(pattern)
+	if err != nil {
+		return stage{}, fmt.Errorf("invalid stage %q: %w", name, err)
+	}
+	return stage{name: name, pattern: &p}, nil
+}
+
+func (s stage) match(job string) bool {
+	if s.pattern != nil {
+		return s.pattern.match(job)
+	}
+	if !strings.HasPrefix(job, s.name) {
+		return false
+	}
+	rest := job[len(s.name):]
+	return len(rest) == 0 || strings.ContainsRune(" -_/:(", rune(rest[0]))
+}
+
+func (sv *statusValidator) compileStages() error {
+	if len(sv.stageTexts) == 0 {
+		return nil
+	}
+	sv.stages = make([]stage, 0, len(sv.stageTexts))
+	seen := make(map[string]bool, len(sv.stageTexts))
+	for _, text := range sv.stageTexts {
+		s, err := parseStage(text)
+		if err != nil {
+			return err
+		}
+		if seen[s.name] {
+			return fmt.Errorf("duplicate stage: %s", s.name)
+		}
+		seen[s.name] = true
+		sv.stages = append(sv.stages, s)
+	}
+	return nil
+}
+
+// summarizeStages groups the jobs by the stages in their order, e.g. "build: 2/2 completed" followed by the states
+// of its jobs, so that the progress of a pipeline is readable while the later stages are yet to start. Each job
+// belongs to the first stage it matches, and the jobs matching none of the stages are grouped last. It returns nil
+// when no stage is configured.
+func summarizeStages(stages []stage, jobStatuses []JobStatus) []string {
+	if len(stages) == 0 {
+		return nil
+	}
+	groups := make([][]JobStatus, len(stages)+1)
+	for _, js := range jobStatuses {
+		i := len(stages)
+		for j, s := range stages {
+			if s.match(js.Job) {
+				i = j
+				break
+			}
+		}
+		groups[i] = append(groups[i], js)
+	}
+
+	var summaries []string
+	for i, group := range groups {
+		name := otherStageName
+		if i < len(stages) {
+			name = stages[i].name
+		}
+		if len(group) == 0 {
+			if i < len(stages) {
+				summaries = append(summaries, fmt.Sprintf("%s: no jobs reported yet", name))
+			}
+			continue
+		}
+
+		var complete int
+		lines := make([]string, 0, len(group))
+		for _, js := range group {
+			if js.State == successState {
+				complete++
+			}
+			lines = append(lines, fmt.Sprintf("  - %s: %s", js.Job, js.State))
+		}
+		summaries = append(summaries, fmt.Sprintf("%s: %d/%d completed\n%s", name, complete, len(group), strings.Join(lines, "\n")))
+	}
+	return summaries
+}
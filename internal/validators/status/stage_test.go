@@ -0,0 +1,136 @@
+package status
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_summarizeStages(t *testing.T) {
+	mustParse := func(texts ...string) []stage {
+		stages := make([]stage, 0, len(texts))
+		for _, text := range texts {
+			s, err := parseStage(text)
+			if err != nil {
+				t.Fatalf("parseStage() error = %v", err)
+			}
+			stages = append(stages, s)
+		}
+		return stages
+	}
+
+	tests := map[string]struct {
+		stages      []stage
+		jobStatuses []JobStatus
+		want        []string
+	}{
+		"returns nil when no stage is configured": {
+			jobStatuses: []JobStatus{{Job: "build", State: successState}},
+		},
+		"groups the jobs by the stages in their order": {
+			stages: mustParse("build", "test", "deploy"),
+			jobStatuses: []JobStatus{
+				{Job: "build-linux", State: successState},
+				{Job: "build / macos", State: successState},
+				{Job: "deploy", State: pendingState},
+				{Job: "test (unit)", State: successState},
+				{Job: "test_e2e", State: pendingState},
+			},
+			want: []string{
+				"build: 2/2 completed\n  - build-linux: success\n  - build / macos: success",
+				"test: 1/2 completed\n  - test (unit): success\n  - test_e2e: pending",
+				"deploy: 0/1 completed\n  - deploy: pending",
+			},
+		},
+		"infers the stages only at the word boundaries": {
+			stages: mustParse("test"),
+			jobStatuses: []JobStatus{
+				{Job: "testing", State: successState},
+				{Job: "test:lint", State: errorState},
+			},
+			want: []string{
+				"test: 0/1 completed\n  - test:lint: error",
+				"other: 1/1 completed\n  - testing: success",
+			},
+		},
+		"assigns the jobs to the first stage they match": {
+			stages: mustParse("e2e=/^(e2e|smoke)-.*$/", "smoke"),
+			jobStatuses: []JobStatus{
+				{Job: "smoke-login", State: successState},
+			},
+			want: []string{
+				"e2e: 1/1 completed\n  - smoke-login: success",
+				"smoke: no jobs reported yet",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := summarizeStages(tt.stages, tt.jobStatuses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("summarizeStages() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseStage(t *testing.T) {
+	tests := map[string]bool{
+		"build":          false,
+		"e2e=e2e-*":      false,
+		"=e2e-*":         true,
+		"e2e=":           true,
+		"e2e=/[a-z/":     true,
+		"e2e = [a-z":     true,
+		"deploy=/^a=b$/": false,
+	}
+	for text, wantErr := range tests {
+		t.Run(text, func(t *testing.T) {
+			if _, err := parseStage(text); (err != nil) != wantErr {
+				t.Errorf("parseStage(%q) error = %v, wantErr %v", text, err, wantErr)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_stages(t *testing.T) {
+	c := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{
+					{Context: stringPtr("self-job"), State: stringPtr(pendingState)},
+					{Context: stringPtr("build"), State: stringPtr(successState)},
+					{Context: stringPtr("test-unit"), State: stringPtr(pendingState)},
+				},
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{}, nil, nil
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+		WithStages("build", "test", "deploy"),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+
+	got, err := v.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	want := "::group::Jobs by stage\n" +
+		"- build: 1/1 completed\n  - build: success\n" +
+		"- test: 0/1 completed\n  - test-unit: pending\n" +
+		"- deploy: no jobs reported yet\n" +
+		"::endgroup::"
+	if !strings.Contains(got.Detail(), want) {
+		t.Errorf("Detail() = %s, want to contain %s", got.Detail(), want)
+	}
+}
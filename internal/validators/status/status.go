@@ -1,13 +1,52 @@
 package status
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
 
 type status struct {
-	totalJobs    []string
-	completeJobs []string
-	errJobs      []string
-	ignoredJobs  []string
-	succeeded    bool
+	totalJobs     []string
+	completeJobs  []string
+	errJobs       []string
+	ignoredJobs   []string
+	optionalJobs  []string
+	unknownStates []string
+	appSummaries  []string
+	stages        []string
+	environments  []string
+	timings       []string
+	reruns        []string
+	baseFailures  []string
+	notes         []string
+	succeeded     bool
+
+	// jobStatuses are the statuses of the validated jobs, excluding the ignored jobs.
+	jobStatuses []JobStatus
+
+	// missingRequiredJobs are the patterns of the required jobs which are not reported yet.
+	missingRequiredJobs []string
+
+	// unreportedJobs are the conditional jobs which are not reported yet.
+	unreportedJobs []string
+
+	// duration is the time elapsed since the first validation.
+	duration time.Duration
+
+	// summaryTemplate overrides the summary at the top of the detail when it is set.
+	summaryTemplate *template.Template
+
+	// unchanged indicates that the status is reused from the previous poll as nothing has changed.
+	unchanged bool
+
+	// headChanged indicates that the head of the pull request has changed since the previous poll.
+	headChanged bool
+
+	// failingOnly suppresses the completed and ignored job lists from the detail, while still showing the counts.
+	failingOnly bool
 }
 
 func prettyPrintJobList(jobs []string) string {
@@ -26,22 +65,24 @@ func prettyPrintJobList(jobs []string) string {
 }
 
 func (s *status) Detail() string {
-	result := fmt.Sprintf(
-		`%d out of %d
-
-Total job count:       %d
-Completed job count:   %d
-Incompleted job count: %d
-Failed job count:      %d
-Ignored job count:     %d
+	result := s.summary()
+
+	if s.failingOnly {
+		result = fmt.Sprintf(`%s
+::group::Failed jobs
+%s
+::endgroup::
+
+::group::Incomplete jobs
+%s
+::endgroup::
 `,
-		len(s.completeJobs), len(s.totalJobs),
-		len(s.totalJobs),
-		len(s.completeJobs),
-		len(s.getIncompleteJobs()),
-		len(s.errJobs),
-		len(s.ignoredJobs),
-	)
+			result,
+			prettyPrintJobList(s.errJobs),
+			prettyPrintJobList(s.getIncompleteJobs()),
+		)
+		return s.appendExtraSections(result)
+	}
 
 	result = fmt.Sprintf(`%s
 ::group::Failed jobs
@@ -72,9 +113,182 @@ Ignored job count:     %d
 		prettyPrintJobList(s.totalJobs),
 	)
 
+	return s.appendExtraSections(result)
+}
+
+// appendExtraSections appends the sections which are only rendered when they have any entry.
+func (s *status) appendExtraSections(result string) string {
+	if len(s.optionalJobs) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Optional pending jobs
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.optionalJobs),
+		)
+	}
+	if len(s.unreportedJobs) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Unreported conditional jobs
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.unreportedJobs),
+		)
+	}
+	if len(s.unknownStates) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Unknown states
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.unknownStates),
+		)
+	}
+	if len(s.appSummaries) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Jobs per app
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.appSummaries),
+		)
+	}
+	if len(s.stages) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Jobs by stage
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.stages),
+		)
+	}
+	if len(s.environments) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Environments
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.environments),
+		)
+	}
+	if len(s.timings) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Job timings
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.timings),
+		)
+	}
+	if len(s.reruns) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Reruns
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.reruns),
+		)
+	}
+	if len(s.baseFailures) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Base branch failures
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.baseFailures),
+		)
+	}
+	if len(s.notes) != 0 {
+		result = fmt.Sprintf(`%s
+::group::Notes
+%s
+::endgroup::
+`,
+			result,
+			prettyPrintJobList(s.notes),
+		)
+	}
 	return result
 }
 
+// sortJobs sorts all the job lists by name, so that the output is stable between runs
+// regardless of the order returned from the API.
+func (s *status) sortJobs() {
+	sort.Strings(s.totalJobs)
+	sort.Strings(s.completeJobs)
+	sort.Strings(s.errJobs)
+	sort.Strings(s.ignoredJobs)
+	sort.Strings(s.optionalJobs)
+	sort.Strings(s.unreportedJobs)
+	sort.Strings(s.unknownStates)
+	sort.SliceStable(s.jobStatuses, func(i, j int) bool {
+		return s.jobStatuses[i].Job < s.jobStatuses[j].Job
+	})
+}
+
+// JobResultReporter exposes the structured results of the validated jobs, for callers building their own reports.
+// The statuses returned by the validator and the errors of the failed validations implement it.
+type JobResultReporter interface {
+	// JobStatuses returns the statuses of the validated jobs sorted by name, excluding the ignored jobs.
+	JobStatuses() []JobStatus
+	// IgnoredJobs returns the names of the ignored jobs.
+	IgnoredJobs() []string
+}
+
+var (
+	_ JobResultReporter = (*status)(nil)
+	_ JobResultReporter = (*failureError)(nil)
+)
+
+func (s *status) JobStatuses() []JobStatus {
+	return s.jobStatuses
+}
+
+func (s *status) IgnoredJobs() []string {
+	return s.ignoredJobs
+}
+
+// MissingRequiredJobsReporter exposes the required jobs which are not reported yet, so that they can be reported as
+// the cause when the wait times out. The statuses returned by the validator implement it.
+type MissingRequiredJobsReporter interface {
+	MissingRequiredJobs() []string
+}
+
+var _ MissingRequiredJobsReporter = (*status)(nil)
+
+// MissingRequiredJobs returns the patterns of the required jobs which are not reported yet.
+func (s *status) MissingRequiredJobs() []string {
+	return s.missingRequiredJobs
+}
+
+// HeadChangeReporter exposes whether the validated head has changed, so that the wait for the new head can be
+// restarted. The statuses returned by the validator implement it.
+type HeadChangeReporter interface {
+	HeadChanged() bool
+}
+
+var _ HeadChangeReporter = (*status)(nil)
+
+// HeadChanged reports whether the head of the pull request has changed since the previous poll.
+func (s *status) HeadChanged() bool {
+	return s.headChanged
+}
+
+// Unchanged reports whether nothing has changed since the previous poll, in which case the status need not be reported again.
+func (s *status) Unchanged() bool {
+	return s.unchanged
+}
+
 func (s *status) IsSuccess() bool {
 	// TDOO: Add test case
 	return s.succeeded
@@ -111,3 +325,38 @@ func (s *status) getIncompleteJobs() []string {
 	}
 	return incomplete
 }
+
+// Progress returns a single line summary of the current progress, which is suitable for in-place rendering.
+func (s *status) Progress() string {
+	incomplete := s.getIncompleteJobs()
+	result := fmt.Sprintf("%d/%d checks complete", len(s.completeJobs), len(s.totalJobs))
+	if len(incomplete) != 0 {
+		result += fmt.Sprintf(", %d pending: %s", len(incomplete), strings.Join(incomplete, ", "))
+	}
+	if len(s.errJobs) != 0 {
+		result += fmt.Sprintf(", %d failed: %s", len(s.errJobs), strings.Join(s.errJobs, ", "))
+	}
+	return result
+}
+
+// failureError is returned when the validation has failed. Its message is the detail of the status,
+// while the progress is still available for the one-line reports.
+type failureError struct {
+	status *status
+}
+
+func (e *failureError) Error() string {
+	return e.status.Detail()
+}
+
+func (e *failureError) Progress() string {
+	return e.status.Progress()
+}
+
+func (e *failureError) JobStatuses() []JobStatus {
+	return e.status.JobStatuses()
+}
+
+func (e *failureError) IgnoredJobs() []string {
+	return e.status.IgnoredJobs()
+}
@@ -138,6 +138,122 @@ Ignored job count:     0
 ::group::All jobs
 []
 ::endgroup::
+`,
+		},
+		"return detail with unknown states": {
+			s: &status{
+				totalJobs:     []string{"job-1"},
+				completeJobs:  []string{},
+				errJobs:       []string{"job-1"},
+				unknownStates: []string{`job-1: state "foo"`},
+			},
+			want: `0 out of 1
+
+Total job count:       1
+Completed job count:   0
+Incompleted job count: 0
+Failed job count:      1
+Ignored job count:     0
+
+::group::Failed jobs
+- job-1
+::endgroup::
+
+::group::Completed jobs
+[]
+::endgroup::
+
+::group::Incomplete jobs
+[]
+::endgroup::
+
+::group::Ignored jobs
+[]
+::endgroup::
+
+::group::All jobs
+- job-1
+::endgroup::
+
+::group::Unknown states
+- job-1: state "foo"
+::endgroup::
+`,
+		},
+		"return detail with jobs per app": {
+			s: &status{
+				totalJobs:    []string{"job-1", "job-2"},
+				completeJobs: []string{"job-1"},
+				appSummaries: []string{"circleci-checks: 1/1", "github-actions: 0/1"},
+			},
+			want: `1 out of 2
+
+Total job count:       2
+Completed job count:   1
+Incompleted job count: 1
+Failed job count:      0
+Ignored job count:     0
+
+::group::Failed jobs
+[]
+::endgroup::
+
+::group::Completed jobs
+- job-1
+::endgroup::
+
+::group::Incomplete jobs
+- job-2
+::endgroup::
+
+::group::Ignored jobs
+[]
+::endgroup::
+
+::group::All jobs
+- job-1
+- job-2
+::endgroup::
+
+::group::Jobs per app
+- circleci-checks: 1/1
+- github-actions: 0/1
+::endgroup::
+`,
+		},
+		"return detail with failed and incomplete jobs only": {
+			s: &status{
+				totalJobs: []string{
+					"job-1",
+					"job-2",
+					"job-3",
+				},
+				completeJobs: []string{
+					"job-2",
+				},
+				errJobs: []string{
+					"job-3",
+				},
+				ignoredJobs: []string{
+					"job-4",
+				},
+				failingOnly: true,
+			},
+			want: `1 out of 3
+
+Total job count:       3
+Completed job count:   1
+Incompleted job count: 1
+Failed job count:      1
+Ignored job count:     1
+
+::group::Failed jobs
+- job-3
+::endgroup::
+
+::group::Incomplete jobs
+- job-1
+::endgroup::
 `,
 		},
 	}
@@ -151,3 +267,66 @@ Ignored job count:     0
 		})
 	}
 }
+
+func Test_status_Progress(t *testing.T) {
+	tests := map[string]struct {
+		s    *status
+		want string
+	}{
+		"return progress with pending jobs": {
+			s: &status{
+				totalJobs: []string{
+					"job-1",
+					"job-2",
+					"job-3",
+				},
+				completeJobs: []string{
+					"job-2",
+				},
+			},
+			want: "1/3 checks complete, 2 pending: job-1, job-3",
+		},
+		"return progress without pending jobs": {
+			s: &status{
+				totalJobs: []string{
+					"job-1",
+					"job-2",
+				},
+				completeJobs: []string{
+					"job-1",
+					"job-2",
+				},
+			},
+			want: "2/2 checks complete",
+		},
+		"return progress with failed jobs": {
+			s: &status{
+				totalJobs: []string{
+					"job-1",
+					"job-2",
+					"job-3",
+				},
+				completeJobs: []string{
+					"job-2",
+				},
+				errJobs: []string{
+					"job-3",
+				},
+			},
+			want: "1/3 checks complete, 1 pending: job-1, 1 failed: job-3",
+		},
+		"return progress when there is no job": {
+			s:    &status{},
+			want: "0/0 checks complete",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tt.s.Progress()
+			if got != tt.want {
+				t.Errorf("status.Progress() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
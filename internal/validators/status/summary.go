@@ -0,0 +1,72 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"text/template"
+	"time"
+)
+
+// DefaultSummaryTemplate is the template of the summary at the top of the detail, which is used unless
+// a custom one is set with WithSummaryTemplate.
+const DefaultSummaryTemplate = `{{ .Completed }} out of {{ .Total }}
+
+Total job count:       {{ .Total }}
+Completed job count:   {{ .Completed }}
+Incompleted job count: {{ .Pending }}
+Failed job count:      {{ .Failed }}
+Ignored job count:     {{ .Ignored }}
+`
+
+var defaultSummaryTemplate = template.Must(parseSummaryTemplate(DefaultSummaryTemplate))
+
+// SummaryData is the data given to the summary template.
+type SummaryData struct {
+	Total     int
+	Completed int
+	Pending   int
+	Failed    int
+	Ignored   int
+	Succeeded bool
+
+	// Duration is the time elapsed since the first validation.
+	Duration time.Duration
+}
+
+// parseSummaryTemplate parses the template and executes it once with empty data, so that a reference to
+// an unknown field is also reported before validating.
+func parseSummaryTemplate(text string) (*template.Template, error) {
+	t, err := template.New("summary").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse summary template: %w", err)
+	}
+	if err := t.Execute(ioutil.Discard, SummaryData{}); err != nil {
+		return nil, fmt.Errorf("failed to execute summary template: %w", err)
+	}
+	return t, nil
+}
+
+func (s *status) summary() string {
+	data := SummaryData{
+		Total:     len(s.totalJobs),
+		Completed: len(s.completeJobs),
+		Pending:   len(s.getIncompleteJobs()),
+		Failed:    len(s.errJobs),
+		Ignored:   len(s.ignoredJobs),
+		Succeeded: s.succeeded,
+		Duration:  s.duration,
+	}
+
+	t := s.summaryTemplate
+	if t == nil {
+		t = defaultSummaryTemplate
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		// The custom template has been executed once when it is set, so this hardly happens.
+		buf.Reset()
+		defaultSummaryTemplate.Execute(&buf, data)
+	}
+	return buf.String()
+}
@@ -0,0 +1,76 @@
+package status
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_status_summary(t *testing.T) {
+	custom, err := parseSummaryTemplate(`{{ if .Succeeded }}OK{{ else }}WAIT{{ end }} {{ .Completed }}/{{ .Total }}, {{ .Pending }} pending, {{ .Failed }} failed in {{ .Duration }}`)
+	if err != nil {
+		t.Fatalf("parseSummaryTemplate() unexpected error: %v", err)
+	}
+
+	tests := map[string]struct {
+		s    *status
+		want string
+	}{
+		"returns summary with the default template": {
+			s: &status{
+				totalJobs:    []string{"job-1", "job-2"},
+				completeJobs: []string{"job-1"},
+			},
+			want: `1 out of 2
+
+Total job count:       2
+Completed job count:   1
+Incompleted job count: 1
+Failed job count:      0
+Ignored job count:     0
+`,
+		},
+		"returns summary with the custom template": {
+			s: &status{
+				totalJobs:       []string{"job-1", "job-2", "job-3"},
+				completeJobs:    []string{"job-1"},
+				errJobs:         []string{"job-2"},
+				duration:        90 * time.Second,
+				summaryTemplate: custom,
+			},
+			want: "WAIT 1/3, 1 pending, 1 failed in 1m30s",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.s.summary(); got != tt.want {
+				t.Errorf("status.summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseSummaryTemplate(t *testing.T) {
+	tests := map[string]struct {
+		text    string
+		wantErr bool
+	}{
+		"returns template when it is valid": {
+			text: DefaultSummaryTemplate,
+		},
+		"returns error when it cannot be parsed": {
+			text:    "{{ .Total ",
+			wantErr: true,
+		},
+		"returns error when it refers to an unknown field": {
+			text:    "{{ .Unknown }}",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseSummaryTemplate(tt.text); (err != nil) != tt.wantErr {
+				t.Errorf("parseSummaryTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+const maxPullRequestsPerPage = 100
+
+// The results of the pull requests validated by SweepPullRequests.
+const (
+	PullRequestMergeable = "mergeable"
+	PullRequestPending   = "pending"
+	PullRequestFailed    = "failed"
+	PullRequestError     = "error"
+)
+
+// PullRequestResult is the result of the gate for the head of an open pull request.
+type PullRequestResult struct {
+	Number  int
+	Title   string
+	HeadSHA string
+
+	// Result is either PullRequestMergeable, PullRequestPending, PullRequestFailed or PullRequestError.
+	Result string
+
+	// Status is the status of the jobs, which is also set when the gate has failed.
+	Status validators.Status
+
+	// Err is the error of the gate, which is a validation error when the result is PullRequestError.
+	Err error
+}
+
+// SweepPullRequests checks the gate once for the head of each open pull request targeting the base branch, in the
+// order of the pull requests listed by GitHub, so that a scheduled job can audit which of them are mergeable. The
+// pull request and the ref of the options are replaced by each pull request and its head, while the other options
+// apply to every pull request. A pull request which fails to be validated is reported as PullRequestError without
+// aborting the sweep.
+func SweepPullRequests(ctx context.Context, c github.Client, base string, opts ...Option) ([]PullRequestResult, error) {
+	sv := &statusValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	if len(base) == 0 {
+		return nil, errors.New("base branch is empty")
+	}
+	// The ref is set by each pull request, and thus the base stands in for it while validating the other fields.
+	sv.ref = base
+	if errs := sv.validateTargetFields(); len(errs) != 0 {
+		return nil, errs
+	}
+
+	prs, err := listOpenPullRequests(ctx, c, sv.owner, sv.repo, base)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PullRequestResult, 0, len(prs))
+	for _, pr := range prs {
+		// The remaining pull requests cannot be validated once the sweep is cancelled.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r := PullRequestResult{
+			Number:  pr.GetNumber(),
+			Title:   pr.GetTitle(),
+			HeadSHA: pr.GetHead().GetSHA(),
+		}
+		prOpts := append(append([]Option{}, opts...), WithPullRequest(r.Number), WithGitHubRef(r.HeadSHA))
+		st, err := Check(ctx, c, prOpts...)
+		var fe *failureError
+		switch {
+		case errors.As(err, &fe):
+			r.Result, r.Status, r.Err = PullRequestFailed, fe.status, err
+		case err != nil:
+			r.Result, r.Err = PullRequestError, err
+		case st.IsSuccess():
+			r.Result, r.Status = PullRequestMergeable, st
+		default:
+			r.Result, r.Status = PullRequestPending, st
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// listOpenPullRequests returns the open pull requests targeting the base branch from all the pages.
+func listOpenPullRequests(ctx context.Context, c github.Client, owner, repo, base string) ([]*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State:       "open",
+		Base:        base,
+		ListOptions: github.ListOptions{PerPage: maxPullRequestsPerPage},
+	}
+	var prs []*github.PullRequest
+	for page := 1; ; page++ {
+		opts.Page = page
+		list, _, err := c.ListPullRequests(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests of %s/%s targeting %s: %w", owner, repo, base, err)
+		}
+		prs = append(prs, list...)
+		if len(list) < maxPullRequestsPerPage {
+			return prs, nil
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func TestSweepPullRequests(t *testing.T) {
+	pr := func(number int, sha string) *github.PullRequest {
+		title := fmt.Sprintf("PR %d", number)
+		return &github.PullRequest{Number: &number, Title: &title, Head: &github.PullRequestBranch{SHA: &sha}}
+	}
+	// The second page is fetched only when the first page is full.
+	firstPage := []*github.PullRequest{pr(1, "green"), pr(2, "pending"), pr(3, "red")}
+	for i := len(firstPage); i < maxPullRequestsPerPage; i++ {
+		firstPage = append(firstPage, pr(100+i, "green"))
+	}
+	secondPage := []*github.PullRequest{pr(4, "broken")}
+	prs := make(map[int]*github.PullRequest)
+	for _, p := range append(append([]*github.PullRequest{}, firstPage...), secondPage...) {
+		prs[p.GetNumber()] = p
+	}
+
+	states := map[string]string{
+		"green":   successState,
+		"pending": pendingState,
+		"red":     errorState,
+	}
+	var listed []int
+	c := &mock.Client{
+		ListPullRequestsFunc: func(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+			if opts.State != "open" || opts.Base != "main" {
+				t.Errorf("ListPullRequests() state = %s, base = %s, want open and main", opts.State, opts.Base)
+			}
+			listed = append(listed, opts.Page)
+			switch opts.Page {
+			case 1:
+				return firstPage, nil, nil
+			case 2:
+				return secondPage, nil, nil
+			}
+			return nil, nil, nil
+		},
+		// The pull request is fetched again, as the listed pull requests lack some fields such as the mergeable state.
+		GetPullRequestFunc: func(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+			return prs[number], nil, nil
+		},
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			state, ok := states[ref]
+			if !ok {
+				return nil, nil, errors.New("err")
+			}
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{{Context: stringPtr("build"), State: stringPtr(state)}},
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{}, nil, nil
+		},
+	}
+
+	got, err := SweepPullRequests(context.Background(), c, "main",
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithSelfJob("self-job"),
+	)
+	if err != nil {
+		t.Fatalf("SweepPullRequests() error = %v", err)
+	}
+	if len(listed) != 2 {
+		t.Errorf("listed pages = %v, want 2 pages", listed)
+	}
+	if len(got) != maxPullRequestsPerPage+1 {
+		t.Fatalf("SweepPullRequests() returned %d results, want %d", len(got), maxPullRequestsPerPage+1)
+	}
+
+	want := map[int]string{
+		1: PullRequestMergeable,
+		2: PullRequestPending,
+		3: PullRequestFailed,
+		4: PullRequestError,
+	}
+	for _, r := range got {
+		result, ok := want[r.Number]
+		if !ok {
+			result = PullRequestMergeable
+		}
+		if r.Result != result {
+			t.Errorf("result of #%d = %s, want %s", r.Number, r.Result, result)
+		}
+		if (r.Err != nil) != (result == PullRequestFailed || result == PullRequestError) {
+			t.Errorf("error of #%d = %v", r.Number, r.Err)
+		}
+		if (r.Status != nil) != (result != PullRequestError) {
+			t.Errorf("status of #%d = %v", r.Number, r.Status)
+		}
+	}
+}
+
+func TestSweepPullRequests_errors(t *testing.T) {
+	c := &mock.Client{
+		ListPullRequestsFunc: func(ctx context.Context, owner, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+			return nil, nil, errors.New("err")
+		},
+	}
+	tests := map[string]struct {
+		base string
+		opts []Option
+	}{
+		"returns error when the base branch is empty": {
+			opts: []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo")},
+		},
+		"returns error when the repository is empty": {
+			base: "main",
+		},
+		"returns error when the pull requests cannot be listed": {
+			base: "main",
+			opts: []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := SweepPullRequests(context.Background(), c, tt.base, tt.opts...); err == nil {
+				t.Error("SweepPullRequests() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const tagRefPrefix = "refs/tags/"
+
+// The types of the git objects which a tag can point to.
+const (
+	gitObjectCommit = "commit"
+	gitObjectTag    = "tag"
+)
+
+// maxTagDepth is the maximum number of the annotated tags followed, as an annotated tag may point to another tag.
+const maxTagDepth = 8
+
+func isTagRef(ref string) bool {
+	return strings.HasPrefix(ref, tagRefPrefix)
+}
+
+// resolveTag resolves the tag ref to the commit it points to. A lightweight tag points to the commit directly, while
+// an annotated tag is an object of its own which points to the commit, and thus the tag object is followed. Tags are
+// resolved regardless of the ref resolution, as the checks are reported for the commit rather than the tag object.
+func (sv *statusValidator) resolveTag(ctx context.Context) error {
+	r, resp, err := sv.client.GetRef(ctx, sv.owner, sv.repo, sv.ref)
+	if err != nil {
+		return sv.wrapRefError(sv.ref, resp, fmt.Errorf("failed to get tag %s: %w", sv.ref, err))
+	}
+
+	kind := "lightweight"
+	obj := r.GetObject()
+	for depth := 0; obj.GetType() == gitObjectTag; depth++ {
+		if depth == maxTagDepth {
+			return fmt.Errorf("tag %s is nested in more than %d annotated tags", sv.ref, maxTagDepth)
+		}
+		kind = "annotated"
+		tag, _, err := sv.client.GetTag(ctx, sv.owner, sv.repo, obj.GetSHA())
+		if err != nil {
+			return fmt.Errorf("failed to get annotated tag %s of %s: %w", obj.GetSHA(), sv.ref, err)
+		}
+		obj = tag.GetObject()
+	}
+	if obj.GetType() != gitObjectCommit {
+		return fmt.Errorf("tag %s points to %s %s, which is not a commit", sv.ref, obj.GetType(), obj.GetSHA())
+	}
+	if len(obj.GetSHA()) == 0 {
+		return fmt.Errorf("%w ref: %s", ErrEmptyCommitSHA, sv.ref)
+	}
+
+	sv.sha = obj.GetSHA()
+	sv.tagNote = fmt.Sprintf("Tag %s (%s) points to commit %s", strings.TrimPrefix(sv.ref, tagRefPrefix), kind, sv.sha)
+	return nil
+}
@@ -0,0 +1,149 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_resolveTag(t *testing.T) {
+	const (
+		commitSHA = "0123456789abcdef0123456789abcdef01234567"
+		tagSHA    = "89abcdef0123456789abcdef0123456789abcdef"
+		outerSHA  = "fedcba9876543210fedcba9876543210fedcba98"
+	)
+	object := func(kind, sha string) *github.GitObject {
+		return &github.GitObject{Type: stringPtr(kind), SHA: stringPtr(sha)}
+	}
+	tags := map[string]*github.Tag{
+		tagSHA:   {Object: object(gitObjectCommit, commitSHA)},
+		outerSHA: {Object: object(gitObjectTag, tagSHA)},
+	}
+
+	tests := map[string]struct {
+		object     *github.GitObject
+		getRefErr  error
+		resolveRef bool
+		wantSHA    string
+		wantNote   string
+		wantErr    bool
+	}{
+		"resolves lightweight tag to the commit it points to": {
+			object:   object(gitObjectCommit, commitSHA),
+			wantSHA:  commitSHA,
+			wantNote: "Tag v1.0.0 (lightweight) points to commit " + commitSHA,
+		},
+		"resolves annotated tag to the commit of the tag object": {
+			object:   object(gitObjectTag, tagSHA),
+			wantSHA:  commitSHA,
+			wantNote: "Tag v1.0.0 (annotated) points to commit " + commitSHA,
+		},
+		"resolves annotated tag pointing to another annotated tag": {
+			object:   object(gitObjectTag, outerSHA),
+			wantSHA:  commitSHA,
+			wantNote: "Tag v1.0.0 (annotated) points to commit " + commitSHA,
+		},
+		"resolves tag even when ref resolution is disabled": {
+			object:     object(gitObjectCommit, commitSHA),
+			resolveRef: false,
+			wantSHA:    commitSHA,
+			wantNote:   "Tag v1.0.0 (lightweight) points to commit " + commitSHA,
+		},
+		"returns error when tag points to a tree": {
+			object:  object("tree", commitSHA),
+			wantErr: true,
+		},
+		"returns error when tag cannot be got": {
+			getRefErr: errors.New("err"),
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotRef string
+			sv := &statusValidator{
+				owner:      "test-owner",
+				repo:       "test-repo",
+				ref:        "refs/tags/v1.0.0",
+				resolveRef: tt.resolveRef,
+				client: &mock.Client{
+					GetRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+						gotRef = ref
+						if tt.getRefErr != nil {
+							return nil, nil, tt.getRefErr
+						}
+						return &github.Reference{Ref: stringPtr(ref), Object: tt.object}, nil, nil
+					},
+					GetTagFunc: func(ctx context.Context, owner, repo, sha string) (*github.Tag, *github.Response, error) {
+						tag, ok := tags[sha]
+						if !ok {
+							return nil, nil, errors.New("not found")
+						}
+						return tag, nil, nil
+					},
+				},
+			}
+			err := sv.resolveCommitSHA(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.resolveCommitSHA() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotRef != "refs/tags/v1.0.0" {
+				t.Errorf("GetRef() ref = %s, want refs/tags/v1.0.0", gotRef)
+			}
+			if sv.sha != tt.wantSHA {
+				t.Errorf("statusValidator.resolveCommitSHA() sha = %s, want %s", sv.sha, tt.wantSHA)
+			}
+			if sv.tagNote != tt.wantNote {
+				t.Errorf("statusValidator.resolveCommitSHA() tag note = %q, want %q", sv.tagNote, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_tag(t *testing.T) {
+	const commitSHA = "0123456789abcdef0123456789abcdef01234567"
+	var gotRefs []string
+	c := &mock.Client{
+		GetRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+			return &github.Reference{Object: &github.GitObject{Type: stringPtr(gitObjectTag), SHA: stringPtr("tag-sha")}}, nil, nil
+		},
+		GetTagFunc: func(ctx context.Context, owner, repo, sha string) (*github.Tag, *github.Response, error) {
+			return &github.Tag{Object: &github.GitObject{Type: stringPtr(gitObjectCommit), SHA: stringPtr(commitSHA)}}, nil, nil
+		},
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			gotRefs = append(gotRefs, ref)
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{{Context: stringPtr("release-build"), State: stringPtr(successState)}},
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			gotRefs = append(gotRefs, ref)
+			return &github.ListCheckRunsResults{}, nil, nil
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("refs/tags/v1.0.0"),
+		WithSelfJob("self-job"),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+
+	got, err := v.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	for _, ref := range gotRefs {
+		if ref != commitSHA {
+			t.Errorf("Validate() fetched ref %s, want %s", ref, commitSHA)
+		}
+	}
+	st := got.(*status)
+	if note := "Tag v1.0.0 (annotated) points to commit " + commitSHA; !containsJob(st.notes, note) {
+		t.Errorf("Validate() notes = %v, want %q", st.notes, note)
+	}
+}
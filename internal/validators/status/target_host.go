@@ -0,0 +1,44 @@
+package status
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// untrustedTargetNote returns the note when the target URL of the commit status does not point to any of the allowed
+// hosts, so that statuses posted by untrusted parties are flagged. Check runs are not checked, as they can only be
+// created by GitHub Apps.
+func (sv *statusValidator) untrustedTargetNote(s *ghaStatus) string {
+	if len(sv.allowedTargetHosts) == 0 || s.Source != JobSourceCommitStatus {
+		return ""
+	}
+	if len(s.DetailsURL) == 0 {
+		return fmt.Sprintf("Commit status %s has no target URL, which is not an allowed host", s.Job)
+	}
+	u, err := url.Parse(s.DetailsURL)
+	if err != nil || len(u.Hostname()) == 0 {
+		return fmt.Sprintf("Commit status %s has an invalid target URL %q", s.Job, s.DetailsURL)
+	}
+	if !isAllowedHost(sv.allowedTargetHosts, u.Hostname()) {
+		return fmt.Sprintf("Commit status %s targets %s, which is not an allowed host", s.Job, u.Hostname())
+	}
+	return ""
+}
+
+// isAllowedHost reports whether the host matches any of the allowed hosts. An allowed host starting with "*."
+// matches its subdomains.
+func isAllowedHost(allowed []string, host string) bool {
+	for _, a := range allowed {
+		if strings.HasPrefix(a, "*.") {
+			if strings.HasSuffix(strings.ToLower(host), strings.ToLower(a[1:])) {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(a, host) {
+			return true
+		}
+	}
+	return false
+}
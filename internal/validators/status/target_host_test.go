@@ -0,0 +1,107 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_isAllowedHost(t *testing.T) {
+	allowed := []string{"ci.example.com", "*.trusted.dev"}
+	tests := map[string]struct {
+		host string
+		want bool
+	}{
+		"returns true when the host is allowed":                 {host: "ci.example.com", want: true},
+		"returns true when the host differs only in case":       {host: "CI.example.com", want: true},
+		"returns true when the host is a wildcard subdomain":    {host: "build.trusted.dev", want: true},
+		"returns false when the host is not allowed":            {host: "evil.example.com", want: false},
+		"returns false when the host only ends with the domain": {host: "untrusted.dev", want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isAllowedHost(allowed, tt.host); got != tt.want {
+				t.Errorf("isAllowedHost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_allowedTargetHosts(t *testing.T) {
+	tests := map[string]struct {
+		targetURL             string
+		failOnUntrustedTarget bool
+		wantErr               bool
+		wantNote              string
+	}{
+		"succeeds without note when the target host is allowed": {
+			targetURL: "https://ci.example.com/builds/1",
+		},
+		"succeeds with note when the target host is not allowed": {
+			targetURL: "https://evil.example.net/builds/1",
+			wantNote:  "Commit status ci targets evil.example.net, which is not an allowed host",
+		},
+		"succeeds with note when there is no target URL": {
+			wantNote: "Commit status ci has no target URL, which is not an allowed host",
+		},
+		"returns error when the target host is not allowed with fail on untrusted target": {
+			targetURL:             "https://evil.example.net/builds/1",
+			failOnUntrustedTarget: true,
+			wantErr:               true,
+			wantNote:              "Commit status ci targets evil.example.net, which is not an allowed host",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:           "self-job",
+				allowedTargetHosts:    []string{"ci.example.com"},
+				failOnUntrustedTarget: tt.failOnUntrustedTarget,
+				client: &mock.Client{
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						s := &github.RepoStatus{Context: stringPtr("ci"), State: stringPtr(successState)}
+						if len(tt.targetURL) != 0 {
+							s.TargetURL = stringPtr(tt.targetURL)
+						}
+						return &github.CombinedStatus{Statuses: []*github.RepoStatus{s}}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						// Check runs are not subject to the allowed hosts.
+						return &github.ListCheckRunsResults{
+							CheckRuns: []*github.CheckRun{
+								{
+									Name:       stringPtr("build"),
+									Status:     stringPtr(checkRunCompletedStatus),
+									Conclusion: stringPtr(checkRunSuccessConclusion),
+									DetailsURL: stringPtr("https://other.example.org/runs/1"),
+								},
+							},
+						}, nil, nil
+					},
+				},
+			}
+			got, err := sv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var detail string
+			if err != nil {
+				detail = err.Error()
+			} else {
+				detail = got.Detail()
+				if !got.IsSuccess() {
+					t.Errorf("statusValidator.Validate() IsSuccess() = false, want true")
+				}
+			}
+			if len(tt.wantNote) == 0 && strings.Contains(detail, "allowed host") {
+				t.Errorf("statusValidator.Validate() detail unexpectedly flags a host:\n%s", detail)
+			}
+			if !strings.Contains(detail, tt.wantNote) {
+				t.Errorf("statusValidator.Validate() detail does not contain %q:\n%s", tt.wantNote, detail)
+			}
+		})
+	}
+}
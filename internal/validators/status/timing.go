@@ -0,0 +1,85 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// jobTiming is when the job has been observed by the polls.
+type jobTiming struct {
+	firstSeen   time.Time
+	settledSeen time.Time
+}
+
+// observeJobTimings records when each job is first seen and first seen settled, and returns the breakdown of the time
+// spent by each job, e.g. "lint: queued 10s, ran 30s". The times of the
+// check runs are taken from their start and completion, while the commit statuses only have the times observed by
+// the polls. The jobs taking the longest come first, as they are most likely on the critical path.
+func (sv *statusValidator) observeJobTimings(jobStatuses []JobStatus) []string {
+	if !sv.timingReport {
+		return nil
+	}
+	if sv.jobTimings == nil {
+		sv.jobTimings = make(map[string]*jobTiming, len(jobStatuses))
+	}
+
+	now := sv.now()
+	type entry struct {
+		summary string
+		total   time.Duration
+	}
+	entries := make([]entry, 0, len(jobStatuses))
+	for _, js := range jobStatuses {
+		t, ok := sv.jobTimings[js.Job]
+		if !ok {
+			t = &jobTiming{firstSeen: now}
+			sv.jobTimings[js.Job] = t
+		}
+		if js.State != pendingState && t.settledSeen.IsZero() {
+			t.settledSeen = now
+		}
+
+		summary, total := t.breakdown(js, now)
+		entries = append(entries, entry{summary: fmt.Sprintf("%s: %s", js.Job, summary), total: total})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].total != entries[j].total {
+			return entries[i].total > entries[j].total
+		}
+		return entries[i].summary < entries[j].summary
+	})
+	timings := make([]string, 0, len(entries))
+	for _, e := range entries {
+		timings = append(timings, e.summary)
+	}
+	return timings
+}
+
+// breakdown returns the time spent by the job, along with its total. The job still running is counted until now.
+func (t *jobTiming) breakdown(js JobStatus, now time.Time) (string, time.Duration) {
+	if js.StartedAt.IsZero() {
+		end, suffix := t.settledSeen, ""
+		if end.IsZero() {
+			end, suffix = now, " so far"
+		}
+		waited := end.Sub(t.firstSeen)
+		return fmt.Sprintf("waited %s%s", waited.Round(time.Second), suffix), waited
+	}
+
+	var parts []string
+	start := js.StartedAt
+	// The job is known to be queued only from when it is first seen, as it may have been queued before the gate started.
+	if js.StartedAt.After(t.firstSeen) {
+		parts = append(parts, fmt.Sprintf("queued %s", js.StartedAt.Sub(t.firstSeen).Round(time.Second)))
+		start = t.firstSeen
+	}
+	end, suffix := js.CompletedAt, ""
+	if end.IsZero() {
+		end, suffix = now, " so far"
+	}
+	parts = append(parts, fmt.Sprintf("ran %s%s", end.Sub(js.StartedAt).Round(time.Second), suffix))
+	return strings.Join(parts, ", "), end.Sub(start)
+}
@@ -0,0 +1,134 @@
+package status
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_timingReport(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time {
+		return start.Add(time.Duration(sec) * time.Second)
+	}
+	timestamp := func(sec int) *github.Timestamp {
+		return &github.Timestamp{Time: at(sec)}
+	}
+
+	// Each poll is the time of the poll and the responses at the time.
+	polls := []struct {
+		now      time.Time
+		statuses []*github.RepoStatus
+		runs     []*github.CheckRun
+		want     []string
+	}{
+		{
+			now: at(0),
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("status-job"), State: stringPtr(pendingState)},
+			},
+			runs: []*github.CheckRun{
+				{Name: stringPtr("lint"), Status: stringPtr(checkRunQueuedStatus)},
+				{Name: stringPtr("build"), Status: stringPtr(checkRunInProgressStatus), StartedAt: timestamp(-60)},
+			},
+			want: []string{
+				"build: ran 1m0s so far",
+				"lint: waited 0s so far",
+				"status-job: waited 0s so far",
+			},
+		},
+		{
+			now: at(20),
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("status-job"), State: stringPtr(successState)},
+			},
+			runs: []*github.CheckRun{
+				{Name: stringPtr("lint"), Status: stringPtr(checkRunInProgressStatus), StartedAt: timestamp(10)},
+				{Name: stringPtr("build"), Status: stringPtr(checkRunInProgressStatus), StartedAt: timestamp(-60)},
+			},
+			want: []string{
+				"build: ran 1m20s so far",
+				"lint: queued 10s, ran 10s so far",
+				"status-job: waited 20s",
+			},
+		},
+		{
+			now: at(60),
+			statuses: []*github.RepoStatus{
+				{Context: stringPtr("status-job"), State: stringPtr(successState)},
+			},
+			runs: []*github.CheckRun{
+				{Name: stringPtr("lint"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), StartedAt: timestamp(10), CompletedAt: timestamp(40)},
+				{Name: stringPtr("build"), Status: stringPtr(checkRunCompletedStatus), Conclusion: stringPtr(checkRunSuccessConclusion), StartedAt: timestamp(-60), CompletedAt: timestamp(50)},
+			},
+			want: []string{
+				"build: ran 1m50s",
+				"lint: queued 10s, ran 30s",
+				"status-job: waited 20s",
+			},
+		},
+	}
+
+	var poll int
+	v, err := CreateValidator(&mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{Statuses: polls[poll].statuses}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{CheckRuns: polls[poll].runs}, nil, nil
+		},
+	},
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+		WithTimingReport(true),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() unexpected error: %v", err)
+	}
+	v.(*statusValidator).clock = func() time.Time { return polls[poll].now }
+
+	for poll = range polls {
+		got, err := v.Validate(context.Background())
+		if err != nil {
+			t.Fatalf("statusValidator.Validate() #%d unexpected error: %v", poll, err)
+		}
+		if timings := got.(*status).timings; !reflect.DeepEqual(timings, polls[poll].want) {
+			t.Errorf("statusValidator.Validate() #%d timings = %v, want %v", poll, timings, polls[poll].want)
+		}
+	}
+}
+
+func Test_statusValidator_Validate_timingReportDisabled(t *testing.T) {
+	v, err := CreateValidator(&mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{
+					{Context: stringPtr("status-job"), State: stringPtr(pendingState)},
+				},
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{}, nil, nil
+		},
+	},
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() unexpected error: %v", err)
+	}
+
+	got, err := v.Validate(context.Background())
+	if err != nil {
+		t.Fatalf("statusValidator.Validate() unexpected error: %v", err)
+	}
+	if timings := got.(*status).timings; timings != nil {
+		t.Errorf("statusValidator.Validate() timings = %v, want nil", timings)
+	}
+}
@@ -0,0 +1,93 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// toleratedConclusion is a failing check run conclusion which does not block only for the job.
+type toleratedConclusion struct {
+	job        string
+	conclusion string
+}
+
+func (tc toleratedConclusion) validate() error {
+	if len(tc.job) == 0 || len(tc.conclusion) == 0 {
+		return fmt.Errorf("tolerated conclusion must be in the form of job:conclusion, got %q", tc.job+":"+tc.conclusion)
+	}
+	if _, ok := knownCheckRunConclusions[tc.conclusion]; !ok {
+		return fmt.Errorf("unknown conclusion %q is tolerated for job %s", tc.conclusion, tc.job)
+	}
+	return nil
+}
+
+// isToleratedConclusion reports whether the conclusion is tolerated for the job. With the tolerance window, conclusions
+// are tolerated only within the window, and strictly validated outside of it.
+func (sv *statusValidator) isToleratedConclusion(job, conclusion string) bool {
+	if sv.toleranceWindow != nil && !sv.toleranceWindow.contains(sv.now()) {
+		return false
+	}
+	for _, tc := range sv.toleratedConclusions {
+		if tc.job == job && tc.conclusion == conclusion {
+			return true
+		}
+	}
+	return false
+}
+
+// timeWindow is the daily window of time, such as "06:00-10:00". The window ending earlier than its start,
+// such as "22:00-02:00", spans midnight.
+type timeWindow struct {
+	start time.Duration
+	end   time.Duration
+	loc   *time.Location
+}
+
+// parseTimeWindow parses the daily window in the form of "HH:MM-HH:MM" in UTC, optionally followed by
+// the IANA time zone name, e.g. "06:00-10:00 Asia/Tokyo".
+func parseTimeWindow(str string) (*timeWindow, error) {
+	fields := strings.Fields(str)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("invalid time window %q, must be in the form of HH:MM-HH:MM [time zone]", str)
+	}
+	bounds := strings.Split(fields[0], "-")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid time window %q, must be in the form of HH:MM-HH:MM [time zone]", str)
+	}
+
+	w := &timeWindow{loc: time.UTC}
+	for i, bound := range bounds {
+		t, err := time.Parse("15:04", bound)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time window %q: %w", str, err)
+		}
+		d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+		if i == 0 {
+			w.start = d
+		} else {
+			w.end = d
+		}
+	}
+	if w.start == w.end {
+		return nil, fmt.Errorf("invalid time window %q, must not be empty", str)
+	}
+	if len(fields) == 2 {
+		loc, err := time.LoadLocation(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone of time window %q: %w", str, err)
+		}
+		w.loc = loc
+	}
+	return w, nil
+}
+
+// contains reports whether the time is within the window. The start is inclusive, and the end is exclusive.
+func (w *timeWindow) contains(t time.Time) bool {
+	t = t.In(w.loc)
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return w.start <= d && d < w.end
+	}
+	return w.start <= d || d < w.end
+}
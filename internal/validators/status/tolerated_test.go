@@ -0,0 +1,117 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_parseTimeWindow(t *testing.T) {
+	tests := map[string]struct {
+		str     string
+		wantErr bool
+	}{
+		"parses the window in UTC":                    {str: "06:00-10:00"},
+		"parses the window with time zone":            {str: "06:00-10:00 Asia/Tokyo"},
+		"parses the window spanning midnight":         {str: "22:00-02:00"},
+		"returns error when the end is missing":       {str: "06:00", wantErr: true},
+		"returns error when the time is invalid":      {str: "06:00-25:00", wantErr: true},
+		"returns error when the window is empty":      {str: "06:00-06:00", wantErr: true},
+		"returns error when the time zone is unknown": {str: "06:00-10:00 Nowhere/Unknown", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseTimeWindow(tt.str); (err != nil) != tt.wantErr {
+				t.Errorf("parseTimeWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_timeWindow_contains(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2021, 1, 1, hour, min, 0, 0, time.UTC)
+	}
+	tests := map[string]struct {
+		window string
+		t      time.Time
+		want   bool
+	}{
+		"returns true at the start":                         {window: "06:00-10:00", t: at(6, 0), want: true},
+		"returns true within the window":                    {window: "06:00-10:00", t: at(9, 59), want: true},
+		"returns false at the end":                          {window: "06:00-10:00", t: at(10, 0), want: false},
+		"returns false before the window":                   {window: "06:00-10:00", t: at(5, 59), want: false},
+		"returns true after midnight of spanning window":    {window: "22:00-02:00", t: at(1, 0), want: true},
+		"returns false outside of spanning window":          {window: "22:00-02:00", t: at(12, 0), want: false},
+		"returns true within the window of the time zone":   {window: "06:00-10:00 Asia/Tokyo", t: at(0, 0), want: true},
+		"returns false outside the window of the time zone": {window: "06:00-10:00 Asia/Tokyo", t: at(6, 0), want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w, err := parseTimeWindow(tt.window)
+			if err != nil {
+				t.Fatalf("parseTimeWindow() unexpected error: %v", err)
+			}
+			if got := w.contains(tt.t); got != tt.want {
+				t.Errorf("timeWindow.contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_toleranceWindow(t *testing.T) {
+	tests := map[string]struct {
+		now     time.Time
+		wantErr bool
+	}{
+		"tolerates the conclusion within the window": {
+			now:     time.Date(2021, 1, 1, 7, 0, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		"fails on the conclusion outside the window": {
+			now:     time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := CreateValidator(&mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("e2e"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunTimedOutConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			},
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithToleratedConclusions("e2e:timed_out"),
+				WithToleranceWindow("06:00-10:00"),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() unexpected error: %v", err)
+			}
+			v.(*statusValidator).clock = func() time.Time { return tt.now }
+
+			got, err := v.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.IsSuccess() {
+				t.Errorf("statusValidator.Validate() IsSuccess() = false, want true")
+			}
+		})
+	}
+}
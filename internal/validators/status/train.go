@@ -0,0 +1,117 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/validators"
+)
+
+// The results of the commits of a merge train validated by ValidateTrain.
+const (
+	TrainCommitGreen   = "green"
+	TrainCommitPending = "pending"
+	TrainCommitFailed  = "failed"
+	TrainCommitError   = "error"
+)
+
+// TrainCommit is the result of the gate for a commit of a merge train.
+type TrainCommit struct {
+	SHA string
+
+	// Result is either TrainCommitGreen, TrainCommitPending, TrainCommitFailed or TrainCommitError.
+	Result string
+
+	// FailedJobs and PendingJobs are the jobs keeping the commit from being green.
+	FailedJobs  []string
+	PendingJobs []string
+
+	// Status is the status of the jobs, which is also set when the gate has failed.
+	Status validators.Status
+
+	// Err is the error of the gate, which is a validation error when the result is TrainCommitError.
+	Err error
+}
+
+// TrainResult is the result of the gate for the commits of a merge train in their order.
+type TrainResult struct {
+	// Commits are the results of the commits up to the blocking commit, which is the last of them when it is set.
+	Commits []TrainCommit
+
+	// Blocking is the index of the first commit which is not green, where the train is to be truncated, or -1 when
+	// all the commits are green.
+	Blocking int
+}
+
+// BlockingCommit returns the first commit which is not green, or nil when all the commits are green.
+func (r *TrainResult) BlockingCommit() *TrainCommit {
+	if r.Blocking < 0 {
+		return nil
+	}
+	return &r.Commits[r.Blocking]
+}
+
+// ValidateTrain checks the gate once for each commit of a merge train in its order, and stops at the first commit
+// which is not green, as the train is truncated there and the later commits include it. The ref of the options is
+// replaced by each commit, while the other options apply to every commit.
+func ValidateTrain(ctx context.Context, c github.Client, shas []string, opts ...Option) (*TrainResult, error) {
+	if len(shas) == 0 {
+		return nil, errors.New("merge train has no commit")
+	}
+	for i, sha := range shas {
+		if len(sha) == 0 {
+			return nil, fmt.Errorf("commit %d of merge train is empty", i+1)
+		}
+	}
+	// The options are validated once for all the commits, rather than reported as the error of every commit.
+	sv := &statusValidator{
+		client: c,
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	sv.ref = shas[0]
+	if err := sv.validateFields(); err != nil {
+		return nil, err
+	}
+
+	result := &TrainResult{
+		Commits:  make([]TrainCommit, 0, len(shas)),
+		Blocking: -1,
+	}
+	for i, sha := range shas {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		commit := checkTrainCommit(ctx, c, sha, opts...)
+		result.Commits = append(result.Commits, commit)
+		if commit.Result != TrainCommitGreen {
+			result.Blocking = i
+			break
+		}
+	}
+	return result, nil
+}
+
+func checkTrainCommit(ctx context.Context, c github.Client, sha string, opts ...Option) TrainCommit {
+	commit := TrainCommit{SHA: sha}
+	st, err := Check(ctx, c, append(append([]Option{}, opts...), WithGitHubRef(sha))...)
+	var fe *failureError
+	switch {
+	case errors.As(err, &fe):
+		commit.Result, commit.Status, commit.Err = TrainCommitFailed, fe.status, err
+		commit.FailedJobs = fe.status.errJobs
+	case err != nil:
+		commit.Result, commit.Err = TrainCommitError, err
+	case st.IsSuccess():
+		commit.Result, commit.Status = TrainCommitGreen, st
+	default:
+		commit.Result, commit.Status = TrainCommitPending, st
+		if s, ok := st.(*status); ok {
+			commit.PendingJobs = s.getIncompleteJobs()
+		}
+	}
+	return commit
+}
@@ -0,0 +1,138 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func TestValidateTrain(t *testing.T) {
+	statuses := map[string][]*github.RepoStatus{
+		"sha1": {
+			{Context: stringPtr("build"), State: stringPtr(successState)},
+		},
+		"sha2": {
+			{Context: stringPtr("build"), State: stringPtr(successState)},
+			{Context: stringPtr("test"), State: stringPtr(errorState)},
+		},
+		"sha3": {
+			{Context: stringPtr("build"), State: stringPtr(successState)},
+			{Context: stringPtr("test"), State: stringPtr(pendingState)},
+		},
+		"sha4": {
+			{Context: stringPtr("build"), State: stringPtr(successState)},
+		},
+	}
+
+	tests := map[string]struct {
+		shas         []string
+		wantResults  []string
+		wantBlocking int
+		wantFailed   []string
+		wantPending  []string
+	}{
+		"reports the red commit in the middle and stops there": {
+			shas:         []string{"sha1", "sha2", "sha3", "sha4"},
+			wantResults:  []string{TrainCommitGreen, TrainCommitFailed},
+			wantBlocking: 1,
+			wantFailed:   []string{"test"},
+		},
+		"reports the pending commit as blocking": {
+			shas:         []string{"sha1", "sha3", "sha4"},
+			wantResults:  []string{TrainCommitGreen, TrainCommitPending},
+			wantBlocking: 1,
+			wantPending:  []string{"test"},
+		},
+		"reports the commit which fails to be validated as blocking": {
+			shas:         []string{"sha1", "unknown", "sha4"},
+			wantResults:  []string{TrainCommitGreen, TrainCommitError},
+			wantBlocking: 1,
+		},
+		"reports no blocking commit when all the commits are green": {
+			shas:         []string{"sha1", "sha4"},
+			wantResults:  []string{TrainCommitGreen, TrainCommitGreen},
+			wantBlocking: -1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					s, ok := statuses[ref]
+					if !ok {
+						return nil, nil, errors.New("err")
+					}
+					return &github.CombinedStatus{Statuses: s}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+
+			got, err := ValidateTrain(context.Background(), c, tt.shas,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithSelfJob("self-job"),
+			)
+			if err != nil {
+				t.Fatalf("ValidateTrain() error = %v", err)
+			}
+			var results []string
+			for i, commit := range got.Commits {
+				if commit.SHA != tt.shas[i] {
+					t.Errorf("commit %d = %s, want %s", i, commit.SHA, tt.shas[i])
+				}
+				results = append(results, commit.Result)
+			}
+			if !reflect.DeepEqual(results, tt.wantResults) {
+				t.Errorf("ValidateTrain() results = %v, want %v", results, tt.wantResults)
+			}
+			if got.Blocking != tt.wantBlocking {
+				t.Fatalf("ValidateTrain() blocking = %d, want %d", got.Blocking, tt.wantBlocking)
+			}
+
+			blocking := got.BlockingCommit()
+			if tt.wantBlocking < 0 {
+				if blocking != nil {
+					t.Errorf("BlockingCommit() = %+v, want nil", blocking)
+				}
+				return
+			}
+			if !reflect.DeepEqual(blocking.FailedJobs, tt.wantFailed) {
+				t.Errorf("BlockingCommit() failed jobs = %v, want %v", blocking.FailedJobs, tt.wantFailed)
+			}
+			if !reflect.DeepEqual(blocking.PendingJobs, tt.wantPending) {
+				t.Errorf("BlockingCommit() pending jobs = %v, want %v", blocking.PendingJobs, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestValidateTrain_errors(t *testing.T) {
+	tests := map[string]struct {
+		shas []string
+		opts []Option
+	}{
+		"returns error when the train is empty": {
+			opts: []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo"), WithSelfJob("self-job")},
+		},
+		"returns error when a commit is empty": {
+			shas: []string{"sha1", ""},
+			opts: []Option{WithGitHubOwnerAndRepo("test-owner", "test-repo"), WithSelfJob("self-job")},
+		},
+		"returns error when the options are invalid": {
+			shas: []string{"sha1"},
+			opts: []Option{WithSelfJob("self-job")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ValidateTrain(context.Background(), &mock.Client{}, tt.shas, tt.opts...); err == nil {
+				t.Error("ValidateTrain() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package status
+
+// StatusTransformer transforms the statuses of the jobs collected on each poll before the result is computed, so that
+// the jobs can be renamed, dropped or synthesized, e.g. from a system which reports to neither of the APIs. The
+// returned statuses replace the collected ones, and are validated in the same way, the ignored jobs and the self job
+// included. The state of a synthesized job should be one of "success", "failure", "error" or "pending".
+type StatusTransformer func(statuses []JobStatus) []JobStatus
+
+// transformStatuses applies the transformer to the collected statuses. The statuses which are returned from the
+// collected ones keep the details which are not exposed by JobStatus, such as the ID of the check run, even when they
+// are renamed. The collected statuses themselves are never modified, as they may be cached across the polls.
+func (sv *statusValidator) transformStatuses(ghaStatuses []*ghaStatus) []*ghaStatus {
+	if sv.transformer == nil {
+		return ghaStatuses
+	}
+
+	statuses := make([]JobStatus, 0, len(ghaStatuses))
+	for _, s := range ghaStatuses {
+		js := s.jobStatus()
+		js.origin = s
+		statuses = append(statuses, js)
+	}
+
+	transformed := sv.transformer(statuses)
+	result := make([]*ghaStatus, 0, len(transformed))
+	for _, js := range transformed {
+		s := &ghaStatus{}
+		if js.origin != nil {
+			*s = *js.origin
+		}
+		s.Job = js.Job
+		s.State = js.State
+		s.Source = js.Source
+		s.DetailsURL = js.DetailsURL
+		s.CheckSuiteID = js.CheckSuiteID
+		s.App = js.App
+		s.StartedAt = js.StartedAt
+		s.CompletedAt = js.CompletedAt
+		s.Unknown = js.Unknown
+		s.Tolerated = js.Tolerated
+		s.Attempts = js.Attempts
+		result = append(result, s)
+	}
+	return result
+}
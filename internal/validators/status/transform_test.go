@@ -0,0 +1,120 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+// dropAndRename drops the jobs of the bot, renames the legacy job and synthesizes an external job.
+func dropAndRename(statuses []JobStatus) []JobStatus {
+	transformed := make([]JobStatus, 0, len(statuses))
+	for _, s := range statuses {
+		switch {
+		case strings.HasPrefix(s.Job, "bot/"):
+			continue
+		case s.Job == "ci/legacy":
+			s.Job = "build"
+		}
+		transformed = append(transformed, s)
+	}
+	return append(transformed, JobStatus{Job: "external", State: successState, Source: JobSourceCommitStatus})
+}
+
+func Test_statusValidator_Validate_statusTransformer(t *testing.T) {
+	var annotationRequests int
+	c := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{
+					{Context: stringPtr("bot/flaky"), State: stringPtr(failureState)},
+				},
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{
+				CheckRuns: []*github.CheckRun{
+					{
+						ID:         int64Ptr(42),
+						Name:       stringPtr("ci/legacy"),
+						Status:     stringPtr(checkRunCompletedStatus),
+						Conclusion: stringPtr(checkRunSuccessConclusion),
+						Output:     &github.CheckRunOutput{AnnotationsCount: intPtr(1)},
+					},
+				},
+			}, nil, nil
+		},
+		ListCheckRunAnnotationsFunc: func(ctx context.Context, owner, repo string, checkRunID int64, opts *github.ListOptions) ([]*github.CheckRunAnnotation, *github.Response, error) {
+			annotationRequests++
+			if checkRunID != 42 {
+				t.Errorf("ListCheckRunAnnotations() check run = %d, want 42 of the renamed job", checkRunID)
+			}
+			return nil, nil, nil
+		},
+	}
+	v, err := CreateValidator(c,
+		WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+		WithGitHubRef("main"),
+		WithSelfJob("self-job"),
+		WithRequiredJobs("build"),
+		WithAnnotationCheckedJobs("build"),
+		WithStatusTransformer(dropAndRename),
+	)
+	if err != nil {
+		t.Fatalf("CreateValidator() error = %v", err)
+	}
+
+	got, err := v.Validate(context.Background())
+	if err != nil || !got.IsSuccess() {
+		t.Fatalf("Validate() = %v, %v, want success", got, err)
+	}
+	st := got.(*status)
+	for _, job := range []string{"build", "external"} {
+		if !containsJob(st.completeJobs, job) {
+			t.Errorf("Validate() complete jobs = %v, want %s", st.completeJobs, job)
+		}
+	}
+	for _, job := range []string{"bot/flaky", "ci/legacy"} {
+		if containsJob(st.totalJobs, job) {
+			t.Errorf("Validate() total jobs = %v, want %s transformed away", st.totalJobs, job)
+		}
+	}
+	// The renamed job keeps the details of its check run, and thus its annotations are still checked.
+	if annotationRequests != 1 {
+		t.Errorf("ListCheckRunAnnotations() called %d times, want 1", annotationRequests)
+	}
+}
+
+func Test_statusValidator_transformStatuses(t *testing.T) {
+	collected := []*ghaStatus{
+		{Job: "ci/legacy", State: successState, Source: JobSourceCheckRun, CheckRunID: 42, Annotations: 3},
+		{Job: "bot/flaky", State: failureState, Source: JobSourceCommitStatus},
+	}
+
+	t.Run("returns the collected statuses as they are without the transformer", func(t *testing.T) {
+		sv := &statusValidator{}
+		if got := sv.transformStatuses(collected); len(got) != 2 || got[0] != collected[0] || got[1] != collected[1] {
+			t.Errorf("transformStatuses() = %v, want the collected statuses", got)
+		}
+	})
+
+	t.Run("drops, renames and synthesizes the statuses", func(t *testing.T) {
+		sv := &statusValidator{transformer: dropAndRename}
+		got := sv.transformStatuses(collected)
+		if len(got) != 2 {
+			t.Fatalf("transformStatuses() = %v, want 2 statuses", got)
+		}
+		if got[0].Job != "build" || got[0].CheckRunID != 42 || got[0].Annotations != 3 {
+			t.Errorf("transformStatuses()[0] = %+v, want build keeping the details of the check run", got[0])
+		}
+		if got[1].Job != "external" || got[1].State != successState || got[1].CheckRunID != 0 {
+			t.Errorf("transformStatuses()[1] = %+v, want the synthesized external job", got[1])
+		}
+		if collected[0].Job != "ci/legacy" {
+			t.Errorf("collected status is renamed to %s, want it unmodified", collected[0].Job)
+		}
+	})
+}
@@ -4,51 +4,343 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/upsidr/merge-gatekeeper/internal/github"
 	"github.com/upsidr/merge-gatekeeper/internal/multierror"
 	"github.com/upsidr/merge-gatekeeper/internal/validators"
 )
 
+const tracerName = "github.com/upsidr/merge-gatekeeper/internal/validators/status"
+
 const (
 	successState = "success"
 	errorState   = "error"
 	failureState = "failure"
 	pendingState = "pending"
+
+	// expectedState is reported for the statuses which are required by the branch protection but not reported yet.
+	expectedState = "expected"
 )
 
 // NOTE: https://docs.github.com/en/rest/reference/checks
 const (
-	checkRunCompletedStatus = "completed"
+	checkRunQueuedStatus     = "queued"
+	checkRunInProgressStatus = "in_progress"
+	checkRunCompletedStatus  = "completed"
 )
 const (
-	checkRunNeutralConclusion = "neutral"
-	checkRunSuccessConclusion = "success"
-	checkRunSkipConclusion    = "skipped"
+	checkRunNeutralConclusion        = "neutral"
+	checkRunSuccessConclusion        = "success"
+	checkRunSkipConclusion           = "skipped"
+	checkRunFailureConclusion        = "failure"
+	checkRunCancelledConclusion      = "cancelled"
+	checkRunTimedOutConclusion       = "timed_out"
+	checkRunActionRequiredConclusion = "action_required"
+	checkRunStaleConclusion          = "stale"
+)
+
+// These are the values that the validator knows how to map. Anything else is reported as unknown in strict mode.
+var (
+	knownCommitStatusStates = map[string]struct{}{
+		successState: {},
+		errorState:   {},
+		failureState: {},
+		pendingState: {},
+	}
+	knownCheckRunStatuses = map[string]struct{}{
+		checkRunQueuedStatus:     {},
+		checkRunInProgressStatus: {},
+		checkRunCompletedStatus:  {},
+	}
+	knownCheckRunConclusions = map[string]struct{}{
+		checkRunNeutralConclusion:        {},
+		checkRunSuccessConclusion:        {},
+		checkRunSkipConclusion:           {},
+		checkRunFailureConclusion:        {},
+		checkRunCancelledConclusion:      {},
+		checkRunTimedOutConclusion:       {},
+		checkRunActionRequiredConclusion: {},
+		checkRunStaleConclusion:          {},
+	}
 )
 
 const (
 	maxStatusesPerPage  = 100
 	maxCheckRunsPerPage = 100
+
+	// maxCheckNameFilters is the maximum number of the jobs to filter the check runs by their names.
+	maxCheckNameFilters = 3
 )
 
 var (
 	ErrInvalidCombinedStatusResponse = errors.New("github combined status response is invalid")
 	ErrInvalidCheckRunResponse       = errors.New("github checkRun response is invalid")
+	ErrEmptyCommitSHA                = errors.New("resolved commit sha is empty")
+	ErrRefNotFound                   = errors.New("ref is not found")
+	ErrUnexpectedSHA                 = errors.New("jobs are reported for another commit than the expected sha")
+	ErrSelfJobNotFound               = errors.New("self job is not found, make sure the self job name matches with the job name")
+	ErrJobSetShrunk                  = errors.New("checks changed underneath us")
 )
 
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
 type ghaStatus struct {
-	Job   string
-	State string
+	Job    string
+	State  string
+	Source JobSource
+
+	// DetailsURL is the details URL of a check run, or the target URL of a commit status.
+	DetailsURL string
+
+	// Description is only populated for commit statuses.
+	Description string
+
+	// The following fields are only populated for check runs, except that the times are also populated for deployments.
+	CheckSuiteID int64
+	App          string
+	StartedAt    time.Time
+	CompletedAt  time.Time
+
+	// Unknown describes the state value which could not be mapped, e.g. `conclusion "foo"`.
+	Unknown string
+
+	// Tolerated is the failing conclusion which is tolerated for the job, and thus mapped to success.
+	Tolerated string
+
+	// Attempts is the number of the check runs of the same name, which are the reruns of the job. It is only
+	// populated for check runs which have been rerun, and the latest attempt is always the one used.
+	Attempts int
+
+	// HungFor is the duration for which the check run has been in progress beyond the hung threshold.
+	HungFor time.Duration
+
+	// CheckRunID is the ID of the check run, and Annotations is the number of the annotations it has emitted.
+	CheckRunID  int64
+	Annotations int
+
+	// MismatchedSHA is the head SHA of the check run attached to another commit than the validated one.
+	MismatchedSHA string
 }
 
 type statusValidator struct {
-	repo        string
-	owner       string
-	ref         string
-	selfJobName string
-	ignoredJobs []string
-	client      github.Client
+	repo       string
+	owner      string
+	ref        string
+	sha        string
+	resolveRef bool
+	prNumber   int
+	followHead bool
+
+	// tagNote reports the commit which the tag ref points to.
+	tagNote string
+
+	// draftSkippedJobs are the jobs which are not required while the pull request is a draft.
+	draftSkippedJobs []string
+	draft            bool
+
+	// mergeRef validates the test merge commit of the pull request, and fails on merge conflicts.
+	mergeRef       bool
+	mergeableState string
+
+	// checkBase fails the validation when the head of the base branch of the pull request has failed jobs.
+	checkBase  bool
+	baseBranch string
+	baseSHAs   map[string]string
+
+	// maxCommitsBehind is the number of commits which the head of the pull request may be behind its base branch.
+	checkBehind      bool
+	maxCommitsBehind int
+	prHead           string
+
+	selfJobName     string
+	selfRunID       string
+	ignoreSelfSuite bool
+	strictStates    bool
+	failingOnly     bool
+	requireSelfJob  bool
+	// matrixSelfJob also disregards the jobs of the matrix of the self job, rather than only the exact self job name.
+	matrixSelfJob bool
+	// reportSelfFailure reports the self job in error or failure state, while it is still not blocking.
+	reportSelfFailure bool
+	ignoredJobs       []string
+	client            github.Client
+	tracerProvider    trace.TracerProvider
+
+	// onlyJobs are the jobs to validate, while all the other jobs are disregarded.
+	onlyJobs []string
+
+	nonBlockingPendingJobs []string
+
+	// passUnlessFailing only blocks on the failed jobs and the pending required jobs, while the other pending jobs
+	// do not block.
+	passUnlessFailing bool
+
+	// hardBlockingJobs are the critical jobs which abort the validation at once when they fail.
+	hardBlockingJobs []string
+
+	// requiredEnvironments are the environments which the ref must be successfully deployed to.
+	requiredEnvironments []string
+
+	// minDistinctApps is the minimum number of distinct apps which must have posted successful check runs.
+	minDistinctApps int
+
+	// checkCombinedState requires the overall state of the commit statuses to agree with the jobs being green.
+	checkCombinedState bool
+
+	// jobAliases maps the old names of the renamed jobs to their new names.
+	jobAliases map[string]string
+
+	// conditionalJobs are the jobs which are ignored while they are not reported, and enforced once they are.
+	conditionalJobs []string
+
+	toleratedConclusions []toleratedConclusion
+
+	// appConclusions are the conclusions which are regarded as success only for the check runs of the apps.
+	appConclusions []appConclusion
+
+	// checkRunStates maps the check run states to the states of the jobs, or nil for the defaults.
+	checkRunStates map[CheckRunState]string
+
+	// toleranceWindow is the daily window within which the tolerated conclusions are tolerated.
+	toleranceWindowText string
+	toleranceWindow     *timeWindow
+
+	// allowedTargetHosts are the hosts which the target URLs of the commit statuses must point to.
+	allowedTargetHosts    []string
+	failOnUntrustedTarget bool
+
+	// requiredDescriptions are the patterns which the descriptions of the named commit statuses must match to succeed.
+	requiredDescriptionPatterns map[string]string
+	requiredDescriptions        map[string]*regexp.Regexp
+
+	// annotationJobs are the jobs whose check runs fail on error annotations even when they have succeeded.
+	annotationJobs  []string
+	annotationNotes map[int64]string
+
+	// allowedWorkflows are the only workflows whose check runs are validated, and suiteWorkflows and runWorkflows
+	// cache the workflow runs of the check suites, and of the check runs whose suites are unknown.
+	allowedWorkflows []string
+	suiteWorkflows   map[int64]suiteWorkflow
+	runWorkflows     map[int64]suiteWorkflow
+
+	// latestCheckSuites drops the check runs of the check suites superseded by a later suite of the same workflow.
+	latestCheckSuites bool
+
+	// jobTimeouts are the durations for which the named jobs are allowed to run, in addition to the global timeout.
+	jobTimeouts map[string]time.Duration
+
+	// hungRunThreshold is the duration beyond which a check run in progress is regarded as hung on its runner.
+	hungRunThreshold time.Duration
+
+	// requiredJobs are the patterns of the jobs which must be reported before succeeding.
+	requiredJobPatterns []string
+	requiredJobs        []jobPattern
+	// failOnMissingRequired fails as soon as a required job is not reported, instead of waiting for it to be reported.
+	failOnMissingRequired bool
+
+	// jobCountLimits are the maximum numbers of the jobs which the patterns may match, e.g. the shards of a test suite.
+	jobCountLimitPatterns map[string]int
+	jobCountLimits        []jobCountLimit
+
+	// stages are the steps of the pipeline in their order, which group the jobs in the summary.
+	stageTexts []string
+	stages     []stage
+
+	// intermediateSHAs are the commits of a push range other than the head, which are reported along with the head.
+	intermediateSHAs   []string
+	failOnIntermediate bool
+
+	// detectVersion detects the version of GitHub Enterprise Server, so that the APIs missing on it are not used.
+	detectVersion   bool
+	versionDetected bool
+	serverVersion   string
+
+	// workflowRunID is the workflow run whose jobs are validated instead of all the jobs for the ref.
+	workflowRunID int64
+	workflowRun   *github.WorkflowRun
+
+	// requireCompletedRuns requires check runs to be completed before the jobs of the same name are regarded as successful.
+	requireCompletedRuns bool
+
+	decider StatusDecider
+
+	// transformer transforms the collected statuses before the result is computed.
+	transformer StatusTransformer
+
+	// refCacheTTL is the duration for which the statuses of the jobs for a ref are reused, 0 means they are not.
+	refCacheTTL time.Duration
+	refCache    map[string]refCacheEntry
+
+	// pageConcurrency is the maximum number of pages fetched at once.
+	pageConcurrency int
+
+	// allowPartialResults proceeds with the pages fetched before a failed page instead of failing.
+	allowPartialResults bool
+
+	// reverifyAfter is the duration for which the all-green result must be sustained before declaring success.
+	reverifyAfter time.Duration
+	greenSince    time.Time
+
+	// minCommitAge is the age which the validated commit must reach before declaring success, and commitDate is the
+	// date of the commit of commitDateRef.
+	minCommitAge  time.Duration
+	commitDate    time.Time
+	commitDateRef string
+
+	// stablePolls is the number of consecutive polls for which the all-green snapshot must be identical.
+	stablePolls  int
+	lastSnapshot string
+	stableCount  int
+
+	// onJobSetShrink is the behavior when the number of jobs decreases between polls.
+	onJobSetShrink JobSetShrinkPolicy
+	lastJobCount   int
+
+	// onHeadSHAMismatch is the behavior for the check runs attached to another head SHA than the validated commit.
+	onHeadSHAMismatch HeadSHAMismatchPolicy
+
+	// succeededJobs are the jobs which have succeeded in the previous polls, to detect them regressing.
+	succeededJobs map[string]struct{}
+	// failOnRegression fails the validation as soon as a job which has succeeded no longer does.
+	failOnRegression bool
+
+	// checkRunStatusFilter is the status which the check runs are filtered by on the server, which is only set by
+	// ListJobs, as the validation needs all the check runs.
+	checkRunStatusFilter string
+
+	// expectedSHA is the commit which the jobs of the validated ref must be reported for, if set.
+	expectedSHA string
+
+	// onErrorState is the behavior for the commit statuses in error state.
+	onErrorState ErrorStatePolicy
+	// maxErrorStateRetries is the number of polls for which a job in error state is waited, 0 means no limit.
+	maxErrorStateRetries int
+	errorStatePolls      map[string]int
+
+	// skipUnchangedPolls reuses the pending result while the responses are unchanged since the previous poll.
+	skipUnchangedPolls bool
+	pendingStatus      *status
+
+	summaryTemplateText string
+	summaryTemplate     *template.Template
+
+	// timingReport reports the time spent by each job, which is observed across the polls.
+	timingReport bool
+	jobTimings   map[string]*jobTiming
+
+	startedAt time.Time
+	clock     func() time.Time
 }
 
 func CreateValidator(c github.Client, opts ...Option) (validators.Validator, error) {
@@ -69,6 +361,117 @@ func (sv *statusValidator) Name() string {
 }
 
 func (sv *statusValidator) validateFields() error {
+	errs := sv.validateTargetFields()
+
+	if len(sv.selfJobName) == 0 {
+		errs = append(errs, errors.New("self job name is empty"))
+	}
+	if sv.mergeRef && sv.prNumber == 0 {
+		errs = append(errs, errors.New("pull request number is required to validate the merge ref"))
+	}
+	if sv.checkBase && sv.prNumber == 0 {
+		errs = append(errs, errors.New("pull request number is required to check the base branch"))
+	}
+	if sv.checkBehind && sv.prNumber == 0 {
+		errs = append(errs, errors.New("pull request number is required to check the commits behind the base branch"))
+	}
+	if err := sv.onJobSetShrink.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := sv.onErrorState.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := sv.onHeadSHAMismatch.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(sv.expectedSHA) != 0 && !commitSHAPattern.MatchString(sv.expectedSHA) {
+		errs = append(errs, fmt.Errorf("invalid expected sha: %q, must be a full commit sha", sv.expectedSHA))
+	}
+	if sv.maxErrorStateRetries < 0 {
+		errs = append(errs, fmt.Errorf("error state retries must not be negative: %d", sv.maxErrorStateRetries))
+	}
+	if sv.maxErrorStateRetries != 0 && sv.onErrorState != ErrorStateRetry {
+		errs = append(errs, errors.New("error state retries are set without the retry policy"))
+	}
+	if len(sv.summaryTemplateText) != 0 {
+		t, err := parseSummaryTemplate(sv.summaryTemplateText)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		sv.summaryTemplate = t
+	}
+	if err := sv.validateJobAliases(); err != nil {
+		errs = append(errs, err)
+	}
+	sv.requiredJobs = sv.requiredJobs[:0]
+	for _, pattern := range sv.requiredJobPatterns {
+		p, err := parseJobPattern(pattern)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sv.requiredJobs = append(sv.requiredJobs, p)
+	}
+	for _, tc := range sv.toleratedConclusions {
+		if err := tc.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, ac := range sv.appConclusions {
+		if err := ac.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := validateCheckRunStates(sv.checkRunStates); err != nil {
+		errs = append(errs, err)
+	}
+	if len(sv.toleranceWindowText) != 0 {
+		w, err := parseTimeWindow(sv.toleranceWindowText)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if len(sv.toleratedConclusions) == 0 {
+			errs = append(errs, errors.New("tolerance window is set without tolerated conclusions"))
+		}
+		sv.toleranceWindow = w
+	}
+	if err := sv.validateJobTimeouts(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := sv.compileRequiredDescriptions(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := sv.compileJobCountLimits(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := sv.compileStages(); err != nil {
+		errs = append(errs, err)
+	}
+	if sv.refCacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("ref cache ttl must not be negative: %s", sv.refCacheTTL))
+	}
+	if sv.hungRunThreshold < 0 {
+		errs = append(errs, fmt.Errorf("hung run threshold must not be negative: %s", sv.hungRunThreshold))
+	}
+	if sv.minDistinctApps < 0 {
+		errs = append(errs, fmt.Errorf("minimum number of distinct apps must not be negative: %d", sv.minDistinctApps))
+	}
+	if sv.stablePolls < 0 {
+		errs = append(errs, fmt.Errorf("number of stable polls must not be negative: %d", sv.stablePolls))
+	}
+	if sv.workflowRunID < 0 {
+		errs = append(errs, fmt.Errorf("workflow run id must not be negative: %d", sv.workflowRunID))
+	}
+
+	if len(errs) != 0 {
+		return errs.Categorize(multierror.CategoryConfig)
+	}
+
+	return nil
+}
+
+// validateTargetFields validates the fields required to call the GitHub API for the ref.
+func (sv *statusValidator) validateTargetFields() multierror.Errors {
 	errs := make(multierror.Errors, 0, 6)
 
 	if len(sv.repo) == 0 {
@@ -77,41 +480,141 @@ func (sv *statusValidator) validateFields() error {
 	if len(sv.owner) == 0 {
 		errs = append(errs, errors.New("repository owner is empty"))
 	}
-	if len(sv.ref) == 0 {
+	if len(sv.ref) == 0 && sv.prNumber == 0 {
 		errs = append(errs, errors.New("reference of repository is empty"))
 	}
-	if len(sv.selfJobName) == 0 {
-		errs = append(errs, errors.New("self job name is empty"))
-	}
 	if sv.client == nil {
 		errs = append(errs, errors.New("github client is empty"))
 	}
 
-	if len(errs) != 0 {
-		return errs
+	return errs.Categorize(multierror.CategoryConfig)
+}
+
+// Validate checks the jobs once. The check is traced as a poll, along with the commit it has validated and the counts
+// of the jobs it has found.
+func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, error) {
+	tp := sv.tracerProvider
+	if tp == nil {
+		tp = trace.NewNoopTracerProvider()
 	}
+	ctx, span := tp.Tracer(tracerName).Start(ctx, "poll", trace.WithAttributes(attribute.String("github.ref", sv.ref)))
+	defer span.End()
 
-	return nil
+	st, err := sv.validate(ctx)
+
+	// The ref is resolved to its commit during the check, e.g. to the head of the pull request.
+	if len(sv.sha) != 0 {
+		span.SetAttributes(attribute.String("github.sha", sv.sha))
+	}
+	var fe *failureError
+	switch s, ok := st.(*status); {
+	case ok:
+		span.SetAttributes(pollAttributes(s, s.IsSuccess())...)
+	case errors.As(err, &fe):
+		span.SetAttributes(pollAttributes(fe.status, false)...)
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return st, err
 }
 
-func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, error) {
-	ghaStatuses, err := sv.listGhaStatuses(ctx)
+func pollAttributes(st *status, success bool) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int("jobs.total", len(st.totalJobs)),
+		attribute.Int("jobs.completed", len(st.completeJobs)),
+		attribute.Int("jobs.failed", len(st.errJobs)),
+		attribute.Int("jobs.ignored", len(st.ignoredJobs)),
+		attribute.Bool("success", success),
+	}
+}
+
+func (sv *statusValidator) validate(ctx context.Context) (validators.Status, error) {
+	headChange, err := sv.resolvePullRequestHead(ctx)
 	if err != nil {
 		return nil, err
 	}
+	mergeableNote, err := sv.checkMergeable()
+	if err != nil {
+		return nil, err
+	}
+	if err := sv.resolveCommitSHA(ctx); err != nil {
+		return nil, err
+	}
+
+	ghaStatuses, info, err := sv.listTargetStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing can have progressed while waiting for the jobs when the responses are unchanged,
+	// and thus the previous result is reused without recomputing.
+	if sv.skipUnchangedPolls && info.unchanged && sv.pendingStatus != nil {
+		st := *sv.pendingStatus
+		st.duration = sv.elapsed()
+		st.unchanged = true
+		return &st, nil
+	}
+	sv.pendingStatus = nil
 
 	st := &status{
-		totalJobs:    make([]string, 0, len(ghaStatuses)),
-		completeJobs: make([]string, 0, len(ghaStatuses)),
-		errJobs:      make([]string, 0, len(ghaStatuses)/2),
-		ignoredJobs:  make([]string, 0, len(ghaStatuses)),
-		succeeded:    true,
+		totalJobs:       make([]string, 0, len(ghaStatuses)),
+		completeJobs:    make([]string, 0, len(ghaStatuses)),
+		errJobs:         make([]string, 0, len(ghaStatuses)/2),
+		ignoredJobs:     make([]string, 0, len(ghaStatuses)),
+		succeeded:       true,
+		duration:        sv.elapsed(),
+		summaryTemplate: sv.summaryTemplate,
+		failingOnly:     sv.failingOnly,
+	}
+	if len(headChange) != 0 {
+		st.notes = append(st.notes, headChange)
+		st.headChanged = true
+	}
+	if info.partialErr != nil {
+		st.notes = append(st.notes, fmt.Sprintf("The statuses are computed from partial data, as some pages failed to be fetched: %v", info.partialErr))
+	} else {
+		// The job count of partial data would be mistaken for a shrinking job set.
+		shrinkNote, err := sv.checkJobSetShrink(len(ghaStatuses))
+		if err != nil {
+			return nil, err
+		}
+		if len(shrinkNote) != 0 {
+			st.notes = append(st.notes, shrinkNote)
+		}
+	}
+	if note := sv.draftNote(); len(note) != 0 {
+		st.notes = append(st.notes, note)
+	}
+	if note := sv.serverVersionNote(); len(note) != 0 {
+		st.notes = append(st.notes, note)
+	}
+	if note := sv.workflowRunNote(); len(note) != 0 {
+		st.notes = append(st.notes, note)
+	}
+	if len(sv.tagNote) != 0 {
+		st.notes = append(st.notes, sv.tagNote)
+	}
+	if len(mergeableNote) != 0 {
+		st.notes = append(st.notes, mergeableNote)
 	}
 
 	st.ignoredJobs = append(st.ignoredJobs, sv.ignoredJobs...)
 
-	var successCnt int
+	selfSuites := sv.selfCheckSuites(ghaStatuses)
+
+	var selfFound bool
+	var hardFailed []string
+	jobStatuses := make([]JobStatus, 0, len(ghaStatuses))
 	for _, ghaStatus := range ghaStatuses {
+		if sv.isSelfJob(ghaStatus.Job) {
+			selfFound = true
+			if note := sv.selfFailureNote(ghaStatus); len(note) != 0 {
+				st.notes = append(st.notes, note)
+			}
+		}
+
 		var toIgnore bool
 		for _, ignored := range sv.ignoredJobs {
 			if ghaStatus.Job == ignored {
@@ -119,132 +622,817 @@ func (sv *statusValidator) Validate(ctx context.Context) (validators.Status, err
 				break
 			}
 		}
+		if _, ok := selfSuites[ghaStatus.CheckSuiteID]; ok {
+			toIgnore = true
+		}
 
 		// Ignored jobs and this job itself should be considered as success regardless of their statuses.
-		if toIgnore || ghaStatus.Job == sv.selfJobName {
-			successCnt++
+		if toIgnore || sv.isSelfJob(ghaStatus.Job) {
+			continue
+		}
+
+		// The required environments are validated regardless of the jobs to validate, as they are set explicitly.
+		if len(sv.onlyJobs) != 0 && ghaStatus.Source != JobSourceDeployment && !containsJob(sv.onlyJobs, ghaStatus.Job) {
+			continue
+		}
+
+		if ghaStatus.State == pendingState && containsJob(sv.nonBlockingPendingJobs, ghaStatus.Job) {
+			st.ignoredJobs = append(st.ignoredJobs, ghaStatus.Job)
+			continue
+		}
+		// Optional jobs are not ignored, but only regarded as not blocking while they are pending.
+		if sv.isOptionalPending(ghaStatus) {
+			st.optionalJobs = append(st.optionalJobs, ghaStatus.Job)
+			continue
+		}
+
+		// Jobs which intentionally don't run for drafts are not required until the pull request is ready.
+		if sv.draft && containsJob(sv.draftSkippedJobs, ghaStatus.Job) {
+			st.ignoredJobs = append(st.ignoredJobs, ghaStatus.Job)
+			continue
+		}
+
+		// The workflow is looked up only for the jobs which are left after the filters needing no request.
+		workflowNote, err := sv.disallowedWorkflowNote(ctx, ghaStatus)
+		if err != nil {
+			return nil, err
+		}
+		if len(workflowNote) != 0 {
+			st.ignoredJobs = append(st.ignoredJobs, ghaStatus.Job)
+			st.notes = append(st.notes, workflowNote)
 			continue
 		}
 
 		st.totalJobs = append(st.totalJobs, ghaStatus.Job)
 
+		if sv.isHardBlockingFailure(ghaStatus) {
+			hardFailed = append(hardFailed, ghaStatus.Job)
+		}
+
+		if sv.strictStates && len(ghaStatus.Unknown) != 0 {
+			st.errJobs = append(st.errJobs, ghaStatus.Job)
+			st.unknownStates = append(st.unknownStates, fmt.Sprintf("%s: %s", ghaStatus.Job, ghaStatus.Unknown))
+			jobStatus := ghaStatus.jobStatus()
+			jobStatus.State = errorState
+			jobStatuses = append(jobStatuses, jobStatus)
+			continue
+		}
+		if note := sv.untrustedTargetNote(ghaStatus); len(note) != 0 {
+			st.notes = append(st.notes, note)
+			if sv.failOnUntrustedTarget {
+				st.errJobs = append(st.errJobs, ghaStatus.Job)
+				jobStatus := ghaStatus.jobStatus()
+				jobStatus.State = errorState
+				jobStatuses = append(jobStatuses, jobStatus)
+				continue
+			}
+		}
+		if note := sv.descriptionMismatchNote(ghaStatus); len(note) != 0 {
+			st.errJobs = append(st.errJobs, ghaStatus.Job)
+			st.notes = append(st.notes, note)
+			jobStatus := ghaStatus.jobStatus()
+			jobStatus.State = errorState
+			jobStatuses = append(jobStatuses, jobStatus)
+			continue
+		}
+		note, err := sv.errorAnnotationNote(ctx, ghaStatus)
+		if err != nil {
+			return nil, err
+		}
+		if len(note) != 0 {
+			st.errJobs = append(st.errJobs, ghaStatus.Job)
+			st.notes = append(st.notes, note)
+			jobStatus := ghaStatus.jobStatus()
+			jobStatus.State = errorState
+			jobStatuses = append(jobStatuses, jobStatus)
+			continue
+		}
+		// A job running beyond its own timeout is most likely stuck, so it fails before the global timeout.
+		if elapsed, exceeded := sv.exceededJobTimeout(ghaStatus); exceeded {
+			st.errJobs = append(st.errJobs, ghaStatus.Job)
+			st.notes = append(st.notes, fmt.Sprintf("%s has been running for %s, exceeding its timeout of %s",
+				ghaStatus.Job, elapsed.Round(time.Second), sv.jobTimeouts[ghaStatus.Job]))
+			jobStatus := ghaStatus.jobStatus()
+			jobStatus.State = errorState
+			jobStatuses = append(jobStatuses, jobStatus)
+			continue
+		}
+		// A check run in progress for that long has most likely lost its runner, which never reports the conclusion.
+		if ghaStatus.HungFor != 0 {
+			st.errJobs = append(st.errJobs, ghaStatus.Job)
+			st.notes = append(st.notes, fmt.Sprintf("%s has been in progress for %s, exceeding the hung threshold of %s",
+				ghaStatus.Job, ghaStatus.HungFor.Round(time.Second), sv.hungRunThreshold))
+			jobStatus := ghaStatus.jobStatus()
+			jobStatus.State = errorState
+			jobStatuses = append(jobStatuses, jobStatus)
+			continue
+		}
+		// A check run of a stale head must not be counted for the validated commit, whatever its state is.
+		if len(ghaStatus.MismatchedSHA) != 0 {
+			st.errJobs = append(st.errJobs, ghaStatus.Job)
+			st.notes = append(st.notes, fmt.Sprintf("Check run %s is attached to head SHA %s, not the validated commit %s",
+				ghaStatus.Job, ghaStatus.MismatchedSHA, sv.targetRef()))
+			jobStatus := ghaStatus.jobStatus()
+			jobStatus.State = errorState
+			jobStatuses = append(jobStatuses, jobStatus)
+			continue
+		}
+		retry, errorStateNote := sv.retryErrorState(ghaStatus)
+		if len(errorStateNote) != 0 {
+			st.notes = append(st.notes, errorStateNote)
+		}
+		if retry {
+			jobStatus := ghaStatus.jobStatus()
+			jobStatus.State = pendingState
+			jobStatuses = append(jobStatuses, jobStatus)
+			continue
+		}
+		jobStatuses = append(jobStatuses, ghaStatus.jobStatus())
+
 		switch ghaStatus.State {
 		case successState:
 			st.completeJobs = append(st.completeJobs, ghaStatus.Job)
-			successCnt++
+			if len(ghaStatus.Tolerated) != 0 {
+				st.notes = append(st.notes, fmt.Sprintf("%s concluded with tolerated conclusion %q", ghaStatus.Job, ghaStatus.Tolerated))
+			}
 		case errorState, failureState:
 			st.errJobs = append(st.errJobs, ghaStatus.Job)
 		}
 	}
-	if len(st.errJobs) != 0 {
-		return nil, errors.New(st.Detail())
+	sv.pruneErrorStatePolls(ghaStatuses)
+	countNotes := sv.exceededJobCountNotes(jobStatuses)
+	st.notes = append(st.notes, countNotes...)
+	regressionNotes, regressed := sv.regressionNotes(jobStatuses)
+	st.notes = append(st.notes, regressionNotes...)
+
+	// Conditional jobs only run for some changes, so they are not required until they are reported.
+	for _, job := range sv.conditionalJobs {
+		if !containsGhaStatus(ghaStatuses, job) {
+			st.unreportedJobs = append(st.unreportedJobs, job)
+		}
+	}
+	// Required jobs which are not reported yet are regarded as pending by default, as they are most likely yet to be
+	// queued.
+	for _, pattern := range sv.missingRequiredJobs(ghaStatuses) {
+		if matchesJob(sv.conditionalJobs, pattern) {
+			continue
+		}
+		st.totalJobs = append(st.totalJobs, pattern)
+		st.missingRequiredJobs = append(st.missingRequiredJobs, pattern)
+		st.notes = append(st.notes, fmt.Sprintf("Required job %s is not reported yet", pattern))
+		if sv.failOnMissingRequired {
+			st.errJobs = append(st.errJobs, pattern)
+			jobStatuses = append(jobStatuses, JobStatus{Job: pattern, State: errorState})
+			continue
+		}
+		jobStatuses = append(jobStatuses, JobStatus{Job: pattern, State: pendingState})
+	}
+	st.appSummaries = summarizeByApp(jobStatuses)
+	st.environments = summarizeEnvironments(jobStatuses)
+	st.timings = sv.observeJobTimings(jobStatuses)
+	st.reruns = summarizeReruns(jobStatuses)
+	st.jobStatuses = append([]JobStatus{}, jobStatuses...)
+	st.sortJobs()
+	st.stages = summarizeStages(sv.stages, st.jobStatuses)
+
+	// A failed hard-blocking job aborts at once, regardless of the decider and the other jobs still running.
+	if len(hardFailed) != 0 {
+		for _, job := range hardFailed {
+			if !containsJob(st.errJobs, job) {
+				st.errJobs = append(st.errJobs, job)
+			}
+		}
+		st.sortJobs()
+		st.notes = append(st.notes, fmt.Sprintf("Hard-blocking job(s) failed, aborting: %s", strings.Join(hardFailed, ", ")))
+		return nil, &failureError{status: st}
+	}
+
+	decision, reason := sv.statusDecider().Decide(jobStatuses)
+	if sv.decider != nil && len(reason) != 0 {
+		st.notes = append(st.notes, "Decision reason: "+reason)
+	}
+
+	// Jobs on the missing pages may still be incomplete or failed, so partial data can never be successful.
+	if decision == DecisionSuccess && info.partialErr != nil {
+		decision = DecisionPending
+	}
+	// The number of the jobs only grows while they are being queued, so too many of them fail without waiting.
+	if len(countNotes) != 0 {
+		decision = DecisionFailure
+	}
+	// A regressed job is most likely rerun, which may take as long as the whole wait to complete again.
+	if len(regressed) != 0 && sv.failOnRegression {
+		for _, job := range regressed {
+			if !containsJob(st.errJobs, job) {
+				st.errJobs = append(st.errJobs, job)
+			}
+		}
+		st.sortJobs()
+		decision = DecisionFailure
+	}
+
+	// The apps are counted only once all the jobs are settled, as the pending jobs may still be reported by other apps.
+	if decision == DecisionSuccess && sv.minDistinctApps != 0 {
+		if n := countSuccessfulApps(jobStatuses); n < sv.minDistinctApps {
+			st.notes = append(st.notes, fmt.Sprintf("Successful checks are posted by %d distinct app(s), fewer than the required %d", n, sv.minDistinctApps))
+			decision = DecisionFailure
+		}
 	}
 
-	if len(ghaStatuses) != successCnt {
+	// The overall state aggregated by GitHub is compared only once all the jobs are green, as a guard against
+	// misreading the states of the individual commit statuses.
+	if decision == DecisionSuccess && sv.checkCombinedState {
+		var note string
+		note, decision = sv.combinedStateMismatch(info.combined)
+		if len(note) != 0 {
+			st.notes = append(st.notes, note)
+		}
+	}
+
+	// Intermediate commits are checked only once the head is settled, as they are reported along with the final result.
+	if decision != DecisionPending && len(sv.intermediateSHAs) != 0 {
+		notes, failed, err := sv.checkIntermediateSHAs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		st.notes = append(st.notes, notes...)
+		if failed && sv.failOnIntermediate {
+			decision = DecisionFailure
+		}
+	}
+
+	// The base branch is checked only once the head is settled as well, and its failures are reported separately.
+	if decision != DecisionPending && sv.checkBase {
+		failed, note, err := sv.checkBaseBranch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		st.notes = append(st.notes, note)
+		st.baseFailures = failed
+		if len(failed) != 0 {
+			decision = DecisionFailure
+		}
+	}
+	if decision != DecisionPending && sv.checkBehind {
+		note, tooFar, err := sv.checkCommitsBehind(ctx)
+		if err != nil {
+			return nil, err
+		}
+		st.notes = append(st.notes, note)
+		if tooFar {
+			decision = DecisionFailure
+		}
+	}
+
+	switch decision {
+	case DecisionFailure:
+		return nil, &failureError{status: st}
+	case DecisionPending:
 		st.succeeded = false
+		sv.resetGreenState()
+		if sv.skipUnchangedPolls {
+			sv.pendingStatus = st
+		}
 		return st, nil
 	}
 
+	// Everything else is green at this point, so the self job should have been found unless it is misconfigured.
+	if sv.requireSelfJob && !selfFound {
+		return nil, fmt.Errorf("%w self job: %s", ErrSelfJobNotFound, sv.selfJobName)
+	}
+
+	sv.reverify(st)
+	sv.stabilize(st, jobStatuses)
+	if err := sv.awaitCommitAge(ctx, st); err != nil {
+		return nil, err
+	}
+
 	return st, nil
 }
 
-func (sv *statusValidator) getCombinedStatus(ctx context.Context) ([]*github.RepoStatus, error) {
-	var combined []*github.RepoStatus
-	page := 1
-	for {
-		c, _, err := sv.client.GetCombinedStatus(ctx, sv.owner, sv.repo, sv.ref, &github.ListOptions{PerPage: maxStatusesPerPage, Page: page})
+func (sv *statusValidator) statusDecider() StatusDecider {
+	if sv.decider != nil {
+		return sv.decider
+	}
+	return DefaultDecider{}
+}
+
+// reverify holds off the success until the all-green result has been sustained for the configured duration,
+// because the combined status is eventually consistent and a late failure may still arrive.
+func (sv *statusValidator) reverify(st *status) {
+	if sv.reverifyAfter <= 0 {
+		return
+	}
+
+	now := sv.now()
+	if sv.greenSince.IsZero() {
+		sv.greenSince = now
+	}
+	if elapsed := now.Sub(sv.greenSince); elapsed < sv.reverifyAfter {
+		st.succeeded = false
+		st.notes = append(st.notes, fmt.Sprintf("All jobs are green, re-verifying in %s before declaring success", sv.reverifyAfter-elapsed))
+	}
+}
+
+// stabilize holds off the success until the same all-green snapshot has been observed for the configured number of
+// consecutive polls, so that a late-arriving job or a flapping state is not missed.
+func (sv *statusValidator) stabilize(st *status, jobStatuses []JobStatus) {
+	if sv.stablePolls <= 1 {
+		return
+	}
+
+	snapshot := snapshotOf(jobStatuses)
+	if snapshot != sv.lastSnapshot {
+		sv.lastSnapshot = snapshot
+		sv.stableCount = 0
+	}
+	sv.stableCount++
+	if sv.stableCount < sv.stablePolls {
+		st.succeeded = false
+		st.notes = append(st.notes, fmt.Sprintf("All jobs are green, waiting for %d more identical poll(s) before declaring success", sv.stablePolls-sv.stableCount))
+	}
+}
+
+// snapshotOf returns the string representing the jobs and their states regardless of the order.
+func snapshotOf(jobStatuses []JobStatus) string {
+	entries := make([]string, 0, len(jobStatuses))
+	for _, js := range jobStatuses {
+		entries = append(entries, js.Job+"="+js.State)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, "\n")
+}
+
+// resetPollState discards the state carried over between polls, which is needed when the validated commit changes.
+func (sv *statusValidator) resetPollState() {
+	sv.resetGreenState()
+	sv.lastJobCount = 0
+	sv.pendingStatus = nil
+	sv.errorStatePolls = nil
+	sv.jobTimings = nil
+	sv.succeededJobs = nil
+}
+
+// resetGreenState discards the state tracking the all-green result, which is needed when the jobs are no longer green.
+func (sv *statusValidator) resetGreenState() {
+	sv.greenSince = time.Time{}
+	sv.lastSnapshot = ""
+	sv.stableCount = 0
+}
+
+// elapsed returns the time elapsed since the first validation.
+func (sv *statusValidator) elapsed() time.Duration {
+	now := sv.now()
+	if sv.startedAt.IsZero() {
+		sv.startedAt = now
+	}
+	return now.Sub(sv.startedAt)
+}
+
+func (sv *statusValidator) now() time.Time {
+	if sv.clock != nil {
+		return sv.clock()
+	}
+	return time.Now()
+}
+
+// matchesJob reports whether the job matches any of the job names, each of which may be a glob or a regular
+// expression delimited by "/" as the required jobs are. An invalid pattern only matches the job of the same name.
+func matchesJob(patterns []string, job string) bool {
+	for _, pattern := range patterns {
+		if pattern == job {
+			return true
+		}
+		if p, err := parseJobPattern(pattern); err == nil && p.match(job) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJobPattern reports whether the job name matches other names than itself.
+func isJobPattern(job string) bool {
+	p, err := parseJobPattern(job)
+	return err == nil && (p.re != nil || strings.ContainsAny(job, `*?[\`))
+}
+
+func containsJob(jobs []string, job string) bool {
+	for _, j := range jobs {
+		if j == job {
+			return true
+		}
+	}
+	return false
+}
+
+// containsGhaStatus reports whether any status is reported for the job, which may be a pattern as matchesJob takes.
+func containsGhaStatus(ghaStatuses []*ghaStatus, job string) bool {
+	for _, ghaStatus := range ghaStatuses {
+		if matchesJob([]string{job}, ghaStatus.Job) {
+			return true
+		}
+	}
+	return false
+}
+
+// selfCheckSuites returns the IDs of the check suites which the gatekeeper itself belongs to.
+// The job name of the gatekeeper does not always match with its check run name (e.g. when the job has a custom
+// name), so a suite is also considered as the self one when any of its runs points to the gatekeeper's workflow run.
+func (sv *statusValidator) selfCheckSuites(ghaStatuses []*ghaStatus) map[int64]struct{} {
+	suites := make(map[int64]struct{})
+	if !sv.ignoreSelfSuite {
+		return suites
+	}
+
+	runPath := "/actions/runs/" + sv.selfRunID + "/"
+	for _, ghaStatus := range ghaStatuses {
+		if ghaStatus.CheckSuiteID == 0 {
+			continue
+		}
+		if sv.isSelfJob(ghaStatus.Job) ||
+			(len(sv.selfRunID) != 0 && strings.Contains(ghaStatus.DetailsURL, runPath)) {
+			suites[ghaStatus.CheckSuiteID] = struct{}{}
+		}
+	}
+	return suites
+}
+
+// resolveCommitSHA resolves the ref to its commit SHA only once, so that all the subsequent calls
+// target the same commit even when the branch moves while validating.
+func (sv *statusValidator) resolveCommitSHA(ctx context.Context) error {
+	if len(sv.sha) != 0 {
+		return nil
+	}
+	if isTagRef(sv.ref) {
+		return sv.resolveTag(ctx)
+	}
+	if !sv.resolveRef {
+		return nil
+	}
+	if commitSHAPattern.MatchString(sv.ref) {
+		sv.sha = sv.ref
+		return nil
+	}
+
+	sha, _, err := sv.client.GetCommitSHA1(ctx, sv.owner, sv.repo, sv.ref, "")
+	if err != nil {
+		return fmt.Errorf("failed to resolve ref %s: %w", sv.ref, err)
+	}
+	if len(sha) == 0 {
+		return fmt.Errorf("%w ref: %s", ErrEmptyCommitSHA, sv.ref)
+	}
+	sv.sha = sha
+	return nil
+}
+
+// targetRef returns the resolved commit SHA if available, and the ref otherwise.
+func (sv *statusValidator) targetRef() string {
+	if len(sv.sha) != 0 {
+		return sv.sha
+	}
+	return sv.ref
+}
+
+// wrapRefError distinguishes the ref not being found from other errors such as permission errors,
+// as GitHub returns 404 for both a missing ref and a missing repository.
+func (sv *statusValidator) wrapRefError(ref string, resp *github.Response, err error) error {
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return err
+	}
+	return fmt.Errorf("%w owner: %s, repo: %s, ref: %s, err: %v", ErrRefNotFound, sv.owner, sv.repo, ref, err)
+}
+
+// getCombinedStatus returns the statuses of all pages, along with how they have been fetched.
+func (sv *statusValidator) getCombinedStatus(ctx context.Context, ref string) ([]*github.RepoStatus, fetchInfo, error) {
+	var mu sync.Mutex
+	pages := make(map[int][]*github.RepoStatus)
+	unchanged := true
+	var state, sha string
+	n, err := fetchPages(ctx, sv.pageConcurrency, func(ctx context.Context, page int) (bool, error) {
+		c, resp, err := sv.client.GetCombinedStatus(ctx, sv.owner, sv.repo, ref, &github.ListOptions{PerPage: maxStatusesPerPage, Page: page})
 		if err != nil {
-			return nil, err
+			return false, sv.wrapRefError(ref, resp, err)
 		}
-		combined = append(combined, c.Statuses...)
-		if c.GetTotalCount() < maxStatusesPerPage {
-			break
+		mu.Lock()
+		pages[page] = c.Statuses
+		// The overall state is pending when there is no commit status at all, which is not meaningful.
+		if page == 1 && len(c.Statuses) != 0 {
+			state = c.GetState()
+			sha = c.GetSHA()
 		}
-		page++
+		unchanged = unchanged && github.IsNotModified(resp)
+		mu.Unlock()
+		return c.GetTotalCount() < maxStatusesPerPage, nil
+	})
+	info, err := sv.fetchInfo(unchanged, err)
+	if err != nil {
+		return nil, fetchInfo{}, err
 	}
-	return combined, nil
+
+	var combined []*github.RepoStatus
+	for page := 1; page <= n; page++ {
+		combined = append(combined, pages[page]...)
+	}
+	if len(state) != 0 {
+		info.combined = newCombinedState(state, combined)
+	}
+	info.sha = sha
+	return combined, info, nil
 }
 
-func (sv *statusValidator) listCheckRunsForRef(ctx context.Context) ([]*github.CheckRun, error) {
+// listCheckRunsForRef returns the check runs of all pages, along with how they have been fetched. When only a few jobs
+// are validated, the check runs are filtered by their names on the server, which needs a request per name but
+// avoids fetching all the pages.
+func (sv *statusValidator) listCheckRunsForRef(ctx context.Context, ref string) ([]*github.CheckRun, fetchInfo, error) {
+	if err := sv.detectServerVersion(ctx); err != nil {
+		return nil, fetchInfo{}, err
+	}
+	if !sv.checkRunsSupported() {
+		return nil, fetchInfo{unchanged: true}, nil
+	}
+
+	// The only jobs narrow the jobs of the target ref, while the other refs, such as the base branch, are validated
+	// as a whole.
+	var names []string
+	if ref == sv.targetRef() {
+		names = sv.checkNameFilters()
+	}
+	if len(names) == 0 {
+		return sv.listCheckRunsForRefByName(ctx, ref, nil)
+	}
+
 	var runResults []*github.CheckRun
-	page := 1
-	for {
-		cr, _, err := sv.client.ListCheckRunsForRef(ctx, sv.owner, sv.repo, sv.ref, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{
-			Page:    page,
-			PerPage: maxCheckRunsPerPage,
-		}})
+	info := fetchInfo{unchanged: true}
+	for i := range names {
+		runs, nameInfo, err := sv.listCheckRunsForRefByName(ctx, ref, &names[i])
 		if err != nil {
-			return nil, err
+			return nil, fetchInfo{}, err
 		}
-		runResults = append(runResults, cr.CheckRuns...)
-		if cr.GetTotal() < maxCheckRunsPerPage {
-			break
+		runResults = append(runResults, runs...)
+		info = info.merge(nameInfo)
+	}
+	return runResults, info, nil
+}
+
+// checkNameFilters returns the names to filter the check runs by on the server, which are the jobs to validate along
+// with the required jobs, as the required jobs need to be found even when they are not validated. It returns nil to
+// fetch all the check runs when all jobs are validated, when too many jobs are to be found to filter them
+// efficiently, or when any of the required jobs is a pattern which the server cannot match.
+//
+// The required jobs alone never filter the check runs, as all the other jobs are validated along with them.
+func (sv *statusValidator) checkNameFilters() []string {
+	if len(sv.onlyJobs) == 0 {
+		return nil
+	}
+	names := append([]string{}, sv.onlyJobs...)
+	for _, p := range sv.requiredJobs {
+		if !containsJob(names, p.pattern) {
+			names = append(names, p.pattern)
+		}
+	}
+	if len(names) > maxCheckNameFilters {
+		return nil
+	}
+	for _, p := range sv.requiredJobs {
+		if isJobPattern(p.pattern) {
+			return nil
+		}
+	}
+	// The self job is fetched as well, as it needs to be found when it is required.
+	if sv.requireSelfJob && !containsJob(names, sv.canonicalJob(sv.selfJobName)) {
+		names = append(names, sv.canonicalJob(sv.selfJobName))
+	}
+	// The jobs may still be reported with their old names, e.g. by the commits before they were renamed.
+	for old, renamed := range sv.jobAliases {
+		if containsJob(names, renamed) {
+			names = append(names, old)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// listCheckRunsForRefByName returns the check runs of all pages with the name, or all the check runs when it is nil.
+func (sv *statusValidator) listCheckRunsForRefByName(ctx context.Context, ref string, name *string) ([]*github.CheckRun, fetchInfo, error) {
+	var mu sync.Mutex
+	pages := make(map[int][]*github.CheckRun)
+	unchanged := true
+	n, err := fetchPages(ctx, sv.pageConcurrency, func(ctx context.Context, page int) (bool, error) {
+		cr, resp, err := sv.client.ListCheckRunsForRef(ctx, sv.owner, sv.repo, ref, &github.ListCheckRunsOptions{
+			CheckName: name,
+			Status:    sv.checkRunStatusFilterOption(),
+			ListOptions: github.ListOptions{
+				Page:    page,
+				PerPage: maxCheckRunsPerPage,
+			},
+		})
+		if err != nil {
+			return false, err
 		}
-		page++
+		mu.Lock()
+		pages[page] = cr.CheckRuns
+		unchanged = unchanged && github.IsNotModified(resp)
+		mu.Unlock()
+		return cr.GetTotal() < maxCheckRunsPerPage, nil
+	})
+	info, err := sv.fetchInfo(unchanged, err)
+	if err != nil {
+		return nil, fetchInfo{}, err
 	}
-	return runResults, nil
+
+	var runResults []*github.CheckRun
+	for page := 1; page <= n; page++ {
+		runResults = append(runResults, pages[page]...)
+	}
+	return runResults, info, nil
+}
+
+// fetchInfo returns how the pages have been fetched. The error of a page after the preceding pages is tolerated
+// when the partial results are allowed, so that the validator can keep polling rather than aborting.
+func (sv *statusValidator) fetchInfo(unchanged bool, err error) (fetchInfo, error) {
+	if err == nil {
+		return fetchInfo{unchanged: unchanged}, nil
+	}
+	var pe *partialPagesError
+	if !sv.allowPartialResults || !errors.As(err, &pe) {
+		return fetchInfo{}, err
+	}
+	return fetchInfo{partialErr: pe.err}, nil
 }
 
 func (sv *statusValidator) listGhaStatuses(ctx context.Context) ([]*ghaStatus, error) {
-	combined, err := sv.getCombinedStatus(ctx)
+	ghaStatuses, _, err := sv.listTargetStatuses(ctx)
+	return ghaStatuses, err
+}
+
+// listTargetStatuses returns the statuses of the jobs and the required deployments for the target ref, along with
+// how they have been fetched.
+func (sv *statusValidator) listTargetStatuses(ctx context.Context) ([]*ghaStatus, fetchInfo, error) {
+	var ghaStatuses []*ghaStatus
+	var info fetchInfo
+	var err error
+	if sv.workflowRunID != 0 {
+		ghaStatuses, info, err = sv.listWorkflowRunStatuses(ctx)
+	} else {
+		ghaStatuses, info, err = sv.listGhaStatusesForRef(ctx, sv.targetRef())
+	}
 	if err != nil {
-		return nil, err
+		return nil, fetchInfo{}, err
+	}
+	deployments, deploymentsInfo, err := sv.listDeploymentStatuses(ctx, sv.targetRef())
+	if err != nil {
+		return nil, fetchInfo{}, err
+	}
+	return sv.transformStatuses(append(ghaStatuses, deployments...)), info.merge(deploymentsInfo), nil
+}
+
+// fetchGhaStatusesForRef fetches the statuses of the jobs for the ref, along with how they have been fetched.
+//
+// The jobs are counted from whichever sources report them, so that either source may be empty:
+//   - when neither the commit statuses nor the check runs report any job, there is nothing to wait for,
+//   - when only the commit statuses or only the check runs report jobs, the jobs of that source are validated alone,
+//   - when both report jobs, they are validated together, and a check run of the same name as a commit status is
+//     counted only once, as the commit status.
+func (sv *statusValidator) fetchGhaStatusesForRef(ctx context.Context, ref string) ([]*ghaStatus, fetchInfo, error) {
+	combined, statusesInfo, err := sv.getCombinedStatus(ctx, ref)
+	if err != nil {
+		return nil, fetchInfo{}, err
 	}
 
 	// Because multiple jobs with the same name may exist when jobs are created dynamically by third-party tools, etc.,
 	// only the latest job should be managed.
-	currentJobs := make(map[string]struct{})
+	currentJobs := make(map[string]*ghaStatus)
 
 	ghaStatuses := make([]*ghaStatus, 0, len(combined))
 	for _, s := range combined {
 		if s.Context == nil || s.State == nil {
-			return nil, fmt.Errorf("%w context: %v, status: %v", ErrInvalidCombinedStatusResponse, s.Context, s.State)
+			return nil, fetchInfo{}, fmt.Errorf("%w context: %v, status: %v", ErrInvalidCombinedStatusResponse, s.Context, s.State)
 		}
-		if _, ok := currentJobs[*s.Context]; ok {
+		// Renamed jobs are reported with their new names, so that they match with the lists of either name.
+		job := sv.canonicalJob(*s.Context)
+		if _, ok := currentJobs[job]; ok {
 			continue
 		}
-		currentJobs[*s.Context] = struct{}{}
 
-		ghaStatuses = append(ghaStatuses, &ghaStatus{
-			Job:   *s.Context,
-			State: *s.State,
-		})
+		ghaStatus := &ghaStatus{
+			Job:         job,
+			State:       *s.State,
+			Source:      JobSourceCommitStatus,
+			DetailsURL:  s.GetTargetURL(),
+			Description: s.GetDescription(),
+		}
+		// The expected status has not been reported yet, which is most likely to happen right after the push.
+		if ghaStatus.State == expectedState {
+			ghaStatus.State = pendingState
+		}
+		currentJobs[job] = ghaStatus
+		if _, ok := knownCommitStatusStates[ghaStatus.State]; !ok {
+			ghaStatus.Unknown = fmt.Sprintf("state %q", *s.State)
+		}
+		ghaStatuses = append(ghaStatuses, ghaStatus)
 	}
 
-	runResults, err := sv.listCheckRunsForRef(ctx)
+	runResults, runsInfo, err := sv.listCheckRunsForRef(ctx, ref)
 	if err != nil {
-		return nil, err
+		return nil, fetchInfo{}, err
+	}
+
+	// The stale suites are dropped first, so that their runs are neither picked nor counted as the attempts.
+	runResults, err = sv.latestSuiteRuns(ctx, runResults)
+	if err != nil {
+		return nil, fetchInfo{}, err
+	}
+	runResults, attempts, err := sv.latestCheckRuns(runResults)
+	if err != nil {
+		return nil, fetchInfo{}, err
+	}
+	if ref == sv.targetRef() {
+		if err := sv.verifyExpectedSHA(ref, statusesInfo.sha, runResults); err != nil {
+			return nil, fetchInfo{}, err
+		}
 	}
 
 	for _, run := range runResults {
-		if run.Name == nil || run.Status == nil {
-			return nil, fmt.Errorf("%w name: %v, status: %v", ErrInvalidCheckRunResponse, run.Name, run.Status)
+		mismatchedSHA, mismatched := sv.mismatchedHeadSHA(ref, run.GetHeadSHA())
+		if mismatched && sv.onHeadSHAMismatch == HeadSHAMismatchIgnore {
+			continue
 		}
-		if _, ok := currentJobs[*run.Name]; ok {
+		job := sv.canonicalJob(*run.Name)
+		if existing, ok := currentJobs[job]; ok {
+			// A commit status of the same name may carry a success from a previous run, while the check run
+			// itself has not completed yet.
+			if sv.requireCompletedRuns && *run.Status != checkRunCompletedStatus {
+				existing.State = pendingState
+			}
 			continue
 		}
-		currentJobs[*run.Name] = struct{}{}
 
 		ghaStatus := &ghaStatus{
-			Job: *run.Name,
+			Job:          job,
+			Source:       JobSourceCheckRun,
+			CheckSuiteID: run.GetCheckSuite().GetID(),
+			App:          run.GetApp().GetSlug(),
+			DetailsURL:   run.GetDetailsURL(),
+			StartedAt:    run.GetStartedAt().Time,
+			CompletedAt:  run.GetCompletedAt().Time,
+			Attempts:     attempts[job],
+			CheckRunID:   run.GetID(),
+			Annotations:  run.GetOutput().GetAnnotationsCount(),
+		}
+		if mismatched {
+			ghaStatus.MismatchedSHA = mismatchedSHA
 		}
+		currentJobs[job] = ghaStatus
 
-		if *run.Status != checkRunCompletedStatus {
-			ghaStatus.State = pendingState
-			ghaStatuses = append(ghaStatuses, ghaStatus)
+		if !sv.setCheckRunState(ghaStatus, *run.Status, run.GetConclusion()) {
 			continue
 		}
+		ghaStatus.HungFor = sv.hungRunFor(ghaStatus, *run.Status)
+		ghaStatuses = append(ghaStatuses, ghaStatus)
+	}
+
+	return ghaStatuses, statusesInfo.merge(runsInfo), nil
+}
 
-		switch *run.Conclusion {
-		case checkRunNeutralConclusion, checkRunSuccessConclusion:
-			ghaStatus.State = successState
-		case checkRunSkipConclusion:
+// latestCheckRuns returns only the newest run for each name, keeping the order in which the names first appear.
+// When a check is re-requested, the old run may still be returned for a while, so the run which started the
+// latest is used. A run which has not been started yet is regarded as the newest, as it is most likely the
+// re-requested one waiting in the queue. The runs reported under the old and the new names of a renamed job are
+// attempts of the same job.
+//
+// It also returns the number of the attempts for each job which has been rerun, telling the flaky jobs.
+func (sv *statusValidator) latestCheckRuns(runs []*github.CheckRun) ([]*github.CheckRun, map[string]int, error) {
+	latest := make([]*github.CheckRun, 0, len(runs))
+	indexes := make(map[string]int, len(runs))
+	attempts := make(map[string]int)
+	for _, run := range runs {
+		if run.Name == nil || run.Status == nil {
+			return nil, nil, fmt.Errorf("%w name: %v, status: %v", ErrInvalidCheckRunResponse, run.Name, run.Status)
+		}
+		job := sv.canonicalJob(*run.Name)
+		i, ok := indexes[job]
+		if !ok {
+			indexes[job] = len(latest)
+			latest = append(latest, run)
 			continue
-		default:
-			ghaStatus.State = errorState
 		}
-		ghaStatuses = append(ghaStatuses, ghaStatus)
+		if attempts[job] == 0 {
+			attempts[job] = 1
+		}
+		attempts[job]++
+		if isNewerCheckRun(run, latest[i]) {
+			latest[i] = run
+		}
 	}
+	return latest, attempts, nil
+}
 
-	return ghaStatuses, nil
+func isNewerCheckRun(run, than *github.CheckRun) bool {
+	if than.StartedAt == nil {
+		return false
+	}
+	if run.StartedAt == nil {
+		return true
+	}
+	return run.StartedAt.After(than.StartedAt.Time)
 }
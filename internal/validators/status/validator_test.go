@@ -4,8 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/upsidr/merge-gatekeeper/internal/github"
 	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
@@ -16,6 +25,14 @@ func stringPtr(str string) *string {
 	return &str
 }
 
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -86,6 +103,313 @@ func TestCreateValidator(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"returns Validator with tolerated conclusions": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithToleratedConclusions("e2e:timed_out, build:test:cancelled"),
+			},
+			want: &statusValidator{
+				client:      &mock.Client{},
+				owner:       "test",
+				repo:        "test-repo",
+				ref:         "sha",
+				selfJobName: "job",
+				toleratedConclusions: []toleratedConclusion{
+					{job: "e2e", conclusion: checkRunTimedOutConclusion},
+					{job: "build:test", conclusion: checkRunCancelledConclusion},
+				},
+			},
+			wantErr: false,
+		},
+		"returns error when tolerated conclusion is malformed": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithToleratedConclusions("e2e"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when tolerated conclusion is unknown": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithToleratedConclusions("e2e:flaky"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when job set shrink policy is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithJobSetShrinkPolicy("restart"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when summary template is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithSummaryTemplate("{{ .Total "),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when required job pattern is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithRequiredJobs("e2e-["),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when job timeout is not positive": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithJobTimeouts(map[string]time.Duration{"lint": 0}),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when merge ref is validated without pull request": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithMergeRef(true),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when tolerance window is set without tolerated conclusions": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithToleranceWindow("06:00-10:00"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when minimum number of distinct apps is negative": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithMinDistinctApps(-1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when the new name of a job alias is aliased again": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithJobAliases(map[string]string{"test": "unit-test", "unit-test": "unit"}),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when error state policy is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithErrorStatePolicy("ignore"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when error state retries are set without the retry policy": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithErrorStateRetries(3),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when app conclusion has no conclusion": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithAppConclusions("some-app"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when app conclusion is unknown": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithAppConclusions("some-app:unknown"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when number of stable polls is negative": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithStableConsecutivePolls(-1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when workflow run id is negative": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithWorkflowRunID(-1),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when base branch is checked without pull request": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithBaseBranchCheck(true),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when hung run threshold is negative": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithHungRunThreshold(-time.Minute),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when required description is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithRequiredDescriptions(map[string]string{"license-scan": "0 violations("}),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when ref cache ttl is negative": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithRefCacheTTL(-time.Second),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when commits behind are checked without pull request": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithMaxCommitsBehind(0),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when maximum number of matching jobs is not positive": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithMaxMatchingJobs(map[string]int{"test-shard-*": 0}),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when pattern of matching jobs is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithMaxMatchingJobs(map[string]int{"test-shard-[": 10}),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when head sha mismatch policy is invalid": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithHeadSHAMismatchPolicy("warn"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when stages are duplicated": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithStages("build", "test", "build=build-*"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		"returns error when expected sha is not a full commit sha": {
+			c: &mock.Client{},
+			opts: []Option{
+				WithGitHubOwnerAndRepo("test", "test-repo"),
+				WithGitHubRef("sha"),
+				WithSelfJob("job"),
+				WithExpectedSHA("abc123"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		"returns error when option is empty": {
 			c:       &mock.Client{},
 			want:    nil,
@@ -151,13 +475,23 @@ func TestName(t *testing.T) {
 
 func Test_statusValidator_Validate(t *testing.T) {
 	type test struct {
-		selfJobName string
-		ignoredJobs []string
-		client      github.Client
-		ctx         context.Context
-		wantErr     bool
-		wantErrStr  string
-		wantStatus  validators.Status
+		selfJobName            string
+		selfRunID              string
+		ignoreSelfSuite        bool
+		strictStates           bool
+		ignoredJobs            []string
+		nonBlockingPendingJobs []string
+		requireSelfJob         bool
+		decider                StatusDecider
+		toleratedConclusions   []toleratedConclusion
+		conditionalJobs        []string
+		minDistinctApps        int
+		onErrorState           ErrorStatePolicy
+		client                 github.Client
+		ctx                    context.Context
+		wantErr                bool
+		wantErrStr             string
+		wantStatus             validators.Status
 	}
 	tests := map[string]test{
 		"returns error when listGhaStatuses return an error": {
@@ -186,6 +520,7 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
 				errJobs:      []string{},
+				jobStatuses:  []JobStatus{},
 			},
 		},
 		"returns succeeded status and nil when there is one job, which is itself": {
@@ -212,6 +547,7 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
 				errJobs:      []string{},
+				jobStatuses:  []JobStatus{},
 			},
 		},
 		"returns failed status and nil when there is one job": {
@@ -237,6 +573,9 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{},
 				ignoredJobs:  []string{},
 				errJobs:      []string{},
+				jobStatuses: []JobStatus{
+					{Job: "job", State: pendingState, Source: JobSourceCommitStatus},
+				},
 			},
 		},
 		"returns error when there is a failed job": {
@@ -354,6 +693,10 @@ func Test_statusValidator_Validate(t *testing.T) {
 				},
 				errJobs:     []string{},
 				ignoredJobs: []string{},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+					{Job: "job-02", State: pendingState, Source: JobSourceCommitStatus},
+				},
 			},
 		},
 		"returns succeeded status and nil when validation is success": {
@@ -394,6 +737,10 @@ func Test_statusValidator_Validate(t *testing.T) {
 				},
 				errJobs:     []string{},
 				ignoredJobs: []string{},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+					{Job: "job-02", State: successState, Source: JobSourceCommitStatus},
+				},
 			},
 		},
 		"returns succeeded status and nil when only an ignored job is failing": {
@@ -429,6 +776,9 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{"job-01"},
 				errJobs:      []string{},
 				ignoredJobs:  []string{"job-02", "job-03"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+				},
 			},
 		},
 		"returns succeeded status and nil when only an ignored job is failing, with failure state": {
@@ -464,480 +814,2192 @@ func Test_statusValidator_Validate(t *testing.T) {
 				completeJobs: []string{"job-01"},
 				errJobs:      []string{},
 				ignoredJobs:  []string{"job-02", "job-03"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+				},
 			},
 		},
-	}
-	for name, tt := range tests {
-		t.Run(name, func(t *testing.T) {
-			sv := &statusValidator{
-				selfJobName: tt.selfJobName,
-				ignoredJobs: tt.ignoredJobs,
-				client:      tt.client,
-			}
-			got, err := sv.Validate(tt.ctx)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if tt.wantErr {
-				if err.Error() != tt.wantErrStr {
-					t.Errorf("statusValidator.Validate() error.Error() = %s, wantErrStr %s", err.Error(), tt.wantErrStr)
-				}
-			}
-			if !reflect.DeepEqual(got, tt.wantStatus) {
-				t.Errorf("statusValidator.Validate() status = %v, want %v", got, tt.wantStatus)
-			}
-		})
-	}
-}
-
-func Test_statusValidator_listStatues(t *testing.T) {
-	type fields struct {
-		repo        string
-		owner       string
-		ref         string
-		selfJobName string
-		client      github.Client
-	}
-	type test struct {
-		fields  fields
-		ctx     context.Context
-		wantErr bool
-		want    []*ghaStatus
-	}
-	tests := map[string]test{
-		"succeeds to get job statuses even if the same job exists": func() test {
-			c := &mock.Client{
+		"returns succeeded status and nil when runs in the self check suite are pending": {
+			selfJobName:     "self-job",
+			ignoreSelfSuite: true,
+			client: &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					return &github.CombinedStatus{
-						Statuses: []*github.RepoStatus{
-							// The first element here is the latest state.
-							{
-								Context: stringPtr("job-01"),
-								State:   stringPtr(successState),
-							},
-							{
-								Context: stringPtr("job-01"), // Same as above job name, and thus should be disregarded as old job status.
-								State:   stringPtr(errorState),
-							},
-						},
-					}, nil, nil
+					return &github.CombinedStatus{}, nil, nil
 				},
 				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
 					return &github.ListCheckRunsResults{
 						CheckRuns: []*github.CheckRun{
-							// The first element here is the latest state.
-							{
-								Name:   stringPtr("job-02"),
-								Status: stringPtr("failure"),
-							},
 							{
-								Name:       stringPtr("job-02"), // Same as above job name, and thus should be disregarded as old job status.
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunNeutralConclusion),
+								Name:       stringPtr("self-job"),
+								Status:     stringPtr("in_progress"),
+								CheckSuite: &github.CheckSuite{ID: int64Ptr(1)},
 							},
 							{
-								Name:       stringPtr("job-03"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunNeutralConclusion),
+								Name:       stringPtr("Merge Gatekeeper"),
+								Status:     stringPtr("in_progress"),
+								CheckSuite: &github.CheckSuite{ID: int64Ptr(1)},
 							},
 							{
-								Name:       stringPtr("job-04"),
+								Name:       stringPtr("job-01"),
 								Status:     stringPtr(checkRunCompletedStatus),
 								Conclusion: stringPtr(checkRunSuccessConclusion),
-							},
-							{
-								Name:       stringPtr("job-05"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr("failure"),
-							},
-							{
-								Name:       stringPtr("job-06"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunSkipConclusion),
+								CheckSuite: &github.CheckSuite{ID: int64Ptr(2)},
 							},
 						},
 					}, nil, nil
 				},
-			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
-				},
-				wantErr: false,
-				want: []*ghaStatus{
-					{
-						Job:   "job-01",
-						State: successState,
-					},
-					{
-						Job:   "job-02",
-						State: pendingState,
-					},
-					{
-						Job:   "job-03",
-						State: successState,
-					},
-					{
-						Job:   "job-04",
-						State: successState,
-					},
-					{
-						Job:   "job-05",
-						State: errorState,
-					},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCheckRun, CheckSuiteID: 2},
 				},
-			}
-		}(),
-		"returns error when the GetCombinedStatus returns an error": func() test {
-			c := &mock.Client{
+			},
+		},
+		"returns succeeded status and nil when the self check suite is detected by the workflow run": {
+			selfJobName:     "self-job",
+			selfRunID:       "123",
+			ignoreSelfSuite: true,
+			client: &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					return nil, nil, errors.New("err")
-				},
-			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
+					return &github.CombinedStatus{}, nil, nil
 				},
-				wantErr: true,
-			}
-		}(),
-		"returns error when the GetCombinedStatus response is invalid": func() test {
-			c := &mock.Client{
-				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					return &github.CombinedStatus{
-						Statuses: []*github.RepoStatus{
-							{},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("Custom Name for Merge Gatekeeper"),
+								Status:     stringPtr("in_progress"),
+								DetailsURL: stringPtr("https://github.com/test-owner/test-repo/actions/runs/123/job/456"),
+								CheckSuite: &github.CheckSuite{ID: int64Ptr(1)},
+							},
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								DetailsURL: stringPtr("https://github.com/test-owner/test-repo/actions/runs/1234/job/789"),
+								CheckSuite: &github.CheckSuite{ID: int64Ptr(2)},
+							},
 						},
 					}, nil, nil
 				},
-			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCheckRun, DetailsURL: "https://github.com/test-owner/test-repo/actions/runs/1234/job/789", CheckSuiteID: 2},
 				},
-				wantErr: true,
-			}
-		}(),
-		"returns error when the ListCheckRunsForRef returns an error": func() test {
-			c := &mock.Client{
+			},
+		},
+		"returns failed status and nil when the self check suite is not ignored": {
+			selfJobName: "self-job",
+			client: &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
 					return &github.CombinedStatus{}, nil, nil
 				},
 				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					return nil, nil, errors.New("error")
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("self-job"),
+								Status:     stringPtr("in_progress"),
+								CheckSuite: &github.CheckSuite{ID: int64Ptr(1)},
+							},
+							{
+								Name:       stringPtr("Merge Gatekeeper"),
+								Status:     stringPtr("in_progress"),
+								CheckSuite: &github.CheckSuite{ID: int64Ptr(1)},
+							},
+						},
+					}, nil, nil
 				},
-			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"Merge Gatekeeper"},
+				completeJobs: []string{},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				jobStatuses: []JobStatus{
+					{Job: "Merge Gatekeeper", State: pendingState, Source: JobSourceCheckRun, CheckSuiteID: 1},
 				},
-				wantErr: true,
-			}
-		}(),
-		"returns error when the ListCheckRunsForRef response is invalid": func() test {
-			c := &mock.Client{
+			},
+		},
+		"returns error when there is a job in an unknown state with strict states": {
+			selfJobName:  "self-job",
+			strictStates: true,
+			client: &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
 					return &github.CombinedStatus{}, nil, nil
 				},
 				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
 					return &github.ListCheckRunsResults{
 						CheckRuns: []*github.CheckRun{
-							{},
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+							},
+							{
+								Name:       stringPtr("job-02"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("brand_new_conclusion"),
+							},
 						},
 					}, nil, nil
 				},
-			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
-				},
-				wantErr: true,
-			}
-		}(),
-		"returns nil when no error occurs": func() test {
-			c := &mock.Client{
+			},
+			wantErr: true,
+			wantErrStr: (&status{
+				totalJobs:     []string{"job-01", "job-02"},
+				completeJobs:  []string{"job-01"},
+				errJobs:       []string{"job-02"},
+				ignoredJobs:   []string{},
+				unknownStates: []string{`job-02: conclusion "brand_new_conclusion"`},
+			}).Detail(),
+		},
+		"returns failed status and nil when there is a commit status in an unknown state without strict states": {
+			selfJobName: "self-job",
+			client: &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
 					return &github.CombinedStatus{
 						Statuses: []*github.RepoStatus{
 							{
 								Context: stringPtr("job-01"),
-								State:   stringPtr(successState),
+								State:   stringPtr("brand_new_state"),
 							},
 						},
 					}, nil, nil
 				},
 				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					return &github.ListCheckRunsResults{
-						CheckRuns: []*github.CheckRun{
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: "brand_new_state", Source: JobSourceCommitStatus, Unknown: "state \"brand_new_state\""},
+				},
+			},
+		},
+		"returns pending status and nil when a required commit status is expected but not reported yet": {
+			selfJobName:  "self-job",
+			strictStates: true,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
 							{
-								Name:   stringPtr("job-02"),
-								Status: stringPtr("failure"),
+								Context: stringPtr("job-01"),
+								State:   stringPtr(expectedState),
 							},
 							{
-								Name:       stringPtr("job-03"),
-								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunNeutralConclusion),
+								Context: stringPtr("job-02"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-02"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: pendingState, Source: JobSourceCommitStatus},
+					{Job: "job-02", State: successState, Source: JobSourceCommitStatus},
+				},
+			},
+		},
+		"returns status with sorted jobs regardless of the input order": {
+			selfJobName: "self-job",
+			ignoredJobs: []string{"job-06", "job-05"},
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-04"),
+								State:   stringPtr(pendingState),
 							},
 							{
-								Name:       stringPtr("job-04"),
+								Context: stringPtr("job-02"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-03"),
 								Status:     stringPtr(checkRunCompletedStatus),
 								Conclusion: stringPtr(checkRunSuccessConclusion),
 							},
 							{
-								Name:       stringPtr("job-05"),
+								Name:   stringPtr("job-01"),
+								Status: stringPtr(checkRunQueuedStatus),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01", "job-02", "job-03", "job-04"},
+				completeJobs: []string{"job-02", "job-03"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{"job-05", "job-06"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: pendingState, Source: JobSourceCheckRun},
+					{Job: "job-02", State: successState, Source: JobSourceCommitStatus},
+					{Job: "job-03", State: successState, Source: JobSourceCheckRun},
+					{Job: "job-04", State: pendingState, Source: JobSourceCommitStatus},
+				},
+			},
+		},
+		"returns succeeded status and nil when a non-blocking job is pending forever": {
+			selfJobName:            "self-job",
+			nonBlockingPendingJobs: []string{"abandoned"},
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("abandoned"),
+								State:   stringPtr(pendingState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{"abandoned"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+				},
+			},
+		},
+		"returns error when a non-blocking pending job reaches error state": {
+			selfJobName:            "self-job",
+			nonBlockingPendingJobs: []string{"abandoned"},
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("abandoned"),
+								State:   stringPtr(errorState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: true,
+			wantErrStr: (&status{
+				totalJobs:    []string{"abandoned", "job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{"abandoned"},
+				ignoredJobs:  []string{},
+			}).Detail(),
+		},
+		"returns error when the self job is not found with require self job": {
+			selfJobName:    "self-job",
+			requireSelfJob: true,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr:    true,
+			wantErrStr: fmt.Errorf("%w self job: %s", ErrSelfJobNotFound, "self-job").Error(),
+		},
+		"returns succeeded status and nil when the self job is found with require self job": {
+			selfJobName:    "self-job",
+			requireSelfJob: true,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("self-job"),
+								State:   stringPtr(pendingState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+				},
+			},
+		},
+		"returns succeeded status and nil when the custom decider allows the failed job": {
+			selfJobName: "self-job",
+			decider: deciderFunc(func(statuses []JobStatus) (Decision, string) {
+				for _, st := range statuses {
+					if st.Job != "job-02" && st.State != successState {
+						return DecisionPending, "job-01 is not completed"
+					}
+				}
+				return DecisionSuccess, "job-02 is allowed to fail"
+			}),
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("job-02"),
+								State:   stringPtr(failureState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{"job-02"},
+				ignoredJobs:  []string{},
+				notes:        []string{"Decision reason: job-02 is allowed to fail"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+					{Job: "job-02", State: failureState, Source: JobSourceCommitStatus},
+				},
+			},
+		},
+		"returns succeeded status and nil when the job concludes with the tolerated conclusion": {
+			selfJobName: "self-job",
+			toleratedConclusions: []toleratedConclusion{
+				{job: "job-01", conclusion: checkRunTimedOutConclusion},
+			},
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
 								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr("failure"),
+								Conclusion: stringPtr(checkRunTimedOutConclusion),
 							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				notes:        []string{`job-01 concluded with tolerated conclusion "timed_out"`},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCheckRun, Tolerated: "timed_out"},
+				},
+			},
+		},
+		"returns error when the job concludes with a failure other than the tolerated conclusion": {
+			selfJobName: "self-job",
+			toleratedConclusions: []toleratedConclusion{
+				{job: "job-01", conclusion: checkRunTimedOutConclusion},
+				{job: "job-02", conclusion: checkRunFailureConclusion},
+			},
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
 							{
-								Name:       stringPtr("job-06"),
+								Name:       stringPtr("job-01"),
 								Status:     stringPtr(checkRunCompletedStatus),
-								Conclusion: stringPtr(checkRunSkipConclusion),
+								Conclusion: stringPtr(checkRunFailureConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: true,
+			wantErrStr: (&status{
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{},
+				errJobs:      []string{"job-01"},
+				ignoredJobs:  []string{},
+			}).Detail(),
+		},
+		"returns succeeded status and nil when a conditional job is not reported": {
+			selfJobName:     "self-job",
+			conditionalJobs: []string{"docs-lint"},
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:      true,
+				totalJobs:      []string{"job-01"},
+				completeJobs:   []string{"job-01"},
+				errJobs:        []string{},
+				ignoredJobs:    []string{},
+				unreportedJobs: []string{"docs-lint"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+				},
+			},
+		},
+		"returns error when a reported conditional job has failed": {
+			selfJobName:     "self-job",
+			conditionalJobs: []string{"docs-*"},
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("docs-lint"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunFailureConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: true,
+			wantErrStr: (&status{
+				totalJobs:    []string{"docs-lint", "job-01"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{"docs-lint"},
+				ignoredJobs:  []string{},
+			}).Detail(),
+		},
+		"returns succeeded status and nil when the successful checks are posted by enough distinct apps": {
+			selfJobName:     "self-job",
+			minDistinctApps: 2,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								App:        &github.App{Slug: stringPtr("github-actions")},
+							},
+							{
+								Name:       stringPtr("job-02"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								App:        &github.App{Slug: stringPtr("circleci-checks")},
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01", "job-02"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				appSummaries: []string{"circleci-checks: 1/1", "github-actions: 1/1"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCheckRun, App: "github-actions"},
+					{Job: "job-02", State: successState, Source: JobSourceCheckRun, App: "circleci-checks"},
+				},
+			},
+		},
+		"returns error when the successful checks are posted by fewer distinct apps than required": {
+			selfJobName:     "self-job",
+			minDistinctApps: 2,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-02"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								App:        &github.App{Slug: stringPtr("github-actions")},
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: true,
+			wantErrStr: (&status{
+				succeeded:    true,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01", "job-02"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				appSummaries: []string{"commit statuses: 1/1", "github-actions: 1/1"},
+				notes:        []string{"Successful checks are posted by 1 distinct app(s), fewer than the required 2"},
+			}).Detail(),
+		},
+		"returns pending status and nil regardless of the distinct apps while a job is pending": {
+			selfJobName:     "self-job",
+			minDistinctApps: 2,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								App:        &github.App{Slug: stringPtr("github-actions")},
+							},
+							{
+								Name:   stringPtr("job-02"),
+								Status: stringPtr(checkRunInProgressStatus),
+								App:    &github.App{Slug: stringPtr("circleci-checks")},
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				appSummaries: []string{"circleci-checks: 0/1", "github-actions: 1/1"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCheckRun, App: "github-actions"},
+					{Job: "job-02", State: pendingState, Source: JobSourceCheckRun, App: "circleci-checks"},
+				},
+			},
+		},
+		"returns pending status and nil when a commit status is in error state with the retry policy": {
+			selfJobName:  "self-job",
+			onErrorState: ErrorStateRetry,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("job-02"),
+								State:   stringPtr(errorState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: false,
+			wantStatus: &status{
+				succeeded:    false,
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{},
+				ignoredJobs:  []string{},
+				notes:        []string{"job-02 is in error state, waiting for it to be retried"},
+				jobStatuses: []JobStatus{
+					{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+					{Job: "job-02", State: pendingState, Source: JobSourceCommitStatus},
+				},
+			},
+		},
+		"returns error when a commit status is in failure state with the retry policy": {
+			selfJobName:  "self-job",
+			onErrorState: ErrorStateRetry,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("job-02"),
+								State:   stringPtr(failureState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			},
+			wantErr: true,
+			wantErrStr: (&status{
+				totalJobs:    []string{"job-01", "job-02"},
+				completeJobs: []string{"job-01"},
+				errJobs:      []string{"job-02"},
+				ignoredJobs:  []string{},
+			}).Detail(),
+		},
+		"returns error when a check run fails with the retry policy": {
+			selfJobName:  "self-job",
+			onErrorState: ErrorStateRetry,
+			client: &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunCancelledConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			},
+			wantErr: true,
+			wantErrStr: (&status{
+				totalJobs:    []string{"job-01"},
+				completeJobs: []string{},
+				errJobs:      []string{"job-01"},
+				ignoredJobs:  []string{},
+			}).Detail(),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:            tt.selfJobName,
+				selfRunID:              tt.selfRunID,
+				ignoreSelfSuite:        tt.ignoreSelfSuite,
+				strictStates:           tt.strictStates,
+				ignoredJobs:            tt.ignoredJobs,
+				nonBlockingPendingJobs: tt.nonBlockingPendingJobs,
+				requireSelfJob:         tt.requireSelfJob,
+				decider:                tt.decider,
+				toleratedConclusions:   tt.toleratedConclusions,
+				conditionalJobs:        tt.conditionalJobs,
+				minDistinctApps:        tt.minDistinctApps,
+				onErrorState:           tt.onErrorState,
+				client:                 tt.client,
+			}
+			// The poll is traced under the context, which thus must not be nil.
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			got, err := sv.Validate(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.wantErrStr {
+					t.Errorf("statusValidator.Validate() error.Error() = %s, wantErrStr %s", err.Error(), tt.wantErrStr)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("statusValidator.Validate() status = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_listStatues(t *testing.T) {
+	type fields struct {
+		repo        string
+		owner       string
+		ref         string
+		selfJobName string
+		client      github.Client
+	}
+	type test struct {
+		fields  fields
+		ctx     context.Context
+		wantErr bool
+		want    []*ghaStatus
+	}
+	tests := map[string]test{
+		"succeeds to get job statuses even if the same job exists": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							// The first element here is the latest state.
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+							{
+								Context: stringPtr("job-01"), // Same as above job name, and thus should be disregarded as old job status.
+								State:   stringPtr(errorState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							// The first element here is the latest state.
+							{
+								Name:   stringPtr("job-02"),
+								Status: stringPtr("failure"),
+							},
+							{
+								Name:       stringPtr("job-02"), // Same as above job name, and thus should be disregarded as old job status.
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunNeutralConclusion),
+							},
+							{
+								Name:       stringPtr("job-03"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunNeutralConclusion),
+							},
+							{
+								Name:       stringPtr("job-04"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+							},
+							{
+								Name:       stringPtr("job-05"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("failure"),
+							},
+							{
+								Name:       stringPtr("job-06"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSkipConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want: []*ghaStatus{
+					{
+						Job:    "job-01",
+						Source: JobSourceCommitStatus,
+						State:  successState,
+					},
+					{
+						Job:      "job-02",
+						Source:   JobSourceCheckRun,
+						State:    pendingState,
+						Unknown:  `status "failure"`,
+						Attempts: 2,
+					},
+					{
+						Job:    "job-03",
+						Source: JobSourceCheckRun,
+						State:  successState,
+					},
+					{
+						Job:    "job-04",
+						Source: JobSourceCheckRun,
+						State:  successState,
+					},
+					{
+						Job:    "job-05",
+						Source: JobSourceCheckRun,
+						State:  errorState,
+					},
+				},
+			}
+		}(),
+		"uses the newest check run when a check is re-requested": func() test {
+			startedAt := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							// Old passing runs are returned first, while the re-requested ones are yet to complete.
+							{
+								Name:       stringPtr("job-01"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								StartedAt:  &github.Timestamp{Time: startedAt},
+							},
+							{
+								Name:   stringPtr("job-01"),
+								Status: stringPtr(checkRunQueuedStatus),
+							},
+							{
+								Name:       stringPtr("job-02"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								StartedAt:  &github.Timestamp{Time: startedAt},
+							},
+							{
+								Name:      stringPtr("job-02"),
+								Status:    stringPtr(checkRunInProgressStatus),
+								StartedAt: &github.Timestamp{Time: startedAt.Add(5 * time.Minute)},
+							},
+							{
+								Name:       stringPtr("job-03"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+								StartedAt:  &github.Timestamp{Time: startedAt.Add(5 * time.Minute)},
+							},
+							{
+								Name:       stringPtr("job-03"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunFailureConclusion),
+								StartedAt:  &github.Timestamp{Time: startedAt},
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want: []*ghaStatus{
+					{
+						Job:      "job-01",
+						Source:   JobSourceCheckRun,
+						State:    pendingState,
+						Attempts: 2,
+					},
+					{
+						Job:       "job-02",
+						Source:    JobSourceCheckRun,
+						StartedAt: startedAt.Add(5 * time.Minute),
+						State:     pendingState,
+						Attempts:  2,
+					},
+					{
+						Job:       "job-03",
+						Source:    JobSourceCheckRun,
+						StartedAt: startedAt.Add(5 * time.Minute),
+						State:     successState,
+						Attempts:  2,
+					},
+				},
+			}
+		}(),
+		"returns error when the GetCombinedStatus returns an error": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return nil, nil, errors.New("err")
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: true,
+			}
+		}(),
+		"returns error when the GetCombinedStatus response is invalid": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: true,
+			}
+		}(),
+		"returns error when the ListCheckRunsForRef returns an error": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return nil, nil, errors.New("error")
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: true,
+			}
+		}(),
+		"returns error when the ListCheckRunsForRef response is invalid": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: true,
+			}
+		}(),
+		"returns nil when no error occurs": func() test {
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					return &github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{
+								Context: stringPtr("job-01"),
+								State:   stringPtr(successState),
+							},
+						},
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					return &github.ListCheckRunsResults{
+						CheckRuns: []*github.CheckRun{
+							{
+								Name:   stringPtr("job-02"),
+								Status: stringPtr("failure"),
+							},
+							{
+								Name:       stringPtr("job-03"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunNeutralConclusion),
+							},
+							{
+								Name:       stringPtr("job-04"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSuccessConclusion),
+							},
+							{
+								Name:       stringPtr("job-05"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr("failure"),
+							},
+							{
+								Name:       stringPtr("job-06"),
+								Status:     stringPtr(checkRunCompletedStatus),
+								Conclusion: stringPtr(checkRunSkipConclusion),
+							},
+						},
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want: []*ghaStatus{
+					{
+						Job:    "job-01",
+						Source: JobSourceCommitStatus,
+						State:  successState,
+					},
+					{
+						Job:     "job-02",
+						Source:  JobSourceCheckRun,
+						State:   pendingState,
+						Unknown: `status "failure"`,
+					},
+					{
+						Job:    "job-03",
+						Source: JobSourceCheckRun,
+						State:  successState,
+					},
+					{
+						Job:    "job-04",
+						Source: JobSourceCheckRun,
+						State:  successState,
+					},
+					{
+						Job:    "job-05",
+						Source: JobSourceCheckRun,
+						State:  errorState,
+					},
+				},
+			}
+		}(),
+		"succeeds to retrieve 100 statuses": func() test {
+			num_statuses := 100
+			statuses := make([]*github.RepoStatus, num_statuses)
+			checkRuns := make([]*github.CheckRun, num_statuses)
+			expectedGhaStatuses := make([]*ghaStatus, num_statuses)
+			for i := 0; i < num_statuses; i++ {
+				statuses[i] = &github.RepoStatus{
+					Context: stringPtr(fmt.Sprintf("job-%d", i)),
+					State:   stringPtr(successState),
+				}
+
+				checkRuns[i] = &github.CheckRun{
+					Name:       stringPtr(fmt.Sprintf("job-%d", i)),
+					Status:     stringPtr(checkRunCompletedStatus),
+					Conclusion: stringPtr(checkRunNeutralConclusion),
+				}
+
+				expectedGhaStatuses[i] = &ghaStatus{
+					Job:    fmt.Sprintf("job-%d", i),
+					Source: JobSourceCommitStatus,
+					State:  successState,
+				}
+			}
+
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					max := min(opts.Page*opts.PerPage, len(statuses))
+					sts := statuses[min((opts.Page-1)*opts.PerPage, max):max]
+					l := len(sts)
+					return &github.CombinedStatus{
+						Statuses:   sts,
+						TotalCount: &l,
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					max := min(opts.ListOptions.Page*opts.ListOptions.PerPage, len(checkRuns))
+					sts := checkRuns[min((opts.ListOptions.Page-1)*opts.ListOptions.PerPage, max):max]
+					l := len(sts)
+					return &github.ListCheckRunsResults{
+						CheckRuns: checkRuns,
+						Total:     &l,
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want:    expectedGhaStatuses,
+			}
+		}(),
+		"succeeds to retrieve 162 statuses": func() test {
+			num_statuses := 162
+			statuses := make([]*github.RepoStatus, num_statuses)
+			checkRuns := make([]*github.CheckRun, num_statuses)
+			expectedGhaStatuses := make([]*ghaStatus, num_statuses)
+			for i := 0; i < num_statuses; i++ {
+				statuses[i] = &github.RepoStatus{
+					Context: stringPtr(fmt.Sprintf("job-%d", i)),
+					State:   stringPtr(successState),
+				}
+
+				checkRuns[i] = &github.CheckRun{
+					Name:       stringPtr(fmt.Sprintf("job-%d", i)),
+					Status:     stringPtr(checkRunCompletedStatus),
+					Conclusion: stringPtr(checkRunNeutralConclusion),
+				}
+
+				expectedGhaStatuses[i] = &ghaStatus{
+					Job:    fmt.Sprintf("job-%d", i),
+					Source: JobSourceCommitStatus,
+					State:  successState,
+				}
+			}
+
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					max := min(opts.Page*opts.PerPage, len(statuses))
+					sts := statuses[min((opts.Page-1)*opts.PerPage, max):max]
+					l := len(sts)
+					return &github.CombinedStatus{
+						Statuses:   sts,
+						TotalCount: &l,
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					max := min(opts.ListOptions.Page*opts.ListOptions.PerPage, len(checkRuns))
+					sts := checkRuns[min((opts.ListOptions.Page-1)*opts.ListOptions.PerPage, max):max]
+					l := len(sts)
+					return &github.ListCheckRunsResults{
+						CheckRuns: checkRuns,
+						Total:     &l,
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want:    expectedGhaStatuses,
+			}
+		}(),
+		"succeeds to retrieve 587 statuses": func() test {
+			num_statuses := 587
+			statuses := make([]*github.RepoStatus, num_statuses)
+			checkRuns := make([]*github.CheckRun, num_statuses)
+			expectedGhaStatuses := make([]*ghaStatus, num_statuses)
+			for i := 0; i < num_statuses; i++ {
+				statuses[i] = &github.RepoStatus{
+					Context: stringPtr(fmt.Sprintf("job-%d", i)),
+					State:   stringPtr(successState),
+				}
+
+				checkRuns[i] = &github.CheckRun{
+					Name:       stringPtr(fmt.Sprintf("job-%d", i)),
+					Status:     stringPtr(checkRunCompletedStatus),
+					Conclusion: stringPtr(checkRunNeutralConclusion),
+				}
+
+				expectedGhaStatuses[i] = &ghaStatus{
+					Job:    fmt.Sprintf("job-%d", i),
+					Source: JobSourceCommitStatus,
+					State:  successState,
+				}
+			}
+
+			c := &mock.Client{
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					max := min(opts.Page*opts.PerPage, len(statuses))
+					sts := statuses[min((opts.Page-1)*opts.PerPage, max):max]
+					l := len(sts)
+					return &github.CombinedStatus{
+						Statuses:   sts,
+						TotalCount: &l,
+					}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					max := min(opts.ListOptions.Page*opts.ListOptions.PerPage, len(checkRuns))
+					sts := checkRuns[min((opts.ListOptions.Page-1)*opts.ListOptions.PerPage, max):max]
+					l := len(sts)
+					return &github.ListCheckRunsResults{
+						CheckRuns: checkRuns,
+						Total:     &l,
+					}, nil, nil
+				},
+			}
+			return test{
+				fields: fields{
+					client:      c,
+					selfJobName: "self-job",
+					owner:       "test-owner",
+					repo:        "test-repo",
+					ref:         "main",
+				},
+				wantErr: false,
+				want:    expectedGhaStatuses,
+			}
+		}(),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				repo:        tt.fields.repo,
+				owner:       tt.fields.owner,
+				ref:         tt.fields.ref,
+				selfJobName: tt.fields.selfJobName,
+				client:      tt.fields.client,
+			}
+			got, err := sv.listGhaStatuses(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("statusValidator.listStatuses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got, want := len(got), len(tt.want); got != want {
+				t.Errorf("statusValidator.listStatuses() length = %v, want %v", got, want)
+			}
+			for i := range tt.want {
+				if !reflect.DeepEqual(got[i], tt.want[i]) {
+					t.Errorf("statusValidator.listStatuses() - %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_refNotFound(t *testing.T) {
+	tests := map[string]struct {
+		code      int
+		wantErrIs bool
+	}{
+		"returns ErrRefNotFound when the api returns 404": {
+			code:      http.StatusNotFound,
+			wantErrIs: true,
+		},
+		"returns other error when the api returns 403": {
+			code:      http.StatusForbidden,
+			wantErrIs: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				owner: "test-owner",
+				repo:  "test-repo",
+				ref:   "missing",
+				client: &mock.Client{
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						return nil, &github.Response{Response: &http.Response{StatusCode: tt.code}}, errors.New("err")
+					},
+				},
+			}
+			_, err := sv.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("statusValidator.Validate() error = nil, want error")
+			}
+			if got := errors.Is(err, ErrRefNotFound); got != tt.wantErrIs {
+				t.Errorf("errors.Is(err, ErrRefNotFound) = %v, want %v", got, tt.wantErrIs)
+			}
+			if tt.wantErrIs && !strings.Contains(err.Error(), "test-owner") {
+				t.Errorf("statusValidator.Validate() error = %v, want it to include the owner", err)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_resolveCommitSHA(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	tests := map[string]struct {
+		ref        string
+		resolveRef bool
+		client     github.Client
+		wantSHA    string
+		wantErr    bool
+	}{
+		"resolves branch name to commit sha": {
+			ref:        "main",
+			resolveRef: true,
+			client: &mock.Client{
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					return sha, nil, nil
+				},
+			},
+			wantSHA: sha,
+		},
+		"does not call api when ref is already commit sha": {
+			ref:        sha,
+			resolveRef: true,
+			client:     &mock.Client{},
+			wantSHA:    sha,
+		},
+		"does not resolve when resolution is disabled": {
+			ref:     "main",
+			client:  &mock.Client{},
+			wantSHA: "",
+		},
+		"returns error when resolved sha is empty": {
+			ref:        "main",
+			resolveRef: true,
+			client: &mock.Client{
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					return "", nil, nil
+				},
+			},
+			wantErr: true,
+		},
+		"returns error when api returns an error": {
+			ref:        "main",
+			resolveRef: true,
+			client: &mock.Client{
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					return "", nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				ref:        tt.ref,
+				resolveRef: tt.resolveRef,
+				client:     tt.client,
+			}
+			err := sv.resolveCommitSHA(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("statusValidator.resolveCommitSHA() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if sv.sha != tt.wantSHA {
+				t.Errorf("statusValidator.resolveCommitSHA() sha = %s, want %s", sv.sha, tt.wantSHA)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_usesResolvedSHA(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	var resolveCnt int
+	var gotRefs []string
+	sv := &statusValidator{
+		ref:        "main",
+		resolveRef: true,
+		client: &mock.Client{
+			GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+				resolveCnt++
+				return sha, nil, nil
+			},
+			GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+				gotRefs = append(gotRefs, ref)
+				return &github.CombinedStatus{}, nil, nil
+			},
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				gotRefs = append(gotRefs, ref)
+				return &github.ListCheckRunsResults{}, nil, nil
+			},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := sv.Validate(context.Background()); err != nil {
+			t.Fatalf("statusValidator.Validate() unexpected error: %v", err)
+		}
+	}
+	if resolveCnt != 1 {
+		t.Errorf("GetCommitSHA1 call count = %d, want 1", resolveCnt)
+	}
+	for _, ref := range gotRefs {
+		if ref != sha {
+			t.Errorf("statusValidator.Validate() used ref %s, want %s", ref, sha)
+		}
+	}
+}
+
+func Test_statusValidator_Validate_postSuccessReverify(t *testing.T) {
+	type poll struct {
+		elapsed       time.Duration
+		state         string
+		wantSucceeded bool
+	}
+	tests := map[string][]poll{
+		"succeeds only after the green result is sustained": {
+			{elapsed: 0, state: successState, wantSucceeded: false},
+			{elapsed: 10 * time.Second, state: successState, wantSucceeded: false},
+			{elapsed: 30 * time.Second, state: successState, wantSucceeded: true},
+		},
+		"restarts the reverification when the result is no longer green": {
+			{elapsed: 0, state: successState, wantSucceeded: false},
+			{elapsed: 20 * time.Second, state: pendingState, wantSucceeded: false},
+			{elapsed: 40 * time.Second, state: successState, wantSucceeded: false},
+			{elapsed: 70 * time.Second, state: successState, wantSucceeded: true},
+		},
+	}
+	for name, polls := range tests {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			var cur poll
+			sv := &statusValidator{
+				selfJobName:   "self-job",
+				reverifyAfter: 30 * time.Second,
+				clock:         func() time.Time { return start.Add(cur.elapsed) },
+				client: &mock.Client{
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						return &github.CombinedStatus{
+							Statuses: []*github.RepoStatus{
+								{
+									Context: stringPtr("job-01"),
+									State:   stringPtr(cur.state),
+								},
+							},
+						}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{}, nil, nil
+					},
+				},
+			}
+			for i, p := range polls {
+				cur = p
+				got, err := sv.Validate(context.Background())
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() #%d unexpected error: %v", i, err)
+				}
+				if got.IsSuccess() != p.wantSucceeded {
+					t.Errorf("statusValidator.Validate() #%d IsSuccess() = %v, want %v", i, got.IsSuccess(), p.wantSucceeded)
+				}
+			}
+		})
+	}
+}
+
+func Test_statusValidator_listGhaStatuses_pageConcurrency(t *testing.T) {
+	const numStatuses = 587
+	const concurrency = 3
+
+	statuses := make([]*github.RepoStatus, numStatuses)
+	for i := 0; i < numStatuses; i++ {
+		statuses[i] = &github.RepoStatus{
+			Context: stringPtr(fmt.Sprintf("job-%d", i)),
+			State:   stringPtr(successState),
+		}
+	}
+
+	var inFlight, maxInFlight int32
+	sv := &statusValidator{
+		pageConcurrency: concurrency,
+		client: &mock.Client{
+			GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					cur := atomic.LoadInt32(&maxInFlight)
+					if n <= cur || atomic.CompareAndSwapInt32(&maxInFlight, cur, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+
+				max := min(opts.Page*opts.PerPage, len(statuses))
+				sts := statuses[min((opts.Page-1)*opts.PerPage, max):max]
+				l := len(sts)
+				return &github.CombinedStatus{
+					Statuses:   sts,
+					TotalCount: &l,
+				}, nil, nil
+			},
+			ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+				return &github.ListCheckRunsResults{}, nil, nil
+			},
+		},
+	}
+
+	got, err := sv.listGhaStatuses(context.Background())
+	if err != nil {
+		t.Fatalf("statusValidator.listGhaStatuses() unexpected error: %v", err)
+	}
+	if len(got) != numStatuses {
+		t.Fatalf("statusValidator.listGhaStatuses() length = %d, want %d", len(got), numStatuses)
+	}
+	for i, st := range got {
+		if want := fmt.Sprintf("job-%d", i); st.Job != want {
+			t.Errorf("statusValidator.listGhaStatuses() - %d = %s, want %s", i, st.Job, want)
+		}
+	}
+	if maxInFlight > concurrency {
+		t.Errorf("max concurrent requests = %d, want <= %d", maxInFlight, concurrency)
+	}
+}
+
+func Test_statusValidator_listGhaStatuses_requireCompletedRuns(t *testing.T) {
+	tests := map[string]struct {
+		requireCompletedRuns bool
+		statuses             []*github.RepoStatus
+		runs                 []*github.CheckRun
+		want                 []*ghaStatus
+	}{
+		"returns pending for an in progress run with a success conclusion": {
+			runs: []*github.CheckRun{
+				{
+					Name:       stringPtr("job-01"),
+					Status:     stringPtr(checkRunInProgressStatus),
+					Conclusion: stringPtr(checkRunSuccessConclusion),
+				},
+			},
+			want: []*ghaStatus{
+				{Job: "job-01", State: pendingState, Source: JobSourceCheckRun},
+			},
+		},
+		"returns success of the commit status for an in progress run of the same name by default": {
+			statuses: []*github.RepoStatus{
+				{
+					Context: stringPtr("job-01"),
+					State:   stringPtr(successState),
+				},
+			},
+			runs: []*github.CheckRun{
+				{
+					Name:   stringPtr("job-01"),
+					Status: stringPtr(checkRunInProgressStatus),
+				},
+			},
+			want: []*ghaStatus{
+				{Job: "job-01", State: successState, Source: JobSourceCommitStatus},
+			},
+		},
+		"returns pending for an in progress run shadowed by a successful commit status when completed runs are required": {
+			requireCompletedRuns: true,
+			statuses: []*github.RepoStatus{
+				{
+					Context: stringPtr("job-01"),
+					State:   stringPtr(successState),
+				},
+				{
+					Context: stringPtr("job-02"),
+					State:   stringPtr(successState),
+				},
+			},
+			runs: []*github.CheckRun{
+				{
+					Name:       stringPtr("job-01"),
+					Status:     stringPtr(checkRunInProgressStatus),
+					Conclusion: stringPtr(checkRunSuccessConclusion),
+				},
+				{
+					Name:       stringPtr("job-02"),
+					Status:     stringPtr(checkRunCompletedStatus),
+					Conclusion: stringPtr(checkRunSuccessConclusion),
+				},
+			},
+			want: []*ghaStatus{
+				{Job: "job-01", State: pendingState, Source: JobSourceCommitStatus},
+				{Job: "job-02", State: successState, Source: JobSourceCommitStatus},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				requireCompletedRuns: tt.requireCompletedRuns,
+				client: &mock.Client{
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						return &github.CombinedStatus{Statuses: tt.statuses}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{CheckRuns: tt.runs}, nil, nil
+					},
+				},
+			}
+			got, err := sv.listGhaStatuses(context.Background())
+			if err != nil {
+				t.Fatalf("statusValidator.listGhaStatuses() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("statusValidator.listGhaStatuses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_stableConsecutivePolls(t *testing.T) {
+	type poll struct {
+		statuses      map[string]string
+		wantSucceeded bool
+	}
+	tests := map[string][]poll{
+		"succeeds only after the identical green snapshot is observed for consecutive polls": {
+			{statuses: map[string]string{"job-01": successState}, wantSucceeded: false},
+			{statuses: map[string]string{"job-01": successState}, wantSucceeded: true},
+		},
+		"keeps waiting when a late job appears after an apparent all green": {
+			{statuses: map[string]string{"job-01": successState}, wantSucceeded: false},
+			{statuses: map[string]string{"job-01": successState, "job-02": successState}, wantSucceeded: false},
+			{statuses: map[string]string{"job-01": successState, "job-02": successState}, wantSucceeded: true},
+		},
+		"restarts counting when a late job is pending": {
+			{statuses: map[string]string{"job-01": successState}, wantSucceeded: false},
+			{statuses: map[string]string{"job-01": successState, "job-02": pendingState}, wantSucceeded: false},
+			{statuses: map[string]string{"job-01": successState, "job-02": successState}, wantSucceeded: false},
+			{statuses: map[string]string{"job-01": successState, "job-02": successState}, wantSucceeded: true},
+		},
+	}
+	for name, polls := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cur poll
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				stablePolls: 2,
+				client: &mock.Client{
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						statuses := make([]*github.RepoStatus, 0, len(cur.statuses))
+						for job, state := range cur.statuses {
+							statuses = append(statuses, &github.RepoStatus{
+								Context: stringPtr(job),
+								State:   stringPtr(state),
+							})
+						}
+						return &github.CombinedStatus{Statuses: statuses}, nil, nil
+					},
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						return &github.ListCheckRunsResults{}, nil, nil
+					},
+				},
+			}
+			for i, p := range polls {
+				cur = p
+				got, err := sv.Validate(context.Background())
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() #%d unexpected error: %v", i, err)
+				}
+				if got.IsSuccess() != p.wantSucceeded {
+					t.Errorf("statusValidator.Validate() #%d IsSuccess() = %v, want %v", i, got.IsSuccess(), p.wantSucceeded)
+				}
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_skipUnchangedPolls(t *testing.T) {
+	notModified := &github.Response{Response: &http.Response{
+		Header: http.Header{http.CanonicalHeaderKey(github.CacheStatusHeader): []string{"hit"}},
+	}}
+	modified := &github.Response{Response: &http.Response{Header: http.Header{}}}
+
+	type poll struct {
+		unchanged     bool
+		state         string
+		wantUnchanged bool
+	}
+	tests := map[string]struct {
+		skipUnchangedPolls bool
+		polls              []poll
+	}{
+		"reuses the pending status while the responses are unchanged": {
+			skipUnchangedPolls: true,
+			polls: []poll{
+				{unchanged: false, state: pendingState, wantUnchanged: false},
+				{unchanged: true, state: pendingState, wantUnchanged: true},
+				{unchanged: true, state: pendingState, wantUnchanged: true},
+				{unchanged: false, state: successState, wantUnchanged: false},
+			},
+		},
+		"does not reuse the status for the first poll": {
+			skipUnchangedPolls: true,
+			polls: []poll{
+				{unchanged: true, state: pendingState, wantUnchanged: false},
+			},
+		},
+		"recomputes the status without skipping unchanged polls": {
+			skipUnchangedPolls: false,
+			polls: []poll{
+				{unchanged: false, state: pendingState, wantUnchanged: false},
+				{unchanged: true, state: pendingState, wantUnchanged: false},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cur poll
+			sv := &statusValidator{
+				selfJobName:        "self-job",
+				skipUnchangedPolls: tt.skipUnchangedPolls,
+				client: &mock.Client{
+					GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+						resp := modified
+						if cur.unchanged {
+							resp = notModified
+						}
+						return &github.CombinedStatus{
+							Statuses: []*github.RepoStatus{
+								{
+									Context: stringPtr("job-01"),
+									State:   stringPtr(cur.state),
+								},
 							},
-						},
-					}, nil, nil
-				},
-			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
-				},
-				wantErr: false,
-				want: []*ghaStatus{
-					{
-						Job:   "job-01",
-						State: successState,
+						}, resp, nil
 					},
-					{
-						Job:   "job-02",
-						State: pendingState,
-					},
-					{
-						Job:   "job-03",
-						State: successState,
-					},
-					{
-						Job:   "job-04",
-						State: successState,
-					},
-					{
-						Job:   "job-05",
-						State: errorState,
+					ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+						resp := modified
+						if cur.unchanged {
+							resp = notModified
+						}
+						return &github.ListCheckRunsResults{}, resp, nil
 					},
 				},
 			}
-		}(),
-		"succeeds to retrieve 100 statuses": func() test {
-			num_statuses := 100
-			statuses := make([]*github.RepoStatus, num_statuses)
-			checkRuns := make([]*github.CheckRun, num_statuses)
-			expectedGhaStatuses := make([]*ghaStatus, num_statuses)
-			for i := 0; i < num_statuses; i++ {
-				statuses[i] = &github.RepoStatus{
-					Context: stringPtr(fmt.Sprintf("job-%d", i)),
-					State:   stringPtr(successState),
+			for i, p := range tt.polls {
+				cur = p
+				got, err := sv.Validate(context.Background())
+				if err != nil {
+					t.Fatalf("statusValidator.Validate() #%d unexpected error: %v", i, err)
 				}
-
-				checkRuns[i] = &github.CheckRun{
-					Name:       stringPtr(fmt.Sprintf("job-%d", i)),
-					Status:     stringPtr(checkRunCompletedStatus),
-					Conclusion: stringPtr(checkRunNeutralConclusion),
+				if unchanged := got.(*status).Unchanged(); unchanged != p.wantUnchanged {
+					t.Errorf("statusValidator.Validate() #%d Unchanged() = %v, want %v", i, unchanged, p.wantUnchanged)
 				}
+			}
+		})
+	}
+}
 
-				expectedGhaStatuses[i] = &ghaStatus{
-					Job:   fmt.Sprintf("job-%d", i),
-					State: successState,
-				}
+func Test_statusValidator_Validate_partialResults(t *testing.T) {
+	numRuns := 150
+	runs := make([]*github.CheckRun, numRuns)
+	for i := 0; i < numRuns; i++ {
+		runs[i] = &github.CheckRun{
+			Name:       stringPtr(fmt.Sprintf("job-%d", i)),
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(checkRunSuccessConclusion),
+		}
+	}
+	client := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			// The second page fails after the first one succeeded.
+			if opts.Page != 1 {
+				return nil, nil, errors.New("page failed")
 			}
+			return &github.ListCheckRunsResults{
+				Total:     &numRuns,
+				CheckRuns: runs[:maxCheckRunsPerPage],
+			}, nil, nil
+		},
+	}
 
-			c := &mock.Client{
+	tests := map[string]struct {
+		allowPartialResults bool
+		wantErr             bool
+	}{
+		"returns error when the partial results are not allowed": {
+			allowPartialResults: false,
+			wantErr:             true,
+		},
+		"returns pending status computed from the partial data when the partial results are allowed": {
+			allowPartialResults: true,
+			wantErr:             false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName:         "self-job",
+				allowPartialResults: tt.allowPartialResults,
+				client:              client,
+			}
+			got, err := sv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.IsSuccess() {
+				t.Errorf("statusValidator.Validate() IsSuccess() = true, want false")
+			}
+			if !strings.Contains(got.Detail(), "computed from partial data") {
+				t.Errorf("statusValidator.Validate() detail does not indicate partial data:\n%s", got.Detail())
+			}
+			if !strings.Contains(got.Detail(), fmt.Sprintf("%d out of %d", maxCheckRunsPerPage, maxCheckRunsPerPage)) {
+				t.Errorf("statusValidator.Validate() detail does not count the fetched jobs:\n%s", got.Detail())
+			}
+		})
+	}
+}
+
+func Test_statusValidator_Validate_onlyJobs(t *testing.T) {
+	runs := []*github.CheckRun{
+		{
+			Name:       stringPtr("lint"),
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(checkRunSuccessConclusion),
+		},
+		{
+			Name:       stringPtr("e2e"),
+			Status:     stringPtr(checkRunCompletedStatus),
+			Conclusion: stringPtr(checkRunFailureConclusion),
+		},
+	}
+
+	tests := map[string]struct {
+		onlyJobs       []string
+		requiredJobs   []string
+		wantCheckNames []string
+		wantSuccess    bool
+	}{
+		"filters the check runs by name on the server for a few jobs": {
+			onlyJobs:       []string{"lint"},
+			wantCheckNames: []string{"lint"},
+			wantSuccess:    true,
+		},
+		"filters the check runs by the required jobs as well": {
+			onlyJobs:       []string{"lint"},
+			requiredJobs:   []string{"e2e"},
+			wantCheckNames: []string{"e2e", "lint"},
+			wantSuccess:    true,
+		},
+		"fetches all the check runs to match a pattern of the required jobs": {
+			onlyJobs:       []string{"lint"},
+			requiredJobs:   []string{"e2e*"},
+			wantCheckNames: []string{""},
+			wantSuccess:    true,
+		},
+		"fetches all the check runs when too many jobs are validated": {
+			onlyJobs:       []string{"lint", "unit", "build", "docs"},
+			wantCheckNames: []string{""},
+			wantSuccess:    true,
+		},
+		"fetches all the check runs when all jobs are validated": {
+			wantCheckNames: []string{""},
+			wantSuccess:    false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotCheckNames []string
+			client := &mock.Client{
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					max := min(opts.Page*opts.PerPage, len(statuses))
-					sts := statuses[(opts.Page-1)*opts.PerPage : max]
-					l := len(sts)
-					return &github.CombinedStatus{
-						Statuses:   sts,
-						TotalCount: &l,
-					}, nil, nil
+					return &github.CombinedStatus{}, nil, nil
 				},
 				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					max := min(opts.ListOptions.Page*opts.ListOptions.PerPage, len(checkRuns))
-					sts := checkRuns[(opts.ListOptions.Page-1)*opts.ListOptions.PerPage : max]
-					l := len(sts)
+					var filtered []*github.CheckRun
+					if opts.CheckName == nil {
+						gotCheckNames = append(gotCheckNames, "")
+						filtered = runs
+					} else {
+						gotCheckNames = append(gotCheckNames, *opts.CheckName)
+						for _, run := range runs {
+							if run.GetName() == *opts.CheckName {
+								filtered = append(filtered, run)
+							}
+						}
+					}
+					total := len(filtered)
 					return &github.ListCheckRunsResults{
-						CheckRuns: checkRuns,
-						Total:     &l,
+						Total:     &total,
+						CheckRuns: filtered,
 					}, nil, nil
 				},
 			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
-				},
-				wantErr: false,
-				want:    expectedGhaStatuses,
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				onlyJobs:    tt.onlyJobs,
+				client:      client,
 			}
-		}(),
-		"succeeds to retrieve 162 statuses": func() test {
-			num_statuses := 162
-			statuses := make([]*github.RepoStatus, num_statuses)
-			checkRuns := make([]*github.CheckRun, num_statuses)
-			expectedGhaStatuses := make([]*ghaStatus, num_statuses)
-			for i := 0; i < num_statuses; i++ {
-				statuses[i] = &github.RepoStatus{
-					Context: stringPtr(fmt.Sprintf("job-%d", i)),
-					State:   stringPtr(successState),
-				}
-
-				checkRuns[i] = &github.CheckRun{
-					Name:       stringPtr(fmt.Sprintf("job-%d", i)),
-					Status:     stringPtr(checkRunCompletedStatus),
-					Conclusion: stringPtr(checkRunNeutralConclusion),
+			for _, pattern := range tt.requiredJobs {
+				p, err := parseJobPattern(pattern)
+				if err != nil {
+					t.Fatalf("parseJobPattern() error = %v", err)
 				}
-
-				expectedGhaStatuses[i] = &ghaStatus{
-					Job:   fmt.Sprintf("job-%d", i),
-					State: successState,
+				sv.requiredJobs = append(sv.requiredJobs, p)
+			}
+			got, err := sv.Validate(context.Background())
+			if tt.wantSuccess {
+				if err != nil || !got.IsSuccess() {
+					t.Errorf("statusValidator.Validate() error = %v, want success", err)
 				}
+			} else if err == nil {
+				t.Errorf("statusValidator.Validate() error = nil, want failure")
+			}
+			if !reflect.DeepEqual(gotCheckNames, tt.wantCheckNames) {
+				t.Errorf("statusValidator.Validate() requested check names = %v, want %v", gotCheckNames, tt.wantCheckNames)
 			}
+		})
+	}
+}
 
-			c := &mock.Client{
-				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					max := min(opts.Page*opts.PerPage, len(statuses))
-					sts := statuses[(opts.Page-1)*opts.PerPage : max]
-					l := len(sts)
-					return &github.CombinedStatus{
-						Statuses:   sts,
-						TotalCount: &l,
-					}, nil, nil
+func Test_statusValidator_Validate_jobStatuses(t *testing.T) {
+	client := &mock.Client{
+		GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+			return &github.CombinedStatus{
+				Statuses: []*github.RepoStatus{
+					{
+						Context:   stringPtr("job-02"),
+						State:     stringPtr(failureState),
+						TargetURL: stringPtr("https://ci.example.com/job-02"),
+					},
+					{
+						Context: stringPtr("job-03"),
+						State:   stringPtr(successState),
+					},
 				},
-				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					max := min(opts.ListOptions.Page*opts.ListOptions.PerPage, len(checkRuns))
-					sts := checkRuns[(opts.ListOptions.Page-1)*opts.ListOptions.PerPage : max]
-					l := len(sts)
-					return &github.ListCheckRunsResults{
-						CheckRuns: checkRuns,
-						Total:     &l,
-					}, nil, nil
+			}, nil, nil
+		},
+		ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+			return &github.ListCheckRunsResults{
+				CheckRuns: []*github.CheckRun{
+					{
+						Name:   stringPtr("job-01"),
+						Status: stringPtr(checkRunInProgressStatus),
+					},
 				},
+			}, nil, nil
+		},
+	}
+
+	tests := map[string]struct {
+		ignoredJobs     []string
+		wantErr         bool
+		wantJobStatuses []JobStatus
+		wantIgnoredJobs []string
+	}{
+		"returns the job statuses sorted by name from the failure": {
+			wantErr: true,
+			wantJobStatuses: []JobStatus{
+				{Job: "job-01", State: pendingState, Source: JobSourceCheckRun},
+				{Job: "job-02", State: failureState, Source: JobSourceCommitStatus, DetailsURL: "https://ci.example.com/job-02"},
+				{Job: "job-03", State: successState, Source: JobSourceCommitStatus},
+			},
+			wantIgnoredJobs: []string{},
+		},
+		"returns the job statuses excluding the ignored jobs from the status": {
+			ignoredJobs: []string{"job-02"},
+			wantErr:     false,
+			wantJobStatuses: []JobStatus{
+				{Job: "job-01", State: pendingState, Source: JobSourceCheckRun},
+				{Job: "job-03", State: successState, Source: JobSourceCommitStatus},
+			},
+			wantIgnoredJobs: []string{"job-02"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv := &statusValidator{
+				selfJobName: "self-job",
+				ignoredJobs: tt.ignoredJobs,
+				client:      client,
 			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
-				},
-				wantErr: false,
-				want:    expectedGhaStatuses,
+			got, err := sv.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statusValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
-		}(),
-		"succeeds to retrieve 587 statuses": func() test {
-			num_statuses := 587
-			statuses := make([]*github.RepoStatus, num_statuses)
-			checkRuns := make([]*github.CheckRun, num_statuses)
-			expectedGhaStatuses := make([]*ghaStatus, num_statuses)
-			for i := 0; i < num_statuses; i++ {
-				statuses[i] = &github.RepoStatus{
-					Context: stringPtr(fmt.Sprintf("job-%d", i)),
-					State:   stringPtr(successState),
-				}
-
-				checkRuns[i] = &github.CheckRun{
-					Name:       stringPtr(fmt.Sprintf("job-%d", i)),
-					Status:     stringPtr(checkRunCompletedStatus),
-					Conclusion: stringPtr(checkRunNeutralConclusion),
-				}
 
-				expectedGhaStatuses[i] = &ghaStatus{
-					Job:   fmt.Sprintf("job-%d", i),
-					State: successState,
+			var r JobResultReporter
+			if tt.wantErr {
+				if !errors.As(err, &r) {
+					t.Fatalf("statusValidator.Validate() error = %v, want JobResultReporter", err)
 				}
+			} else {
+				r = got.(JobResultReporter)
 			}
+			if !reflect.DeepEqual(r.JobStatuses(), tt.wantJobStatuses) {
+				t.Errorf("JobStatuses() = %+v, want %+v", r.JobStatuses(), tt.wantJobStatuses)
+			}
+			if !reflect.DeepEqual(r.IgnoredJobs(), tt.wantIgnoredJobs) {
+				t.Errorf("IgnoredJobs() = %v, want %v", r.IgnoredJobs(), tt.wantIgnoredJobs)
+			}
+		})
+	}
+}
 
+func Test_statusValidator_Validate_tracerProvider(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	tests := map[string]struct {
+		buildState    string
+		wantErr       bool
+		wantSuccess   bool
+		wantCompleted int64
+		wantFailed    int64
+	}{
+		"traces the successful poll": {
+			buildState:    successState,
+			wantSuccess:   true,
+			wantCompleted: 2,
+		},
+		"traces the failed poll": {
+			buildState:    errorState,
+			wantErr:       true,
+			wantCompleted: 1,
+			wantFailed:    1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
 			c := &mock.Client{
+				GetCommitSHA1Func: func(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error) {
+					return sha, nil, nil
+				},
 				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
-					max := min(opts.Page*opts.PerPage, len(statuses))
-					sts := statuses[(opts.Page-1)*opts.PerPage : max]
-					l := len(sts)
+					// The API call of a traced client is a child of the poll.
+					_, span := tp.Tracer("test").Start(ctx, "GetCombinedStatus")
+					span.End()
 					return &github.CombinedStatus{
-						Statuses:   sts,
-						TotalCount: &l,
+						Statuses: []*github.RepoStatus{
+							{Context: stringPtr("self-job"), State: stringPtr(pendingState)},
+							{Context: stringPtr("build"), State: stringPtr(tt.buildState)},
+							{Context: stringPtr("lint"), State: stringPtr(successState)},
+						},
 					}, nil, nil
 				},
 				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
-					max := min(opts.ListOptions.Page*opts.ListOptions.PerPage, len(checkRuns))
-					sts := checkRuns[(opts.ListOptions.Page-1)*opts.ListOptions.PerPage : max]
-					l := len(sts)
-					return &github.ListCheckRunsResults{
-						CheckRuns: checkRuns,
-						Total:     &l,
-					}, nil, nil
+					return &github.ListCheckRunsResults{}, nil, nil
 				},
 			}
-			return test{
-				fields: fields{
-					client:      c,
-					selfJobName: "self-job",
-					owner:       "test-owner",
-					repo:        "test-repo",
-					ref:         "main",
-				},
-				wantErr: false,
-				want:    expectedGhaStatuses,
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithRefResolution(true),
+				WithSelfJob("self-job"),
+				WithTracerProvider(tp),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
 			}
-		}(),
-	}
-	for name, tt := range tests {
-		t.Run(name, func(t *testing.T) {
-			sv := &statusValidator{
-				repo:        tt.fields.repo,
-				owner:       tt.fields.owner,
-				ref:         tt.fields.ref,
-				selfJobName: tt.fields.selfJobName,
-				client:      tt.fields.client,
+			if _, err := v.Validate(context.Background()); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			got, err := sv.listGhaStatuses(tt.ctx)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("statusValidator.listStatuses() error = %v, wantErr %v", err, tt.wantErr)
+
+			spans := recorder.Ended()
+			if len(spans) != 2 {
+				t.Fatalf("Ended() = %d spans, want the API call and the poll", len(spans))
 			}
-			if got, want := len(got), len(tt.want); got != want {
-				t.Errorf("statusValidator.listStatuses() length = %v, want %v", got, want)
+			call, poll := spans[0], spans[1]
+			if poll.Name() != "poll" || call.Parent().SpanID() != poll.SpanContext().SpanID() {
+				t.Fatalf("Ended() = %s child of %s, want the API call to be the child of the poll", call.Name(), poll.Name())
 			}
-			for i := range tt.want {
-				if !reflect.DeepEqual(got[i], tt.want[i]) {
-					t.Errorf("statusValidator.listStatuses() - %d = %v, want %v", i, got[i], tt.want[i])
+			want := map[attribute.Key]attribute.Value{
+				"github.ref":     attribute.StringValue("main"),
+				"github.sha":     attribute.StringValue(sha),
+				"jobs.total":     attribute.Int64Value(2),
+				"jobs.completed": attribute.Int64Value(tt.wantCompleted),
+				"jobs.failed":    attribute.Int64Value(tt.wantFailed),
+				"success":        attribute.BoolValue(tt.wantSuccess),
+			}
+			got := make(map[attribute.Key]attribute.Value)
+			for _, attr := range poll.Attributes() {
+				got[attr.Key] = attr.Value
+			}
+			for key, value := range want {
+				if got[key] != value {
+					t.Errorf("poll %s = %v, want %v", key, got[key].Emit(), value.Emit())
 				}
 			}
+			if failed := poll.Status().Code == codes.Error; failed != tt.wantErr {
+				t.Errorf("poll status = %v, wantErr %v", poll.Status(), tt.wantErr)
+			}
 		})
 	}
 }
@@ -0,0 +1,96 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+)
+
+const (
+	// maxWorkflowJobsPerPage is the maximum number of the jobs of a workflow run returned in a page.
+	maxWorkflowJobsPerPage = 100
+
+	// workflowJobsLatestFilter returns only the jobs of the latest attempt, so that the jobs of the re-run
+	// attempts are not mixed with the ones they replace.
+	workflowJobsLatestFilter = "latest"
+
+	// workflowJobApp is the app posting the check runs of the workflow jobs.
+	workflowJobApp = "github-actions"
+)
+
+// listWorkflowRunStatuses returns the statuses of the jobs of the workflow run, along with how they have been
+// fetched. The jobs are check runs posted by GitHub Actions, and thus they are mapped in the same way.
+func (sv *statusValidator) listWorkflowRunStatuses(ctx context.Context) ([]*ghaStatus, fetchInfo, error) {
+	run, resp, err := sv.client.GetWorkflowRunByID(ctx, sv.owner, sv.repo, sv.workflowRunID)
+	if err != nil {
+		return nil, fetchInfo{}, fmt.Errorf("failed to get workflow run %d: %w", sv.workflowRunID, err)
+	}
+	sv.workflowRun = run
+	runUnchanged := github.IsNotModified(resp)
+
+	var mu sync.Mutex
+	pages := make(map[int][]*github.WorkflowJob)
+	unchanged := true
+	n, err := fetchPages(ctx, sv.pageConcurrency, func(ctx context.Context, page int) (bool, error) {
+		jobs, resp, err := sv.client.ListWorkflowJobs(ctx, sv.owner, sv.repo, sv.workflowRunID, &github.ListWorkflowJobsOptions{
+			Filter: workflowJobsLatestFilter,
+			ListOptions: github.ListOptions{
+				Page:    page,
+				PerPage: maxWorkflowJobsPerPage,
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+		mu.Lock()
+		pages[page] = jobs.Jobs
+		unchanged = unchanged && github.IsNotModified(resp)
+		mu.Unlock()
+		return page*maxWorkflowJobsPerPage >= jobs.GetTotalCount(), nil
+	})
+	info, err := sv.fetchInfo(runUnchanged && unchanged, err)
+	if err != nil {
+		return nil, fetchInfo{}, fmt.Errorf("failed to list jobs of workflow run %d: %w", sv.workflowRunID, err)
+	}
+
+	ghaStatuses := make([]*ghaStatus, 0, len(pages[1]))
+	for page := 1; page <= n; page++ {
+		for _, job := range pages[page] {
+			if job.Name == nil || job.Status == nil {
+				return nil, fetchInfo{}, fmt.Errorf("%w name: %v, status: %v", ErrInvalidCheckRunResponse, job.Name, job.Status)
+			}
+			ghaStatus := &ghaStatus{
+				Job:         sv.canonicalJob(*job.Name),
+				Source:      JobSourceCheckRun,
+				App:         workflowJobApp,
+				DetailsURL:  job.GetHTMLURL(),
+				StartedAt:   job.GetStartedAt().Time,
+				CompletedAt: job.GetCompletedAt().Time,
+			}
+			if !sv.setCheckRunState(ghaStatus, *job.Status, job.GetConclusion()) {
+				continue
+			}
+			ghaStatuses = append(ghaStatuses, ghaStatus)
+		}
+	}
+	return ghaStatuses, info, nil
+}
+
+// workflowRunNote reports the overall status of the validated workflow run, e.g. "Workflow run CI #42 is completed
+// with conclusion failure".
+func (sv *statusValidator) workflowRunNote() string {
+	if sv.workflowRun == nil {
+		return ""
+	}
+	run := sv.workflowRun
+	name := fmt.Sprintf("%s #%d", run.GetName(), run.GetRunNumber())
+	if len(run.GetName()) == 0 {
+		name = fmt.Sprintf("%d", sv.workflowRunID)
+	}
+	if run.GetStatus() != checkRunCompletedStatus {
+		return fmt.Sprintf("Workflow run %s has no conclusion yet, as it is %s", name, run.GetStatus())
+	}
+	return fmt.Sprintf("Workflow run %s is completed with conclusion %s", name, run.GetConclusion())
+}
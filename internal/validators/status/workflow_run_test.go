@@ -0,0 +1,157 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/upsidr/merge-gatekeeper/internal/github"
+	"github.com/upsidr/merge-gatekeeper/internal/github/mock"
+)
+
+func Test_statusValidator_Validate_workflowRun(t *testing.T) {
+	tests := map[string]struct {
+		run          *github.WorkflowRun
+		jobs         []*github.WorkflowJob
+		wantErr      bool
+		wantComplete []string
+		wantErrJobs  []string
+		wantNote     string
+	}{
+		"succeeds when all the jobs of the run have succeeded": {
+			run: &github.WorkflowRun{Name: stringPtr("CI"), RunNumber: intPtr(42), Status: stringPtr("completed"), Conclusion: stringPtr("success")},
+			jobs: []*github.WorkflowJob{
+				{Name: stringPtr("build"), Status: stringPtr("completed"), Conclusion: stringPtr("success")},
+				{Name: stringPtr("test"), Status: stringPtr("completed"), Conclusion: stringPtr("success")},
+				{Name: stringPtr("docs"), Status: stringPtr("completed"), Conclusion: stringPtr("skipped")},
+			},
+			wantComplete: []string{"build", "test"},
+			wantErrJobs:  []string{},
+			wantNote:     "Workflow run CI #42 is completed with conclusion success",
+		},
+		"waits while a job of the run is in progress": {
+			run: &github.WorkflowRun{Name: stringPtr("CI"), RunNumber: intPtr(42), Status: stringPtr("in_progress")},
+			jobs: []*github.WorkflowJob{
+				{Name: stringPtr("build"), Status: stringPtr("completed"), Conclusion: stringPtr("success")},
+				{Name: stringPtr("test"), Status: stringPtr("in_progress")},
+			},
+			wantComplete: []string{"build"},
+			wantErrJobs:  []string{},
+			wantNote:     "Workflow run CI #42 has no conclusion yet, as it is in_progress",
+		},
+		"fails when a job of the run has failed": {
+			run: &github.WorkflowRun{Name: stringPtr("CI"), RunNumber: intPtr(42), Status: stringPtr("completed"), Conclusion: stringPtr("failure")},
+			jobs: []*github.WorkflowJob{
+				{Name: stringPtr("build"), Status: stringPtr("completed"), Conclusion: stringPtr("success")},
+				{Name: stringPtr("test"), Status: stringPtr("completed"), Conclusion: stringPtr("failure"), HTMLURL: stringPtr("https://github.com/test-owner/test-repo/actions/runs/1234/job/2")},
+			},
+			wantErr:      true,
+			wantComplete: []string{"build"},
+			wantErrJobs:  []string{"test"},
+			wantNote:     "Workflow run CI #42 is completed with conclusion failure",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mock.Client{
+				GetWorkflowRunByIDFunc: func(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+					if runID != 1234 {
+						t.Errorf("GetWorkflowRunByID() runID = %d, want 1234", runID)
+					}
+					return tt.run, nil, nil
+				},
+				ListWorkflowJobsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error) {
+					if runID != 1234 || opts.Filter != "latest" {
+						t.Errorf("ListWorkflowJobs() runID = %d, opts = %+v, want the latest jobs of 1234", runID, opts)
+					}
+					return &github.Jobs{TotalCount: intPtr(len(tt.jobs)), Jobs: tt.jobs}, nil, nil
+				},
+				GetCombinedStatusFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+					t.Error("GetCombinedStatus() is called, want only the jobs of the workflow run")
+					return &github.CombinedStatus{}, nil, nil
+				},
+				ListCheckRunsForRefFunc: func(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+					t.Error("ListCheckRunsForRef() is called, want only the jobs of the workflow run")
+					return &github.ListCheckRunsResults{}, nil, nil
+				},
+			}
+			v, err := CreateValidator(c,
+				WithGitHubOwnerAndRepo("test-owner", "test-repo"),
+				WithGitHubRef("main"),
+				WithSelfJob("self-job"),
+				WithWorkflowRunID(1234),
+			)
+			if err != nil {
+				t.Fatalf("CreateValidator() error = %v", err)
+			}
+
+			got, err := v.Validate(context.Background())
+			var st *status
+			if tt.wantErr {
+				var fe *failureError
+				if !errors.As(err, &fe) {
+					t.Fatalf("Validate() error = %v, want failure", err)
+				}
+				st = fe.status
+			} else {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				st = got.(*status)
+			}
+			if !reflect.DeepEqual(st.completeJobs, tt.wantComplete) {
+				t.Errorf("Validate() completeJobs = %v, want %v", st.completeJobs, tt.wantComplete)
+			}
+			if !reflect.DeepEqual(st.errJobs, tt.wantErrJobs) {
+				t.Errorf("Validate() errJobs = %v, want %v", st.errJobs, tt.wantErrJobs)
+			}
+			if !containsJob(st.notes, tt.wantNote) {
+				t.Errorf("Validate() notes = %v, want %q", st.notes, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_statusValidator_listWorkflowRunStatuses_pages(t *testing.T) {
+	jobs := make([]*github.WorkflowJob, 0, maxWorkflowJobsPerPage+1)
+	for i := 0; i < maxWorkflowJobsPerPage+1; i++ {
+		jobs = append(jobs, &github.WorkflowJob{Name: stringPtr(fmt.Sprintf("job-%03d", i)), Status: stringPtr("queued")})
+	}
+	sv := &statusValidator{
+		owner:         "test-owner",
+		repo:          "test-repo",
+		workflowRunID: 1234,
+		client: &mock.Client{
+			GetWorkflowRunByIDFunc: func(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+				return &github.WorkflowRun{Status: stringPtr("queued")}, nil, nil
+			},
+			ListWorkflowJobsFunc: func(ctx context.Context, owner, repo string, runID int64, opts *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error) {
+				start := (opts.Page - 1) * opts.PerPage
+				if start >= len(jobs) {
+					return &github.Jobs{TotalCount: intPtr(len(jobs))}, nil, nil
+				}
+				end := start + opts.PerPage
+				if end > len(jobs) {
+					end = len(jobs)
+				}
+				return &github.Jobs{TotalCount: intPtr(len(jobs)), Jobs: jobs[start:end]}, nil, nil
+			},
+		},
+	}
+
+	got, _, err := sv.listWorkflowRunStatuses(context.Background())
+	if err != nil {
+		t.Fatalf("listWorkflowRunStatuses() error = %v", err)
+	}
+	if len(got) != len(jobs) {
+		t.Errorf("listWorkflowRunStatuses() returned %d jobs, want %d", len(got), len(jobs))
+	}
+	if got[0].State != pendingState || got[0].App != "github-actions" {
+		t.Errorf("listWorkflowRunStatuses() = %+v, want a pending job of github-actions", got[0])
+	}
+	if want := "Workflow run 1234 has no conclusion yet, as it is queued"; sv.workflowRunNote() != want {
+		t.Errorf("workflowRunNote() = %q, want %q", sv.workflowRunNote(), want)
+	}
+}